@@ -0,0 +1,418 @@
+// Package config loads the configuration of a global manager from a YAML or JSON file and GRO_*
+// environment variables, and can watch the file to apply changes while the process runs.
+//
+// The file covers the settings of types.Config:
+//
+//	shutdown_timeout: 30s
+//	max_routines: 1000
+//	metrics:
+//	  address: ":9090"
+//	  interval: 5s
+//	apps:
+//	  ingestion:
+//	    max_routines: 200
+//	    shutdown_timeout: 60s
+//	    defaults:
+//	      timeout: 5m
+//	    locals:
+//	      workers:
+//	        max_routines: 50
+//	functions:
+//	  fetch:
+//	    timeout: 30s
+//	    max_concurrent: 10
+//
+// Environment variables override the file (see the Env constants). Settings missing from both keep
+// the value of types.DefaultConfig, so removing a setting from a watched file resets it.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+	"go.yaml.in/yaml/v2"
+)
+
+// Environment variables read by ApplyEnv. Lists are comma separated name=value pairs.
+const (
+	EnvShutdownTimeout = "GRO_SHUTDOWN_TIMEOUT" // 30s
+	EnvMaxRoutines     = "GRO_MAX_ROUTINES"     // 1000
+	EnvMetrics         = "GRO_METRICS"          // true or false
+	EnvMetricsAddr     = "GRO_METRICS_ADDR"     // :9090 (enables metrics unless GRO_METRICS=false)
+	EnvMetricsInterval = "GRO_METRICS_INTERVAL" // 5s
+	// EnvAppMaxRoutines holds app quotas: ingestion=200,http=50
+	EnvAppMaxRoutines = "GRO_APP_MAX_ROUTINES"
+	// EnvLocalMaxRoutines holds local quotas: ingestion/workers=50
+	EnvLocalMaxRoutines = "GRO_LOCAL_MAX_ROUTINES"
+	// EnvAppShutdownTimeout holds app shutdown timeouts: ingestion=60s,http=5s
+	EnvAppShutdownTimeout = "GRO_APP_SHUTDOWN_TIMEOUT"
+	// EnvLocalShutdownTimeout holds local shutdown timeouts: ingestion/workers=30s
+	EnvLocalShutdownTimeout = "GRO_LOCAL_SHUTDOWN_TIMEOUT"
+	// EnvFunctionTimeout holds function timeout defaults: fetch=30s,index=1m
+	EnvFunctionTimeout = "GRO_FUNCTION_TIMEOUT"
+	// EnvFunctionMaxConcurrent holds function concurrency defaults: fetch=10
+	EnvFunctionMaxConcurrent = "GRO_FUNCTION_MAX_CONCURRENT"
+)
+
+// Duration is a time.Duration written as a string ("30s", "1m30s") in YAML and JSON
+type Duration time.Duration
+
+// UnmarshalYAML parses a duration string
+func (D *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+	return D.parse(value)
+}
+
+// UnmarshalJSON parses a duration string
+func (D *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string like \"30s\": %w", err)
+	}
+	return D.parse(value)
+}
+
+// parse parses a duration string
+func (D *Duration) parse(value string) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*D = Duration(duration)
+	return nil
+}
+
+// File is the configuration file format. It can also be a section of an application's own
+// configuration file - decode it there and call Apply.
+type File struct {
+	ShutdownTimeout *Duration           `yaml:"shutdown_timeout" json:"shutdown_timeout"`
+	MaxRoutines     *int                `yaml:"max_routines" json:"max_routines"`
+	Metrics         *Metrics            `yaml:"metrics" json:"metrics"`
+	Apps            map[string]App      `yaml:"apps" json:"apps"`
+	Functions       map[string]Function `yaml:"functions" json:"functions"`
+}
+
+// Metrics is the metrics section of the file. Metrics are enabled when the section is present,
+// unless enabled is false.
+type Metrics struct {
+	Enabled  *bool     `yaml:"enabled" json:"enabled"`
+	Address  string    `yaml:"address" json:"address"` // "" = collector only
+	Interval *Duration `yaml:"interval" json:"interval"`
+}
+
+// App is an entry of the apps section
+type App struct {
+	MaxRoutines     int              `yaml:"max_routines" json:"max_routines"`
+	ShutdownTimeout Duration         `yaml:"shutdown_timeout" json:"shutdown_timeout"`
+	Defaults        Function         `yaml:"defaults" json:"defaults"`
+	Locals          map[string]Local `yaml:"locals" json:"locals"`
+}
+
+// Local is an entry of the locals section of an app
+type Local struct {
+	MaxRoutines     int      `yaml:"max_routines" json:"max_routines"`
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" json:"shutdown_timeout"`
+	Defaults        Function `yaml:"defaults" json:"defaults"`
+}
+
+// Function is an entry of the functions section
+type Function struct {
+	Timeout       Duration `yaml:"timeout" json:"timeout"`
+	MaxConcurrent int      `yaml:"max_concurrent" json:"max_concurrent"`
+}
+
+// Load returns types.DefaultConfig with the file at path (skipped if path is "") and the GRO_*
+// environment variables applied, validated. Errors in the file or the environment wrap ErrInvalidConfig.
+//
+// Example:
+//
+//	cfg, err := config.Load("orchestrator.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	globalMgr.Init(global.WithConfig(cfg))
+func Load(path string) (types.Config, error) {
+	var data []byte
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return types.Config{}, err
+		}
+	}
+	return load(path, data)
+}
+
+// load is Load for the content of the file at path
+func load(path string, data []byte) (types.Config, error) {
+	file, err := Parse(data, isJSON(path))
+	if err != nil {
+		return types.Config{}, err
+	}
+	cfg := types.DefaultConfig()
+	file.Apply(&cfg)
+	if err := ApplyEnv(&cfg); err != nil {
+		return types.Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return types.Config{}, err
+	}
+	return cfg, nil
+}
+
+// ReadFile reads a configuration file - JSON if its extension is .json, YAML otherwise.
+// Unknown settings are rejected.
+func ReadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, isJSON(path))
+}
+
+// isJSON reports whether the file at path is a JSON file
+func isJSON(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// Parse parses a configuration file (JSON if isJSON, YAML otherwise), rejecting unknown settings
+func Parse(data []byte, isJSON bool) (*File, error) {
+	file := &File{}
+	if isJSON {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(file); err != nil {
+			return nil, fmt.Errorf("%w: %v", errors.ErrInvalidConfig, err)
+		}
+		return file, nil
+	}
+	if err := yaml.UnmarshalStrict(data, file); err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidConfig, err)
+	}
+	return file, nil
+}
+
+// Apply sets the settings present in the file on cfg. The apps and functions sections replace
+// those of cfg.
+func (F *File) Apply(cfg *types.Config) {
+	if F.ShutdownTimeout != nil {
+		cfg.ShutdownTimeout = time.Duration(*F.ShutdownTimeout)
+	}
+	if F.MaxRoutines != nil {
+		cfg.MaxRoutines = *F.MaxRoutines
+	}
+	if F.Metrics != nil {
+		cfg.Metrics = F.Metrics.Enabled == nil || *F.Metrics.Enabled
+		cfg.MetricsURL = F.Metrics.Address
+		if F.Metrics.Interval != nil {
+			cfg.MetricsInterval = time.Duration(*F.Metrics.Interval)
+		}
+	}
+	if F.Apps != nil {
+		cfg.Apps = make(map[string]types.AppConfig, len(F.Apps))
+		for appName, app := range F.Apps {
+			appConfig := types.AppConfig{ManagerConfig: types.ManagerConfig{
+				MaxRoutines:     app.MaxRoutines,
+				ShutdownTimeout: time.Duration(app.ShutdownTimeout),
+				Defaults:        app.Defaults.config(),
+			}}
+			if len(app.Locals) > 0 {
+				appConfig.Locals = make(map[string]types.ManagerConfig, len(app.Locals))
+				for localName, local := range app.Locals {
+					appConfig.Locals[localName] = types.ManagerConfig{
+						MaxRoutines:     local.MaxRoutines,
+						ShutdownTimeout: time.Duration(local.ShutdownTimeout),
+						Defaults:        local.Defaults.config(),
+					}
+				}
+			}
+			cfg.Apps[appName] = appConfig
+		}
+	}
+	if F.Functions != nil {
+		cfg.Functions = make(map[string]types.FunctionConfig, len(F.Functions))
+		for functionName, function := range F.Functions {
+			cfg.Functions[functionName] = function.config()
+		}
+	}
+}
+
+// config returns the routine defaults of a functions or defaults entry
+func (F Function) config() types.FunctionConfig {
+	return types.FunctionConfig{Timeout: time.Duration(F.Timeout), MaxConcurrent: F.MaxConcurrent}
+}
+
+// ApplyEnv sets the settings present in the GRO_* environment variables on cfg. App, local and
+// function entries are added to (or replace) those of cfg.
+func ApplyEnv(cfg *types.Config) error {
+	if value, ok := os.LookupEnv(EnvShutdownTimeout); ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return envError(EnvShutdownTimeout, err)
+		}
+		cfg.ShutdownTimeout = timeout
+	}
+	if value, ok := os.LookupEnv(EnvMaxRoutines); ok {
+		maxRoutines, err := strconv.Atoi(value)
+		if err != nil {
+			return envError(EnvMaxRoutines, err)
+		}
+		cfg.MaxRoutines = maxRoutines
+	}
+	if value, ok := os.LookupEnv(EnvMetricsAddr); ok {
+		cfg.Metrics = true
+		cfg.MetricsURL = value
+	}
+	if value, ok := os.LookupEnv(EnvMetrics); ok {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return envError(EnvMetrics, err)
+		}
+		cfg.Metrics = enabled
+	}
+	if value, ok := os.LookupEnv(EnvMetricsInterval); ok {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return envError(EnvMetricsInterval, err)
+		}
+		cfg.MetricsInterval = interval
+	}
+
+	apps := make(map[string]types.AppConfig, len(cfg.Apps))
+	for appName, app := range cfg.Apps {
+		apps[appName] = app
+	}
+	setApp := func(appName string, set func(app *types.ManagerConfig)) {
+		app := apps[appName]
+		set(&app.ManagerConfig)
+		apps[appName] = app
+	}
+	setLocal := func(name string, set func(local *types.ManagerConfig)) error {
+		appName, localName, ok := strings.Cut(name, "/")
+		if !ok {
+			return fmt.Errorf("expected app/local, got %q", name)
+		}
+		app := apps[appName]
+		locals := make(map[string]types.ManagerConfig, len(app.Locals)+1)
+		for name, local := range app.Locals {
+			locals[name] = local
+		}
+		local := locals[localName]
+		set(&local)
+		locals[localName] = local
+		app.Locals = locals
+		apps[appName] = app
+		return nil
+	}
+	if err := parseList(EnvAppMaxRoutines, func(appName, value string) error {
+		maxRoutines, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		setApp(appName, func(app *types.ManagerConfig) { app.MaxRoutines = maxRoutines })
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := parseList(EnvAppShutdownTimeout, func(appName, value string) error {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		setApp(appName, func(app *types.ManagerConfig) { app.ShutdownTimeout = timeout })
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := parseList(EnvLocalMaxRoutines, func(name, value string) error {
+		maxRoutines, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		return setLocal(name, func(local *types.ManagerConfig) { local.MaxRoutines = maxRoutines })
+	}); err != nil {
+		return err
+	}
+	if err := parseList(EnvLocalShutdownTimeout, func(name, value string) error {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		return setLocal(name, func(local *types.ManagerConfig) { local.ShutdownTimeout = timeout })
+	}); err != nil {
+		return err
+	}
+	if len(apps) > 0 {
+		cfg.Apps = apps
+	}
+
+	functions := make(map[string]types.FunctionConfig, len(cfg.Functions))
+	for functionName, function := range cfg.Functions {
+		functions[functionName] = function
+	}
+	if err := parseList(EnvFunctionTimeout, func(functionName, value string) error {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		function := functions[functionName]
+		function.Timeout = timeout
+		functions[functionName] = function
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := parseList(EnvFunctionMaxConcurrent, func(functionName, value string) error {
+		maxConcurrent, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		function := functions[functionName]
+		function.MaxConcurrent = maxConcurrent
+		functions[functionName] = function
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(functions) > 0 {
+		cfg.Functions = functions
+	}
+	return nil
+}
+
+// parseList calls set for each name=value pair of the environment variable key
+func parseList(key string, set func(name, value string) error) error {
+	list, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+	for _, pair := range strings.Split(list, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return envError(key, fmt.Errorf("expected name=value, got %q", pair))
+		}
+		if err := set(strings.TrimSpace(name), strings.TrimSpace(value)); err != nil {
+			return envError(key, err)
+		}
+	}
+	return nil
+}
+
+// envError wraps an invalid environment variable in ErrInvalidConfig
+func envError(key string, err error) error {
+	return fmt.Errorf("%w: %s: %v", errors.ErrInvalidConfig, key, err)
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bytes"
+	goerrors "errors"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// DefaultPollInterval is how often a Watcher checks its file by default
+const DefaultPollInterval = 2 * time.Second
+
+// WatchConfig configures a Watcher
+type WatchConfig struct {
+	// PollInterval is how often the file is checked for changes (default 2s)
+	PollInterval time.Duration
+	// OnReload is called after each reload with the changed settings, or with the error that
+	// rejected the new configuration (the previous configuration stays in effect)
+	OnReload func(changes []types.ConfigChange, err error)
+}
+
+// Watcher polls a configuration file and applies its changes with Reconfigure
+type Watcher struct {
+	path   string
+	target interfaces.Configurer
+	config WatchConfig
+
+	mu   sync.Mutex // serializes reloads
+	last []byte     // content of the file at the last reload
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// Watch polls the file at path and applies the configuration loaded from it (see Load) to target,
+// typically a global manager or an orchestrator, whenever its content changes. The current content
+// is not applied - load it first and pass it to Init. Returns an error if the file cannot be read.
+//
+// Example:
+//
+//	cfg, err := config.Load("orchestrator.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	globalMgr.Init(global.WithConfig(cfg))
+//
+//	watcher, err := config.Watch("orchestrator.yaml", globalMgr, config.WatchConfig{
+//	    OnReload: func(changes []types.ConfigChange, err error) {
+//	        if err != nil {
+//	            log.Printf("Config rejected: %v", err)
+//	        }
+//	    },
+//	})
+//	defer watcher.Stop()
+func Watch(path string, target interfaces.Configurer, config WatchConfig) (*Watcher, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+
+	watcher := &Watcher{
+		path:   path,
+		target: target,
+		config: config,
+		last:   data,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go watcher.run()
+	return watcher, nil
+}
+
+// Reload loads the file and applies it now, also if its content did not change - e.g. from a
+// SignalPolicy.OnReload hook on SIGHUP. Returns the changed settings.
+func (W *Watcher) Reload() ([]types.ConfigChange, error) {
+	W.mu.Lock()
+	defer W.mu.Unlock()
+	data, err := os.ReadFile(W.path)
+	if err != nil {
+		return W.report(nil, err)
+	}
+	return W.reload(data)
+}
+
+// Stop stops polling the file and waits for a reload in progress
+func (W *Watcher) Stop() {
+	W.once.Do(func() { close(W.stop) })
+	<-W.done
+}
+
+// run polls the file until the watcher is stopped
+func (W *Watcher) run() {
+	defer close(W.done)
+	ticker := time.NewTicker(W.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-W.stop:
+			return
+		case <-ticker.C:
+			W.poll()
+		}
+	}
+}
+
+// poll reloads the file if its content changed
+func (W *Watcher) poll() {
+	W.mu.Lock()
+	defer W.mu.Unlock()
+	data, err := os.ReadFile(W.path)
+	if err != nil {
+		// A file being replaced may be missing for a moment - retry on the next poll
+		return
+	}
+	if bytes.Equal(data, W.last) {
+		return
+	}
+	W.reload(data)
+}
+
+// reload applies data as the new configuration. A rejected content is not retried until it changes.
+func (W *Watcher) reload(data []byte) ([]types.ConfigChange, error) {
+	W.last = data
+	cfg, err := load(W.path, data)
+	if err != nil {
+		return W.report(nil, err)
+	}
+	changes, err := W.target.Reconfigure(cfg)
+	return W.report(changes, err)
+}
+
+// report logs and records the result of a reload and passes it to OnReload
+func (W *Watcher) report(changes []types.ConfigChange, err error) ([]types.ConfigChange, error) {
+	if err != nil {
+		reason := "reconfigure_failed"
+		if goerrors.Is(err, errors.ErrInvalidConfig) {
+			reason = "invalid_config"
+		}
+		metrics.RecordOperationError("config", "reload", reason)
+		log.Printf("Config reload of %s rejected: %v", W.path, err)
+	} else if len(changes) > 0 {
+		log.Printf("Config reload of %s changed %d settings", W.path, len(changes))
+	}
+	if W.config.OnReload != nil {
+		W.config.OnReload(changes, err)
+	}
+	return changes, err
+}
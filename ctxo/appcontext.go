@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"time"
-
 )
 
 type AppContext struct {
@@ -52,7 +51,6 @@ func (ac *AppContext) Init() context.Context {
 		if appCancels == nil {
 			appCancels = make(map[string]context.CancelFunc)
 		}
-		ac.GlobalContext.setupSignalHandler()
 	}
 
 	// Check if app context already exists and is valid
@@ -138,3 +136,23 @@ func SpawnChildWithTimeout(ctx context.Context, timeout time.Duration) (context.
 		cancel()
 	}
 }
+
+// WithValuesFrom returns a context that is cancelled like ctx and carries its values, falling back to
+// the values of values (e.g. the request-scoped values of a caller that must not cancel ctx)
+func WithValuesFrom(ctx, values context.Context) context.Context {
+	return valuesContext{Context: ctx, values: values}
+}
+
+// valuesContext looks up values in its context first, then in values
+type valuesContext struct {
+	context.Context
+	values context.Context
+}
+
+// Value returns the value of key in the context, or else in values
+func (vc valuesContext) Value(key interface{}) interface{} {
+	if value := vc.Context.Value(key); value != nil {
+		return value
+	}
+	return vc.values.Value(key)
+}
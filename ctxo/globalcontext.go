@@ -3,12 +3,7 @@ package ctxo
 import (
 	"context"
 	"log"
-	"os"
-	"os/signal"
-	"sync"
-	"syscall"
 	"time"
-
 )
 
 type GlobalContext struct{}
@@ -42,7 +37,6 @@ func (gc *GlobalContext) Init() context.Context {
 		appCancels = make(map[string]context.CancelFunc)
 	}
 
-	gc.setupSignalHandler()
 	return globalContext
 }
 
@@ -87,7 +81,6 @@ func (gc *GlobalContext) Shutdown() {
 	}
 	globalContext = nil
 	isInitialized = false
-	signalOnce = sync.Once{}
 
 }
 
@@ -109,7 +102,6 @@ func (gc *GlobalContext) NewChildContextWithTimeout(timeout time.Duration) (cont
 	}
 }
 
-
 // ListActiveApps returns a list of all apps with active contexts.
 func (gc *GlobalContext) ListActiveApps() []string {
 	ctxMu.RLock()
@@ -123,16 +115,3 @@ func (gc *GlobalContext) ListActiveApps() []string {
 	}
 	return apps
 }
-
-func (gc *GlobalContext) setupSignalHandler() {
-	signalOnce.Do(func() {
-		sigCh := make(chan os.Signal, 1)
-		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-		go func() {
-			sig := <-sigCh
-			log.Printf("Global context received shutdown signal: %s", sig)
-			gc.Shutdown()
-			signal.Stop(sigCh)
-		}()
-	})
-}
@@ -12,7 +12,6 @@ var (
 	appContexts   map[string]context.Context    // appContexts stores app-level contexts
 	appCancels    map[string]context.CancelFunc // appCancels stores app-level cancel functions
 	ctxMu         sync.RWMutex                  // ctxMu protects concurrent access to all context maps
-	signalOnce    sync.Once                     // signalOnce ensures the os signal handler is only set up once.
 	isInitialized bool                          // isInitialized tracks if the global context has been initialized.
 )
 
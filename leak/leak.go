@@ -0,0 +1,321 @@
+// Package leak finds goroutines started through the orchestrator that are no longer accounted for:
+// routines still running after they left their local manager (e.g. after Shutdown), and goroutines
+// that a routine's worker started on its own.
+//
+// Every tracked routine runs with pprof labels holding its app, local, function and routine ID
+// (types.LabelApp etc.), and goroutines started by its worker inherit them. The detector takes a
+// snapshot of all goroutine stacks from the runtime goroutine profile, which carries these labels,
+// and matches each labelled stack against the tracked types.Routine entries.
+//
+// Routines of an isolated tree (orchestrator.New) also carry the tree's ID (types.LabelTree) and are
+// looked up in their own tree: Detect and VerifyNoLeaks check the package-level tree, DetectTree and
+// VerifyNoLeaks with ForTree check an isolated one.
+package leak
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// spawnFrame is the function every routine goroutine runs in - goroutines started by a worker do not
+const spawnFrame = "goroutine-orchestrator/manager/local.(*LocalManagerStruct).spawnGoroutine.func"
+
+// Goroutine is a group of identical goroutines (same stack and labels) attributed to a routine.
+// The names and routine ID are those of the routine the goroutines belong to or were started by.
+type Goroutine struct {
+	Count        int // Number of goroutines with this stack
+	AppName      string
+	LocalName    string
+	FunctionName string
+	RoutineID    string
+	TreeID       string   // types.GlobalManager.TreeID of the routine's tree, empty for the package-level tree
+	Stack        []string // Function names, innermost first
+}
+
+// String returns a one line description of the goroutine group
+func (G Goroutine) String() string {
+	top := ""
+	if len(G.Stack) > 0 {
+		top = G.Stack[0]
+	}
+	return fmt.Sprintf("%d goroutine(s) of routine %s (%s/%s/%s) in %s", G.Count, G.RoutineID, G.AppName, G.LocalName, G.FunctionName, top)
+}
+
+// Report classifies the goroutines started through the orchestrator
+type Report struct {
+	TakenAt   time.Time
+	Tracked   []Goroutine // Routine goroutines still tracked by their local manager
+	Lingering []Goroutine // Routine goroutines no longer tracked, e.g. still running after Shutdown
+	Untracked []Goroutine // Goroutines started by a routine's worker itself
+}
+
+// Leaks returns the lingering and untracked goroutines
+func (R *Report) Leaks() []Goroutine {
+	leaks := make([]Goroutine, 0, len(R.Lingering)+len(R.Untracked))
+	leaks = append(leaks, R.Lingering...)
+	return append(leaks, R.Untracked...)
+}
+
+// String lists the lingering and untracked goroutines with their stacks
+func (R *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d tracked, %d lingering, %d untracked goroutine group(s)\n", len(R.Tracked), len(R.Lingering), len(R.Untracked))
+	for _, group := range []struct {
+		name       string
+		goroutines []Goroutine
+	}{{"lingering", R.Lingering}, {"untracked", R.Untracked}} {
+		for _, g := range group.goroutines {
+			fmt.Fprintf(&b, "%s: %s\n", group.name, g)
+			for _, frame := range g.Stack {
+				fmt.Fprintf(&b, "\t%s\n", frame)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Detect takes a snapshot of all goroutines and classifies those started through the package-level
+// manager tree. Only goroutines of appName are considered if it is not empty.
+func Detect(appName string) (*Report, error) {
+	return DetectTree(nil, appName)
+}
+
+// DetectTree is Detect for the manager tree of globalManager, e.g. orchestrator.GlobalManager() -
+// nil is the package-level tree. Goroutines of other trees are not considered.
+func DetectTree(globalManager *types.GlobalManager, appName string) (*Report, error) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return nil, err
+	}
+	groups, err := parseProfile(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{TakenAt: time.Now()}
+	for _, g := range groups {
+		if g.RoutineID == "" || g.TreeID != globalManager.TreeID() || (appName != "" && g.AppName != appName) {
+			// Not started through the orchestrator, or by another tree
+			continue
+		}
+		switch {
+		case !g.isRoutine:
+			report.Untracked = append(report.Untracked, g.Goroutine)
+		case isTracked(globalManager, g.Goroutine):
+			report.Tracked = append(report.Tracked, g.Goroutine)
+		default:
+			report.Lingering = append(report.Lingering, g.Goroutine)
+		}
+	}
+	for _, list := range [][]Goroutine{report.Tracked, report.Lingering, report.Untracked} {
+		sort.Slice(list, func(i, j int) bool { return list[i].RoutineID < list[j].RoutineID })
+	}
+	return report, nil
+}
+
+// isTracked reports whether the routine is still in its local manager's tracking map.
+// A nil globalManager is the package-level tree.
+func isTracked(globalManager *types.GlobalManager, g Goroutine) bool {
+	if globalManager == nil {
+		var err error
+		if globalManager, err = types.GetGlobalManager(); err != nil {
+			return false
+		}
+	}
+	localManager, err := globalManager.GetLocalManager(g.AppName, g.LocalName)
+	if err != nil {
+		return false
+	}
+	_, err = localManager.GetRoutine(g.RoutineID)
+	return err == nil
+}
+
+// TestingT is the subset of testing.TB used by VerifyNoLeaks
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Option configures VerifyNoLeaks
+type Option func(*verifyOptions)
+
+type verifyOptions struct {
+	timeout       time.Duration
+	appName       string
+	globalManager *types.GlobalManager
+}
+
+// Default time VerifyNoLeaks waits for goroutines to exit
+const DefaultVerifyTimeout = 2 * time.Second
+
+// WithTimeout sets how long VerifyNoLeaks waits for goroutines to exit (default DefaultVerifyTimeout)
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *verifyOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// ForApp only checks the goroutines of one app manager
+func ForApp(appName string) Option {
+	return func(opts *verifyOptions) {
+		opts.appName = appName
+	}
+}
+
+// ForTree checks the goroutines of the manager tree of globalManager (e.g. orchestrator.GlobalManager())
+// instead of those of the package-level tree
+func ForTree(globalManager *types.GlobalManager) Option {
+	return func(opts *verifyOptions) {
+		opts.globalManager = globalManager
+	}
+}
+
+// VerifyNoLeaks fails the test if goroutines started through the orchestrator are still running -
+// tracked, lingering or started by a worker - once the timeout for them to exit has passed.
+// Call it after shutting the managers down.
+//
+// Example:
+//
+//	if err := localMgr.Shutdown(true); err != nil {
+//	    t.Fatal(err)
+//	}
+//	leak.VerifyNoLeaks(t)
+func VerifyNoLeaks(t TestingT, opts ...Option) {
+	t.Helper()
+	options := &verifyOptions{timeout: DefaultVerifyTimeout}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	deadline := time.Now().Add(options.timeout)
+	for {
+		report, err := DetectTree(options.globalManager, options.appName)
+		if err != nil {
+			t.Errorf("leak detection failed: %v", err)
+			return
+		}
+		if len(report.Tracked) == 0 && len(report.Leaks()) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			for _, g := range report.Tracked {
+				t.Errorf("routine still running: %s", g)
+			}
+			t.Errorf("goroutine leaks: %s", report)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// profileGroup is a parsed goroutine profile entry
+type profileGroup struct {
+	Goroutine
+	isRoutine bool // The stack runs in spawnGoroutine, i.e. it is the routine's own goroutine
+}
+
+// parseProfile parses a goroutine profile written with debug=1:
+//
+//	3 @ 0x47d82a 0x480985
+//	# labels: {"orchestrator.app":"api", "orchestrator.routine_id":"..."}
+//	#	0x480984	time.Sleep+0x164	/usr/local/go/src/runtime/time.go:368
+func parseProfile(buf *bytes.Buffer) ([]profileGroup, error) {
+	var groups []profileGroup
+	var current *profileGroup
+
+	scanner := bufio.NewScanner(buf)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "" || strings.HasPrefix(line, "goroutine profile:"):
+			continue
+		case strings.HasPrefix(line, "# labels: "):
+			if current == nil {
+				continue
+			}
+			labels, err := parseLabels(strings.TrimPrefix(line, "# labels: "))
+			if err != nil {
+				return nil, err
+			}
+			current.AppName = labels[types.LabelApp]
+			current.LocalName = labels[types.LabelLocal]
+			current.FunctionName = labels[types.LabelFunction]
+			current.RoutineID = labels[types.LabelRoutineID]
+			current.TreeID = labels[types.LabelTree]
+		case strings.HasPrefix(line, "#"):
+			if current == nil {
+				continue
+			}
+			// "#\t0x4e1273\tmain.main.func1+0xb3\t/tmp/main.go:14" - fields are tab separated
+			fields := strings.FieldsFunc(line, func(r rune) bool { return r == '\t' })
+			if len(fields) < 3 {
+				continue
+			}
+			function := fields[2]
+			if i := strings.LastIndex(function, "+0x"); i >= 0 {
+				function = function[:i]
+			}
+			current.Stack = append(current.Stack, function)
+			if strings.Contains(function, spawnFrame) {
+				current.isRoutine = true
+			}
+		default:
+			// "<count> @ <pcs>" starts a new group
+			countField, _, found := strings.Cut(line, " @")
+			if !found {
+				continue
+			}
+			count, err := strconv.Atoi(strings.TrimSpace(countField))
+			if err != nil {
+				return nil, fmt.Errorf("malformed goroutine profile line %q: %w", line, err)
+			}
+			groups = append(groups, profileGroup{Goroutine: Goroutine{Count: count}})
+			current = &groups[len(groups)-1]
+		}
+	}
+	return groups, scanner.Err()
+}
+
+// parseLabels parses a label set printed as {"key":"value", "key2":"value2"}
+func parseLabels(s string) (map[string]string, error) {
+	labels := make(map[string]string)
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "{")
+	s = strings.TrimSuffix(s, "}")
+	for s != "" {
+		key, rest, err := unquotePrefix(s)
+		if err != nil {
+			return nil, err
+		}
+		rest = strings.TrimPrefix(rest, ":")
+		value, rest, err := unquotePrefix(rest)
+		if err != nil {
+			return nil, err
+		}
+		labels[key] = value
+		s = strings.TrimPrefix(strings.TrimPrefix(rest, ","), " ")
+	}
+	return labels, nil
+}
+
+// unquotePrefix unquotes the quoted string at the start of s and returns the rest
+func unquotePrefix(s string) (string, string, error) {
+	quoted, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", "", fmt.Errorf("malformed goroutine labels %q: %w", s, err)
+	}
+	value, err := strconv.Unquote(quoted)
+	if err != nil {
+		return "", "", err
+	}
+	return value, s[len(quoted):], nil
+}
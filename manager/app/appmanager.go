@@ -1,6 +1,9 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	LocalHelper "github.com/JupiterMetaLabs/goroutine-orchestrator/internal/helper/local"
@@ -17,6 +20,8 @@ import (
 type AppManagerStruct struct {
 	// AppName is the unique identifier for this application manager
 	AppName string
+	// Global is the manager tree the app manager belongs to - nil means the package-level types.Global
+	Global *types.GlobalManager
 }
 
 // NewAppManager creates and returns a new AppManager instance for the specified application name.
@@ -38,9 +43,47 @@ func NewAppManager(Appname string) interfaces.AppGoroutineManagerInterface {
 	}
 }
 
+// NewAppManagerFor returns an AppManager for the specified application name within globalManager
+// instead of the package-level singleton (nil means the singleton). Call CreateApp() to register it.
+//
+// Example:
+//
+//	appMgr := app.NewAppManagerFor(types.NewIsolatedGlobalManager(), "my-app")
+//	app, err := appMgr.CreateApp()
+func NewAppManagerFor(globalManager *types.GlobalManager, Appname string) interfaces.AppGoroutineManagerInterface {
+	return &AppManagerStruct{
+		AppName: Appname,
+		Global:  globalManager,
+	}
+}
+
+// getGlobalManager returns the manager tree the app manager belongs to
+func (AM *AppManagerStruct) getGlobalManager() (*types.GlobalManager, error) {
+	if AM.Global != nil {
+		return AM.Global, nil
+	}
+	return types.GetGlobalManager()
+}
+
+// publish delivers event to the subscribers of the manager tree (the default bus if Global is nil)
+func (AM *AppManagerStruct) publish(event types.Event) {
+	AM.Global.Publish(event)
+}
+
+// getAppManager returns the app manager from its manager tree
+func (AM *AppManagerStruct) getAppManager() (*types.AppManager, error) {
+	globalManager, err := AM.getGlobalManager()
+	if err != nil {
+		return nil, &errors.NotFoundError{Name: AM.AppName, Err: errors.ErrAppManagerNotFound}
+	}
+	return globalManager.GetAppManager(AM.AppName)
+}
+
 // CreateApp initializes and registers the app manager with the global manager.
 // This method is idempotent - calling it multiple times returns the existing app manager.
 // If the global manager is not initialized, it will be created automatically.
+// A stopped app manager is reopened with a fresh context; its local managers are reopened by
+// NewLocalManager (or CreateLocal).
 //
 // The method performs the following operations:
 //   - Checks if app manager already exists (returns existing if found)
@@ -51,7 +94,8 @@ func NewAppManager(Appname string) interfaces.AppGoroutineManagerInterface {
 //
 // Returns:
 //   - *types.AppManager: The initialized app manager instance
-//   - error: nil on success, error if initialization fails
+//   - error: nil on success, error if initialization fails, or an *errors.ManagerClosedError if the
+//     global manager is shutting down or stopped, or the app manager is shutting down
 //
 // Example:
 //
@@ -67,30 +111,68 @@ func (AM *AppManagerStruct) CreateApp() (*types.AppManager, error) {
 		metrics.RecordManagerOperationDuration("app", "create", duration, AM.AppName)
 	}()
 
-	// First check if the app manager is already initialized
-	if !types.IsIntilized().App(AM.AppName) {
-		// If Global Manager is Not Intilized, then we need to initialize it
-		if !types.IsIntilized().Global() {
-			global := types.NewGlobalManager().SetGlobalMutex().SetGlobalWaitGroup().SetGlobalContext()
-			types.SetGlobalManager(global)
-		}
+	// If Global Manager is Not Intilized, then we need to initialize it
+	if AM.Global == nil && !types.IsIntilized().Global() {
+		global := types.NewGlobalManager().SetGlobalMutex().SetGlobalWaitGroup().SetGlobalContext()
+		types.SetGlobalManager(global)
+		// Trap SIGINT and SIGTERM like global Init does - without a global manager to shut down they
+		// cancel the contexts of the tree, until global Init installs the manager shutdown
+		global.HandleSignals(types.SignalPolicy{}, nil)
 	}
 
-	if types.IsIntilized().App(AM.AppName) {
-		return types.GetAppManager(AM.AppName)
+	globalManager, err := AM.getGlobalManager()
+	if err != nil {
+		return nil, err
 	}
 
-	app := types.NewAppManager(AM.AppName).SetAppContext().SetAppMutex()
-	types.SetAppManager(AM.AppName, app)
+	// No new app managers while the global manager shuts down or after it stopped
+	if state := globalManager.GetState(); state != types.StateRunning {
+		metrics.RecordOperationError("manager", "create_app", "manager_closed")
+		return nil, &errors.ManagerClosedError{State: state.String()}
+	}
+
+	// First check if the app manager is already initialized - a stopped one is reopened
+	var app *types.AppManager
+	if globalManager.HasAppManager(AM.AppName) {
+		app, err = globalManager.GetAppManager(AM.AppName)
+		if err != nil || app.GetState() == types.StateRunning {
+			return app, err
+		}
+		if app.GetState() == types.StateDraining {
+			metrics.RecordOperationError("manager", "create_app", "manager_closed")
+			return nil, &errors.ManagerClosedError{App: AM.AppName, State: types.StateDraining.String()}
+		}
+		// App managers of isolated trees derive their context from the tree's root context
+		var parentCtx context.Context
+		if globalManager.IsIsolated() {
+			parentCtx, _ = globalManager.GetGlobalContext()
+		}
+		if !app.Reopen(parentCtx) {
+			// Reopened concurrently
+			return app, nil
+		}
+	} else {
+		app = globalManager.NewAppManager(AM.AppName)
+		// Apply the app settings of the global configuration
+		if metadata := globalManager.GetMetadata(); metadata != nil {
+			if appConfig, ok := metadata.GetAppConfig(AM.AppName); ok {
+				app.Configure(appConfig.ManagerConfig)
+			}
+		}
+	}
 
-	// Record operation
-	metrics.RecordManagerOperation("app", "create", AM.AppName)
+	AM.publish(types.Event{
+		Type:    types.EventAppCreated,
+		Manager: types.EventManagerApp,
+		AppName: AM.AppName,
+	})
 
 	return app, nil
 }
 
 // Shutdown gracefully or forcefully shuts down all local managers within this app manager.
 // This method coordinates the shutdown of all local managers and their goroutines.
+// Use ShutdownWithReport to learn which routines exited gracefully and which had to be force-cancelled.
 //
 // Parameters:
 //   - safe: If true, performs graceful shutdown (waits for goroutines with timeout, then force cancels).
@@ -109,6 +191,9 @@ func (AM *AppManagerStruct) CreateApp() (*types.AppManager, error) {
 //	- Cancels app manager's context
 //	- No waiting for goroutines to complete
 //
+// The app manager is draining while it shuts down and stopped afterwards (see GetState) - in both
+// states NewLocalManager and the Go() of its local managers are rejected until CreateApp reopens it.
+//
 // Returns:
 //   - error: nil on success, error if app manager not found or shutdown fails
 //
@@ -119,75 +204,274 @@ func (AM *AppManagerStruct) CreateApp() (*types.AppManager, error) {
 //	    log.Printf("Shutdown error: %v", err)
 //	}
 func (AM *AppManagerStruct) Shutdown(safe bool) error {
-	startTime := time.Now()
-	shutdownType := "unsafe"
-	if safe {
-		shutdownType = "safe"
+	_, err := AM.ShutdownWithReport(safe)
+	return err
+}
+
+// ShutdownWithReport shuts down the app manager like Shutdown and returns a report of the shutdown,
+// with one child report per local manager (see LocalManagerStruct.ShutdownWithReport).
+// Local manager shutdown errors are recorded in the children instead of being discarded.
+// The app's shutdown timeout (SetShutdownTimeout) applies, the global one if it has none.
+//
+// Returns:
+//   - *types.ShutdownReport: The app level report (never nil)
+//   - error: nil on success, error if app manager not found
+//
+// Example:
+//
+//	report, _ := appMgr.ShutdownWithReport(true)
+//	log.Println(report)
+func (AM *AppManagerStruct) ShutdownWithReport(safe bool) (*types.ShutdownReport, error) {
+	return AM.ShutdownWithTimeout(safe, AM.GetEffectiveConfig().ShutdownTimeout)
+}
+
+// ShutdownWithTimeout shuts down the app manager like ShutdownWithReport within timeout instead of
+// the global ShutdownTimeout. Local managers shut down phase by phase in their declared shutdown
+// order (see LocalManagerStruct.SetShutdownPriority and ShutdownAfter), local managers of the same
+// phase concurrently; each phase gets an equal slice of timeout and is recorded in the report as
+// "local_managers_phase_N".
+//
+// Example:
+//
+//	report, err := appMgr.ShutdownWithTimeout(true, 10*time.Second)
+func (AM *AppManagerStruct) ShutdownWithTimeout(safe bool, timeout time.Duration) (*types.ShutdownReport, error) {
+	return AM.shutdown(context.Background(), safe, timeout)
+}
+
+// ShutdownContext safely shuts down the app manager like ShutdownWithReport, driven by the caller's
+// context instead of the global ShutdownTimeout: each shutdown phase gets an equal slice of the time
+// left until the deadline of ctx, and all waiting stops when ctx is done (a ctx without deadline
+// waits until it is cancelled). Routines still running then are force-cancelled.
+//
+// Returns:
+//   - *types.ShutdownReport: The app level report with one child per local manager (never nil)
+//   - error: nil on success, error if app manager not found, ctx.Err() if ctx ended before every
+//     routine exited gracefully
+//
+// Example:
+//
+//	// Kubernetes preStop budget
+//	ctx, cancel := context.WithTimeout(context.Background(), 25*time.Second)
+//	defer cancel()
+//	report, err := appMgr.ShutdownContext(ctx)
+func (AM *AppManagerStruct) ShutdownContext(ctx context.Context) (*types.ShutdownReport, error) {
+	report, err := AM.shutdown(ctx, true, types.ContextTimeout(ctx))
+	if err == nil && ctx.Err() != nil && !report.Clean() {
+		err = ctx.Err()
 	}
+	return report, err
+}
+
+// shutdown shuts down the app manager within timeout, waiting for local managers unless ctx is done first
+func (AM *AppManagerStruct) shutdown(ctx context.Context, safe bool, timeout time.Duration) (*types.ShutdownReport, error) {
+	startTime := time.Now()
+	report := types.NewShutdownReport(types.ShutdownLevelApp, AM.AppName, safe)
 
 	defer func() {
-		duration := time.Since(startTime)
-		metrics.RecordShutdownDuration("app", shutdownType, duration, AM.AppName, "")
+		report.Finish()
+		AM.publish(types.Event{
+			Type:     types.EventShutdownFinished,
+			Manager:  types.EventManagerApp,
+			AppName:  AM.AppName,
+			Safe:     safe,
+			Duration: time.Since(startTime),
+			Report:   report,
+		})
 	}()
 
-	appManager, err := types.GetAppManager(AM.AppName)
+	appManager, err := AM.getAppManager()
 	if err != nil {
 		metrics.RecordOperationError("manager", "shutdown", "get_app_manager_failed")
-		return err
+		report.AddError(err)
+		return report, err
 	}
 
+	// New local managers and routines are rejected from now on
+	appManager.SetState(types.StateDraining)
+	defer appManager.SetState(types.StateStopped)
+
 	// Get all local managers
 	localManagers, err := AM.GetAllLocalManagers()
 	if err != nil {
 		metrics.RecordOperationError("manager", "shutdown", "get_local_managers_failed")
-		return err
+		report.AddError(err)
+		return report, err
 	}
 
-	// Record shutdown operation
-	metrics.RecordManagerOperation("app", "shutdown", AM.AppName)
+	AM.publish(types.Event{
+		Type:    types.EventShutdownBegan,
+		Manager: types.EventManagerApp,
+		AppName: AM.AppName,
+		Safe:    safe,
+	})
+
+	// Local reports are collected in local manager order, whichever finishes first
+	localReports := make([]*types.ShutdownReport, len(localManagers))
+
+	// Group the local managers into shutdown phases
+	phases, indexes := localShutdownPhases(appManager, localManagers)
+	phaseTimeout := timeout
+	if len(phases) > 1 {
+		phaseTimeout = timeout / time.Duration(len(phases))
+	}
 
-	if safe {
-		// Safe shutdown: trigger shutdown on all local managers and wait
-		if appManager.Wg != nil {
-			// Add all local managers to the wait group
-			for _, localMgr := range localManagers {
-				appManager.Wg.Add(1)
-				go func(lm *types.LocalManager) {
-					defer appManager.Wg.Done()
+	for phase, localNames := range phases {
+		phaseStart := time.Now()
+
+		if safe {
+			// Safe shutdown: trigger shutdown on all local managers of the phase and wait
+			// The phase ends after its slice of the timeout, or when ctx is done
+			phaseCtx, cancelPhase := context.WithTimeout(ctx, phaseTimeout)
+			if appManager.Wg != nil {
+				// Add the phase's local managers to the wait group
+				for _, localName := range localNames {
+					i := indexes[localName]
+					appManager.Wg.Add(1)
+					go func(i int, lm *types.LocalManager) {
+						defer appManager.Wg.Done()
+
+						// Create a LocalManager instance to call Shutdown
+						lmInstance := local.NewLocalManagerFor(AM.Global, AM.AppName, lm.LocalName)
+
+						// Call Shutdown on the local manager, within its own shutdown timeout if it has one
+						// This will trigger the improved safe shutdown logic (graceful -> timeout -> force)
+						localCtx, cancelLocal := types.BoundContext(phaseCtx, lm.GetManagerConfig().ShutdownTimeout)
+						defer cancelLocal()
+						localReports[i], _ = lmInstance.ShutdownContext(localCtx)
+
+						// Wait for local manager's wait group (redundant but safe) - routines that
+						// ignored the force cancel must not block the shutdown past its timeout
+						if lm.Wg != nil {
+							types.WaitContext(localCtx, lm.Wg)
+						}
+					}(i, localManagers[i])
+				}
+				// Wait for the phase's local managers to shutdown before starting the next phase
+				appManager.Wg.Wait()
+			}
+			cancelPhase()
+		} else {
+			// Unsafe shutdown: cancel all local manager contexts forcefully
+			for _, localName := range localNames {
+				i := indexes[localName]
+				// Create a LocalManager instance to call Shutdown
+				lmInstance := local.NewLocalManagerFor(AM.Global, AM.AppName, localName)
+
+				// Call Shutdown(false) which handles cancellation
+				localReports[i], _ = lmInstance.ShutdownWithTimeout(false, types.BoundTimeout(phaseTimeout, localManagers[i].GetManagerConfig().ShutdownTimeout))
+			}
+		}
 
-					// Create a LocalManager instance to call Shutdown
-					lmInstance := local.NewLocalManager(AM.AppName, lm.LocalName)
+		report.AddPhase(fmt.Sprintf("local_managers_phase_%d", phase+1), phaseStart)
+	}
 
-					// Call Shutdown on the local manager
-					// This will trigger the improved safe shutdown logic (graceful -> timeout -> force)
-					_ = lmInstance.Shutdown(true)
+	// Cancel the app manager's context
+	if !safe && appManager.Cancel != nil {
+		appManager.Cancel()
+	}
 
-					// Wait for local manager's wait group (redundant but safe)
-					if lm.Wg != nil {
-						lm.Wg.Wait()
-					}
-				}(localMgr)
-			}
-			// Wait for all local managers to shutdown
-			appManager.Wg.Wait()
-		}
-	} else {
-		// Unsafe shutdown: cancel all local manager contexts forcefully
-		for _, localMgr := range localManagers {
-			// Create a LocalManager instance to call Shutdown
-			lmInstance := local.NewLocalManager(AM.AppName, localMgr.LocalName)
+	for _, localReport := range localReports {
+		report.AddChild(localReport)
+	}
+	report.SortChildren()
 
-			// Call Shutdown(false) which handles cancellation
-			_ = lmInstance.Shutdown(false)
-		}
+	return report, nil
+}
+
+// Drain drains all local managers of this app manager for a rolling deploy: new local managers and
+// routines are rejected, and each local manager lets its running routines finish before escalating
+// to cancellation and then to force removal (see LocalManagerStruct.Drain). Local managers drain
+// phase by phase in their declared shutdown order, local managers of the same phase concurrently;
+// every phase gets the full config timeouts and is recorded in the report as "local_managers_phase_N".
+//
+// Returns:
+//   - *types.ShutdownReport: The app level report with one child per local manager (never nil)
+//   - error: nil on success, error if app manager not found
+//
+// Example:
+//
+//	report, err := appMgr.Drain(types.DrainConfig{WaitTimeout: 30 * time.Second})
+//	if err == nil && !report.Clean() {
+//	    log.Printf("Routines had to be cancelled: %s", report)
+//	}
+func (AM *AppManagerStruct) Drain(config types.DrainConfig) (*types.ShutdownReport, error) {
+	startTime := time.Now()
+	report := types.NewShutdownReport(types.ShutdownLevelApp, AM.AppName, true)
+
+	defer func() {
+		report.Finish()
+		AM.publish(types.Event{
+			Type:     types.EventShutdownFinished,
+			Manager:  types.EventManagerApp,
+			AppName:  AM.AppName,
+			Safe:     true,
+			Duration: time.Since(startTime),
+			Report:   report,
+		})
+	}()
 
-		// Cancel the app manager's context
-		if appManager.Cancel != nil {
-			appManager.Cancel()
+	appManager, err := AM.getAppManager()
+	if err != nil {
+		metrics.RecordOperationError("manager", "drain", "get_app_manager_failed")
+		report.AddError(err)
+		return report, err
+	}
+
+	// New local managers and routines are rejected from now on
+	appManager.SetState(types.StateDraining)
+	defer appManager.SetState(types.StateStopped)
+
+	localManagers, err := AM.GetAllLocalManagers()
+	if err != nil {
+		metrics.RecordOperationError("manager", "drain", "get_local_managers_failed")
+		report.AddError(err)
+		return report, err
+	}
+
+	AM.publish(types.Event{
+		Type:    types.EventShutdownBegan,
+		Manager: types.EventManagerApp,
+		AppName: AM.AppName,
+		Safe:    true,
+	})
+
+	localReports := make([]*types.ShutdownReport, len(localManagers))
+	phases, indexes := localShutdownPhases(appManager, localManagers)
+	for phase, localNames := range phases {
+		phaseStart := time.Now()
+
+		var wg sync.WaitGroup
+		for _, localName := range localNames {
+			wg.Add(1)
+			go func(i int, localName string) {
+				defer wg.Done()
+				localReports[i], _ = local.NewLocalManagerFor(AM.Global, AM.AppName, localName).Drain(config)
+			}(indexes[localName], localName)
 		}
+		wg.Wait()
+
+		report.AddPhase(fmt.Sprintf("local_managers_phase_%d", phase+1), phaseStart)
 	}
 
-	return nil
+	for _, localReport := range localReports {
+		report.AddChild(localReport)
+	}
+	report.SortChildren()
+
+	return report, nil
+}
+
+// localShutdownPhases groups the local managers into their shutdown phases and returns each local
+// manager's index in localManagers
+func localShutdownPhases(appManager *types.AppManager, localManagers []*types.LocalManager) ([][]string, map[string]int) {
+	indexes := make(map[string]int, len(localManagers))
+	declaredOrders := appManager.GetLocalShutdownOrders()
+	orders := make(map[string]types.ShutdownOrder, len(localManagers))
+	for i, localMgr := range localManagers {
+		indexes[localMgr.LocalName] = i
+		orders[localMgr.LocalName] = declaredOrders[localMgr.LocalName]
+	}
+	return types.ShutdownPhases(orders), indexes
 }
 
 // NewLocalManager creates a new local manager within this app manager.
@@ -219,10 +503,10 @@ func (AM *AppManagerStruct) NewLocalManager(localName string) (interfaces.LocalG
 	}()
 
 	// Use the LocalManagerCreator interface to create a new local manager
-	localManager := local.NewLocalManager(AM.AppName, localName)
+	localManager := local.NewLocalManagerFor(AM.Global, AM.AppName, localName)
 	if localManager == nil {
 		metrics.RecordOperationError("manager", "create_local", "local_manager_not_found")
-		return nil, errors.ErrLocalManagerNotFound
+		return nil, &errors.NotFoundError{Name: localName, Err: errors.ErrLocalManagerNotFound}
 	}
 	_, err := localManager.CreateLocal(localName)
 	if err != nil {
@@ -252,7 +536,7 @@ func (AM *AppManagerStruct) NewLocalManager(localName string) (interfaces.LocalG
 //	    fmt.Printf("Local: %s\n", local.LocalName)
 //	}
 func (AM *AppManagerStruct) GetAllLocalManagers() ([]*types.LocalManager, error) {
-	appManager, err := types.GetAppManager(AM.AppName)
+	appManager, err := AM.getAppManager()
 	if err != nil {
 		return nil, err
 	}
@@ -275,7 +559,7 @@ func (AM *AppManagerStruct) GetAllLocalManagers() ([]*types.LocalManager, error)
 //	    log.Printf("Local manager not found: %v", err)
 //	}
 func (AM *AppManagerStruct) GetLocalManager(localName string) (*types.LocalManager, error) {
-	appManager, err := types.GetAppManager(AM.AppName)
+	appManager, err := AM.getAppManager()
 	if err != nil {
 		return nil, err
 	}
@@ -304,7 +588,7 @@ func (AM *AppManagerStruct) GetLocalManager(localName string) (*types.LocalManag
 func (AM *AppManagerStruct) GetAllGoroutines() ([]*types.Routine, error) {
 	// Return the All Goroutines for the particular app manager
 	// Dont use this unless you need to get all the goroutines for the particular app manager. This would take significant memory.
-	appManager, err := types.GetAppManager(AM.AppName)
+	appManager, err := AM.getAppManager()
 	if err != nil {
 		return nil, err
 	}
@@ -335,7 +619,7 @@ func (AM *AppManagerStruct) GetGoroutineCount() int {
 	// Dont Use GetAllGoroutines() as it will create a new slice - memory usage would be O(n)
 	// and it will be a performance issue
 	// Return the Go Routine count for the particular app manager
-	appManager, err := types.GetAppManager(AM.AppName)
+	appManager, err := AM.getAppManager()
 	if err != nil {
 		return 0
 	}
@@ -358,7 +642,7 @@ func (AM *AppManagerStruct) GetGoroutineCount() int {
 //	count := appMgr.GetLocalManagerCount()
 //	log.Printf("App has %d local managers", count)
 func (AM *AppManagerStruct) GetLocalManagerCount() int {
-	appManager, err := types.GetAppManager(AM.AppName)
+	appManager, err := AM.getAppManager()
 	if err != nil {
 		return 0
 	}
@@ -382,13 +666,142 @@ func (AM *AppManagerStruct) GetLocalManagerCount() int {
 //	    log.Printf("Local manager not found: %v", err)
 //	}
 func (AM *AppManagerStruct) GetLocalManagerByName(localName string) (*types.LocalManager, error) {
-	appManager, err := types.GetAppManager(AM.AppName)
+	appManager, err := AM.getAppManager()
 	if err != nil {
 		return nil, err
 	}
 	return appManager.GetLocalManager(localName)
 }
 
+// SetMaxRoutines sets the routine quota for this app manager.
+// The quota covers routines across all local managers of the app, so one noisy app
+// cannot use up the global MaxRoutines limit. A value of 0 disables the app quota.
+//
+// Returns:
+//   - error: Returns error if app manager is not found
+//
+// Example:
+//
+//	if err := appMgr.SetMaxRoutines(500); err != nil {
+//	    log.Printf("Error: %v", err)
+//	}
+func (AM *AppManagerStruct) SetMaxRoutines(maxRoutines int) error {
+	appManager, err := AM.getAppManager()
+	if err != nil {
+		return err
+	}
+	appManager.SetMaxRoutines(maxRoutines)
+	// Wake waiters in case the quota was raised
+	AM.Global.Admission().Release()
+	return nil
+}
+
+// GetMaxRoutines returns the routine quota for this app manager (0 = unlimited).
+func (AM *AppManagerStruct) GetMaxRoutines() int {
+	appManager, err := AM.getAppManager()
+	if err != nil {
+		return 0
+	}
+	return appManager.GetMaxRoutines()
+}
+
+// SetShutdownTimeout overrides the global shutdown timeout for this app manager: Shutdown uses it,
+// and so do its local managers that have no timeout of their own. Within a global shutdown the app
+// is not waited for longer than its timeout (nor longer than the global shutdown allows).
+// A value of 0 restores the global timeout.
+//
+// Returns:
+//   - error: Returns error if app manager is not found, or an error wrapping ErrInvalidConfig if
+//     timeout is negative
+//
+// Example:
+//
+//	// Ingestion flushes its buffers on shutdown
+//	ingestionMgr.SetShutdownTimeout(60 * time.Second)
+func (AM *AppManagerStruct) SetShutdownTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("%w: shutdown timeout must not be negative, got %v", errors.ErrInvalidConfig, timeout)
+	}
+	appManager, err := AM.getAppManager()
+	if err != nil {
+		return err
+	}
+	appManager.SetShutdownTimeout(timeout)
+	return nil
+}
+
+// SetRoutineDefaults sets the defaults for every routine spawned by the local managers of this app
+// manager. Local manager defaults, function defaults (global.WithFunctionDefaults) and options
+// passed to Go() override them; zero fields set no default.
+//
+// Returns:
+//   - error: Returns error if app manager is not found, or an error wrapping ErrInvalidConfig if a
+//     value is negative
+//
+// Example:
+//
+//	appMgr.SetRoutineDefaults(types.FunctionConfig{Timeout: 5 * time.Minute})
+func (AM *AppManagerStruct) SetRoutineDefaults(defaults types.FunctionConfig) error {
+	if defaults.Timeout < 0 || defaults.MaxConcurrent < 0 {
+		return fmt.Errorf("%w: routine defaults must not be negative, got %+v", errors.ErrInvalidConfig, defaults)
+	}
+	appManager, err := AM.getAppManager()
+	if err != nil {
+		return err
+	}
+	appManager.SetDefaults(defaults)
+	return nil
+}
+
+// GetEffectiveConfig returns the settings in effect for this app manager: its quota, and its
+// shutdown timeout and routine defaults or the global ones where it has none.
+func (AM *AppManagerStruct) GetEffectiveConfig() types.ManagerConfig {
+	globalManager, err := AM.getGlobalManager()
+	if err != nil {
+		return types.ManagerConfig{ShutdownTimeout: types.ShutdownTimeout}
+	}
+	return globalManager.GetEffectiveConfig(AM.AppName, "")
+}
+
+// Use adds interceptors wrapping every worker run of the routines spawned in this app manager's
+// local managers, after those added before. They run inside the interceptors of the global manager
+// and outside those of the local managers (see LocalManagerStruct.Use).
+//
+// Returns:
+//   - func(): Removes the interceptors again - routines already running keep their chain
+//   - error: Returns error if app manager is not found, or an error wrapping ErrInvalidConfig if an
+//     interceptor is nil
+//
+// Example:
+//
+//	appMgr.Use(logRuns) // see types.Interceptor
+func (AM *AppManagerStruct) Use(interceptors ...types.Interceptor) (remove func(), err error) {
+	if err := types.ValidateInterceptors(interceptors); err != nil {
+		return nil, err
+	}
+	appManager, err := AM.getAppManager()
+	if err != nil {
+		return nil, err
+	}
+	return appManager.UseInterceptors(interceptors...), nil
+}
+
+// GetState returns the lifecycle state of this app manager: running, draining while it shuts down,
+// or stopped once it has shut down. An app manager that does not exist is reported as stopped.
+//
+// Example:
+//
+//	if appMgr.GetState() == types.StateStopped {
+//	    appMgr.CreateApp() // reopen
+//	}
+func (AM *AppManagerStruct) GetState() types.ManagerState {
+	appManager, err := AM.getAppManager()
+	if err != nil {
+		return types.StateStopped
+	}
+	return appManager.GetState()
+}
+
 // Get retrieves a specific app manager by its name.
 //
 // Returns:
@@ -402,5 +815,5 @@ func (AM *AppManagerStruct) GetLocalManagerByName(localName string) (*types.Loca
 //	    log.Printf("App manager not found: %v", err)
 //	}
 func (AM *AppManagerStruct) Get() (*types.AppManager, error) {
-	return types.GetAppManager(AM.AppName)
+	return AM.getAppManager()
 }
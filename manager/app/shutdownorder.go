@@ -0,0 +1,76 @@
+package app
+
+import (
+	"slices"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// SetShutdownPriority sets when this app manager shuts down relative to the other app managers.
+// App managers with a lower priority shut down first (default 0); each distinct priority becomes
+// its own shutdown phase of the global shutdown.
+//
+// Example:
+//
+//	ingressApp.SetShutdownPriority(0)  // stops first
+//	storageApp.SetShutdownPriority(1)  // stops after ingress
+func (AM *AppManagerStruct) SetShutdownPriority(priority int) error {
+	appManager, err := AM.getAppManager()
+	if err != nil {
+		return err
+	}
+
+	types.LockShutdownOrder()
+	defer types.UnlockShutdownOrder()
+
+	order := appManager.GetShutdownOrder()
+	order.Priority = priority
+	appManager.SetShutdownOrder(order)
+	return nil
+}
+
+// ShutdownAfter declares that this app manager shuts down only after the named app managers have
+// shut down, regardless of priority. App managers that do not exist at shutdown time are ignored.
+//
+// Returns ErrShutdownDependencyCycle (and keeps the previous ordering) if the declaration would
+// create a dependency cycle.
+//
+// Example:
+//
+//	storageApp.ShutdownAfter("ingress", "queue")
+func (AM *AppManagerStruct) ShutdownAfter(appNames ...string) error {
+	globalManager, err := AM.getGlobalManager()
+	if err != nil {
+		return err
+	}
+	appManager, err := AM.getAppManager()
+	if err != nil {
+		return err
+	}
+
+	types.LockShutdownOrder()
+	defer types.UnlockShutdownOrder()
+
+	orders := globalManager.GetAppShutdownOrders()
+	if err := types.CheckShutdownCycle(orders, AM.AppName, appNames); err != nil {
+		return err
+	}
+
+	order := appManager.GetShutdownOrder()
+	for _, appName := range appNames {
+		if !slices.Contains(order.After, appName) {
+			order.After = append(order.After, appName)
+		}
+	}
+	appManager.SetShutdownOrder(order)
+	return nil
+}
+
+// GetShutdownOrder returns the declared shutdown ordering of this app manager
+func (AM *AppManagerStruct) GetShutdownOrder() types.ShutdownOrder {
+	appManager, err := AM.getAppManager()
+	if err != nil {
+		return types.ShutdownOrder{}
+	}
+	return appManager.GetShutdownOrder()
+}
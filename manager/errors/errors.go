@@ -3,15 +3,30 @@ package errors
 import "fmt"
 
 var (
-	ErrGlobalManagerNotFound = fmt.Errorf("global manager not found")
-	ErrAppManagerNotFound    = fmt.Errorf("app manager not found")
-	ErrLocalManagerNotFound  = fmt.Errorf("local manager not found")
-	ErrLockContextCancelled  = fmt.Errorf("lock acquisition cancelled due to context cancellation")
-	ErrRoutineNotFound       = fmt.Errorf("routine not found")
-	ErrFunctionWgNotFound    = fmt.Errorf("function wg not found")
+	ErrGlobalManagerNotFound   = fmt.Errorf("global manager not found")
+	ErrAppManagerNotFound      = fmt.Errorf("app manager not found")
+	ErrLocalManagerNotFound    = fmt.Errorf("local manager not found")
+	ErrLockContextCancelled    = fmt.Errorf("lock acquisition cancelled due to context cancellation")
+	ErrRoutineNotFound         = fmt.Errorf("routine not found")
+	ErrFunctionWgNotFound      = fmt.Errorf("function wg not found")
+	ErrMaxRoutinesReached      = fmt.Errorf("max routines limit reached")
+	ErrAdmissionTimeout        = fmt.Errorf("timed out waiting for a routine slot")
+	ErrMaxConcurrentReached    = fmt.Errorf("max concurrent routines of function reached")
+	ErrRoutinePanicked         = fmt.Errorf("routine panicked")
+	ErrRoutineNotFinished      = fmt.Errorf("routine has not finished")
+	ErrWorkerPoolClosed        = fmt.Errorf("worker pool is closed")
+	ErrWorkerPoolFull          = fmt.Errorf("worker pool queue is full")
+	ErrWorkerPoolNotFound      = fmt.Errorf("worker pool not found")
+	ErrShutdownDependencyCycle = fmt.Errorf("shutdown dependency cycle")
+	ErrInvalidSchedule         = fmt.Errorf("invalid schedule")
+	ErrScheduleNotFound        = fmt.Errorf("schedule not found")
+	ErrShutdownTimeout         = fmt.Errorf("shutdown timeout")
+	ErrManagerClosed           = fmt.Errorf("manager closed")
+	ErrInvalidConfig           = fmt.Errorf("invalid config")
 )
 
 // this is for warnings
 var (
+	// Deprecated: creating an existing local manager returns it with a nil error - this is no longer returned
 	WrngLocalManagerAlreadyExists = fmt.Errorf("local manager already exists")
 )
@@ -0,0 +1,184 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Admission rejection reasons reported by AdmissionRejectedError (and the admission rejection metric)
+const (
+	ReasonMaxRoutines      = "max_routines"
+	ReasonMaxConcurrent    = "max_concurrent"
+	ReasonAdmissionTimeout = "admission_timeout"
+	ReasonManagerShutdown  = "manager_shutdown"
+)
+
+// Admission limit scopes reported by AdmissionRejectedError
+const (
+	ScopeGlobal   = "global"
+	ScopeApp      = "app"
+	ScopeLocal    = "local"
+	ScopeFunction = "function"
+)
+
+// NotFoundError is returned when a named manager, routine, wait group, pool or schedule does not exist.
+// It wraps the matching sentinel, e.g. ErrLocalManagerNotFound or ErrRoutineNotFound.
+type NotFoundError struct {
+	Name string // Name or ID that was looked up
+	Err  error  // Sentinel of the kind that was looked up
+}
+
+func (E *NotFoundError) Error() string {
+	if E.Name == "" {
+		return E.Err.Error()
+	}
+	return fmt.Sprintf("%v: %s", E.Err, E.Name)
+}
+
+func (E *NotFoundError) Unwrap() error {
+	return E.Err
+}
+
+// ShutdownTimeoutError is returned when routines did not exit within a shutdown (or drain) timeout.
+// It wraps ErrShutdownTimeout and, if the caller's context ended the wait, the context error.
+type ShutdownTimeoutError struct {
+	App       string
+	Local     string
+	Function  string        // Function being shut down ("" if not function level)
+	Pool      string        // Worker pool being drained ("" if not a pool)
+	Timeout   time.Duration // Timeout that expired (the time waited if the context ended the wait)
+	Remaining int           // Routines (tasks for a pool) still running when the timeout expired
+	Cause     error         // Context error that ended the wait (nil if the timeout expired)
+}
+
+func (E *ShutdownTimeoutError) Error() string {
+	var target string
+	switch {
+	case E.Pool != "":
+		target = "worker pool " + E.Pool
+	case E.Function != "":
+		target = "function " + E.Function
+	default:
+		target = "local manager"
+	}
+	msg := fmt.Sprintf("%v for %s in %s after %v: %d still running", ErrShutdownTimeout, target, managerPath(E.App, E.Local), E.Timeout, E.Remaining)
+	if E.Cause != nil {
+		msg += ": " + E.Cause.Error()
+	}
+	return msg
+}
+
+func (E *ShutdownTimeoutError) Unwrap() []error {
+	if E.Cause == nil {
+		return []error{ErrShutdownTimeout}
+	}
+	return []error{ErrShutdownTimeout, E.Cause}
+}
+
+// AdmissionRejectedError is returned by Go() when a routine is not admitted.
+// It wraps the sentinel of the limit (ErrMaxRoutinesReached, ErrMaxConcurrentReached or
+// ErrAdmissionTimeout) and, if any, the cause - e.g. a ManagerClosedError when the manager shut
+// down while Go() was waiting for a slot.
+type AdmissionRejectedError struct {
+	App      string
+	Local    string
+	Function string
+	Reason   string // ReasonMaxRoutines, ReasonMaxConcurrent, ReasonAdmissionTimeout or ReasonManagerShutdown
+	Scope    string // Scope of the limit that was reached: ScopeGlobal, ScopeApp, ScopeLocal or ScopeFunction
+	Limit    int    // Limit that was reached
+	Err      error  // Sentinel of the limit
+	Cause    error  // Underlying error (nil if none)
+}
+
+func (E *AdmissionRejectedError) Error() string {
+	var b strings.Builder
+	b.WriteString(E.Err.Error())
+	switch E.Scope {
+	case ScopeGlobal:
+		fmt.Fprintf(&b, ": global (limit %d)", E.Limit)
+	case ScopeApp:
+		fmt.Fprintf(&b, ": app manager %s (limit %d)", E.App, E.Limit)
+	case ScopeLocal:
+		fmt.Fprintf(&b, ": local manager %s (limit %d)", managerPath(E.App, E.Local), E.Limit)
+	case ScopeFunction:
+		fmt.Fprintf(&b, ": %s in local manager %s (limit %d)", E.Function, managerPath(E.App, E.Local), E.Limit)
+	}
+	if E.Cause != nil {
+		fmt.Fprintf(&b, ": %v", E.Cause)
+	}
+	return b.String()
+}
+
+func (E *AdmissionRejectedError) Unwrap() []error {
+	if E.Cause == nil {
+		return []error{E.Err}
+	}
+	return []error{E.Err, E.Cause}
+}
+
+// ManagerClosedError is returned when an operation needs a manager that is shutting down or was shut down.
+// It wraps ErrManagerClosed and the cause (e.g. context.Canceled), if any.
+type ManagerClosedError struct {
+	App   string // "" for the global manager
+	Local string // "" for an app (or the global) manager
+	State string // State of the manager ("draining" or "stopped"), "" if not known
+	Cause error  // Underlying error (nil if none)
+}
+
+func (E *ManagerClosedError) Error() string {
+	msg := fmt.Sprintf("%v: %s", ErrManagerClosed, managerPath(E.App, E.Local))
+	if E.State != "" {
+		msg += " is " + E.State
+	}
+	if E.Cause != nil {
+		msg += ": " + E.Cause.Error()
+	}
+	return msg
+}
+
+func (E *ManagerClosedError) Unwrap() []error {
+	if E.Cause == nil {
+		return []error{ErrManagerClosed}
+	}
+	return []error{ErrManagerClosed, E.Cause}
+}
+
+// RoutinePanicError is the error of a routine (or pool task, schedule run) whose worker panicked.
+// It wraps ErrRoutinePanicked.
+type RoutinePanicError struct {
+	App       string
+	Local     string
+	Function  string
+	RoutineID string      // "" if not known, e.g. for pool tasks
+	Value     interface{} // Recovered panic value (nil if the panic was not recovered)
+	Stack     []byte      // Stack trace of the panic (nil if not captured)
+}
+
+func (E *RoutinePanicError) Error() string {
+	var b strings.Builder
+	b.WriteString(ErrRoutinePanicked.Error())
+	if E.Value != nil {
+		fmt.Fprintf(&b, ": %v", E.Value)
+	}
+	if len(E.Stack) > 0 {
+		b.WriteString("\n")
+		b.Write(E.Stack)
+	}
+	return b.String()
+}
+
+func (E *RoutinePanicError) Unwrap() error {
+	return ErrRoutinePanicked
+}
+
+// managerPath formats an app and local manager name as "app/local" ("global" if both are empty)
+func managerPath(app, local string) string {
+	if app == "" && local == "" {
+		return "global"
+	}
+	if local == "" {
+		return app
+	}
+	return app + "/" + local
+}
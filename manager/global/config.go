@@ -0,0 +1,371 @@
+package global
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// WithConfig replaces the whole configuration with cfg - options passed after it still apply
+func WithConfig(cfg types.Config) types.ConfigOption {
+	return func(c *types.Config) {
+		*c = cfg
+	}
+}
+
+// WithShutdownTimeout sets the graceful shutdown timeout
+func WithShutdownTimeout(timeout time.Duration) types.ConfigOption {
+	return func(c *types.Config) {
+		c.ShutdownTimeout = timeout
+	}
+}
+
+// WithMaxRoutines sets the process-wide routine limit enforced by Go() (0 = unlimited)
+func WithMaxRoutines(maxRoutines int) types.ConfigOption {
+	return func(c *types.Config) {
+		c.MaxRoutines = maxRoutines
+	}
+}
+
+// WithMetrics enables Prometheus metrics. addr is the address the metrics server listens on
+// ("" = run the collector only), interval the collection interval (0 = keep the current interval).
+func WithMetrics(addr string, interval time.Duration) types.ConfigOption {
+	return func(c *types.Config) {
+		c.Metrics = true
+		c.MetricsURL = addr
+		if interval != 0 {
+			c.MetricsInterval = interval
+		}
+	}
+}
+
+// WithoutMetrics disables Prometheus metrics, stopping the collector and server if they run
+func WithoutMetrics() types.ConfigOption {
+	return func(c *types.Config) {
+		c.Metrics = false
+	}
+}
+
+// WithSignalPolicy sets how the global manager handles process signals
+func WithSignalPolicy(policy types.SignalPolicy) types.ConfigOption {
+	return func(c *types.Config) {
+		c.Signals = &policy
+	}
+}
+
+// WithoutSignalHandlers installs no signal handlers - the host application owns signals
+func WithoutSignalHandlers() types.ConfigOption {
+	return WithSignalPolicy(types.SignalPolicy{Disabled: true})
+}
+
+// WithAppConfig sets the quota, shutdown timeout and routine defaults of an app manager (zero values
+// inherit, see types.ManagerConfig), applied when it exists or once it is created. Its local
+// manager settings are kept.
+func WithAppConfig(appName string, config types.ManagerConfig) types.ConfigOption {
+	return func(c *types.Config) {
+		updateApp(c, appName, func(app *types.AppConfig) {
+			app.ManagerConfig = config
+		})
+	}
+}
+
+// WithLocalConfig sets the quota, shutdown timeout and routine defaults of a local manager (zero
+// values inherit from its app manager), applied when it exists or once it is created
+func WithLocalConfig(appName, localName string, config types.ManagerConfig) types.ConfigOption {
+	return func(c *types.Config) {
+		updateLocal(c, appName, localName, func(local *types.ManagerConfig) {
+			*local = config
+		})
+	}
+}
+
+// WithAppQuota sets the routine quota of an app manager (0 = unlimited), applied when it exists or
+// once it is created
+func WithAppQuota(appName string, maxRoutines int) types.ConfigOption {
+	return func(c *types.Config) {
+		updateApp(c, appName, func(app *types.AppConfig) {
+			app.MaxRoutines = maxRoutines
+		})
+	}
+}
+
+// WithLocalQuota sets the routine quota of a local manager (0 = unlimited), applied when it exists or
+// once it is created
+func WithLocalQuota(appName, localName string, maxRoutines int) types.ConfigOption {
+	return func(c *types.Config) {
+		updateLocal(c, appName, localName, func(local *types.ManagerConfig) {
+			local.MaxRoutines = maxRoutines
+		})
+	}
+}
+
+// updateApp changes the settings of an app manager in a copy of c.Apps, so configs sharing the map
+// are not affected
+func updateApp(c *types.Config, appName string, update func(app *types.AppConfig)) {
+	apps := make(map[string]types.AppConfig, len(c.Apps)+1)
+	for name, app := range c.Apps {
+		apps[name] = app
+	}
+	app := apps[appName]
+	update(&app)
+	apps[appName] = app
+	c.Apps = apps
+}
+
+// updateLocal changes the settings of a local manager in a copy of c.Apps
+func updateLocal(c *types.Config, appName, localName string, update func(local *types.ManagerConfig)) {
+	updateApp(c, appName, func(app *types.AppConfig) {
+		locals := make(map[string]types.ManagerConfig, len(app.Locals)+1)
+		for name, local := range app.Locals {
+			locals[name] = local
+		}
+		local := locals[localName]
+		update(&local)
+		locals[localName] = local
+		app.Locals = locals
+	})
+}
+
+// WithFunctionDefaults sets the defaults for the routines of a function name in every local manager.
+// Options passed to Go() override them.
+func WithFunctionDefaults(functionName string, defaults types.FunctionConfig) types.ConfigOption {
+	return func(c *types.Config) {
+		functions := make(map[string]types.FunctionConfig, len(c.Functions)+1)
+		for name, function := range c.Functions {
+			functions[name] = function
+		}
+		functions[functionName] = defaults
+		c.Functions = functions
+	}
+}
+
+// GetConfig returns the current configuration of the global manager.
+//
+// Returns:
+//   - types.Config: The current configuration (Signals is nil if no signal policy was installed)
+//   - error: Returns error if global manager is not initialized
+func (GM *GlobalManagerStruct) GetConfig() (types.Config, error) {
+	g, err := GM.getGlobalManager()
+	if err != nil {
+		return types.Config{}, err
+	}
+	return g.GetConfig(), nil
+}
+
+// Reconfigure validates cfg and applies the settings that differ from the current configuration.
+// Nothing is applied if cfg is invalid or the metrics server fails to start. A nil cfg.Signals
+// keeps the installed signal policy.
+//
+// Returns:
+//   - []types.ConfigChange: The settings that changed, in declaration order (empty if none did)
+//   - error: Returns an error wrapping ErrInvalidConfig if cfg is invalid, or the metrics server error
+//
+// Example:
+//
+//	cfg, _ := globalMgr.GetConfig()
+//	cfg.MaxRoutines = 500
+//	changes, err := globalMgr.Reconfigure(cfg)
+//	for _, change := range changes {
+//	    log.Printf("Changed %s", change)
+//	}
+func (GM *GlobalManagerStruct) Reconfigure(cfg types.Config) ([]types.ConfigChange, error) {
+	g, err := GM.getGlobalManager()
+	if err != nil {
+		return nil, err
+	}
+	return GM.reconfigure(g, cfg)
+}
+
+// reconfigure applies the settings of cfg that differ from the current configuration of globalManager
+func (GM *GlobalManagerStruct) reconfigure(globalManager *types.GlobalManager, cfg types.Config) ([]types.ConfigChange, error) {
+	if err := cfg.Validate(); err != nil {
+		metrics.RecordOperationError("manager", "reconfigure", "invalid_config")
+		return nil, err
+	}
+
+	current := globalManager.GetConfig()
+	changes := current.Diff(cfg)
+	changed := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		changed[change.Setting] = true
+	}
+	metadata := globalManager.NewMetadata()
+
+	// Metrics first - starting the server is the only step that can fail
+	if changed[types.ConfigMetrics] || changed[types.ConfigMetricsURL] || changed[types.ConfigMetricsInterval] {
+		metadata.SetMetrics(cfg.Metrics, cfg.MetricsURL, cfg.MetricsInterval)
+		if err := applyMetrics(globalManager, current, cfg); err != nil {
+			metadata.SetMetrics(current.Metrics, current.MetricsURL, current.MetricsInterval)
+			metrics.RecordOperationError("manager", "reconfigure", "metrics_server")
+			return nil, err
+		}
+	}
+	if changed[types.ConfigShutdownTimeout] {
+		metadata.SetShutdownTimeout(cfg.ShutdownTimeout)
+	}
+	if changed[types.ConfigMaxRoutines] {
+		metadata.SetMaxRoutines(cfg.MaxRoutines)
+		// Wake Go() calls waiting for a slot in case the limit was raised
+		globalManager.Admission().Release()
+	}
+	if !sameApps(current.Apps, cfg.Apps) {
+		metadata.SetApps(cfg.Apps)
+		applyAppConfigs(globalManager, current.Apps, cfg.Apps)
+	}
+	if !sameFunctions(current.Functions, cfg.Functions) {
+		// Read by Go() on the next spawn
+		metadata.SetFunctions(cfg.Functions)
+	}
+	if changed[types.ConfigSignals] {
+		globalManager.HandleSignals(*cfg.Signals, GM.signalShutdown)
+	}
+
+	if len(changes) > 0 {
+		metrics.RecordManagerOperation("global", "reconfigure", "")
+	}
+	return changes, nil
+}
+
+// applyAppConfigs sets the app and local manager settings that changed from current to next on the
+// existing app and local managers
+func applyAppConfigs(globalManager *types.GlobalManager, current, next map[string]types.AppConfig) {
+	appNames := make(map[string]bool, len(current)+len(next))
+	for appName := range current {
+		appNames[appName] = true
+	}
+	for appName := range next {
+		appNames[appName] = true
+	}
+	for appName := range appNames {
+		appManager, err := globalManager.GetAppManager(appName)
+		if err != nil {
+			// Applied by CreateApp
+			continue
+		}
+		before, after := current[appName], next[appName]
+		if before.ManagerConfig != after.ManagerConfig {
+			appManager.Configure(after.ManagerConfig)
+		}
+		localNames := make(map[string]bool, len(before.Locals)+len(after.Locals))
+		for localName := range before.Locals {
+			localNames[localName] = true
+		}
+		for localName := range after.Locals {
+			localNames[localName] = true
+		}
+		for localName := range localNames {
+			localManager, err := appManager.GetLocalManager(localName)
+			if err != nil {
+				// Applied by CreateLocal
+				continue
+			}
+			if config := after.Locals[localName]; before.Locals[localName] != config {
+				localManager.Configure(config)
+			}
+		}
+	}
+	// Wake Go() calls waiting for a slot in case a quota was raised
+	globalManager.Admission().Release()
+}
+
+// sameApps reports whether two app configurations hold the same settings
+func sameApps(a, b map[string]types.AppConfig) bool {
+	return len(types.Config{Apps: a}.Diff(types.Config{Apps: b})) == 0
+}
+
+// sameFunctions reports whether two function configurations hold the same defaults
+func sameFunctions(a, b map[string]types.FunctionConfig) bool {
+	return len(types.Config{Functions: a}.Diff(types.Config{Functions: b})) == 0
+}
+
+// applyMetrics starts or stops the metrics collector and server for the change from current to cfg.
+// The collection interval must already be stored in the metadata.
+func applyMetrics(globalManager *types.GlobalManager, current, cfg types.Config) error {
+	if globalManager.IsIsolated() {
+		return applyTreeMetrics(globalManager, current, cfg)
+	}
+	if !cfg.Metrics {
+		if metrics.IsCollectorRunning() {
+			metrics.StopCollector()
+		}
+		stopMetricsServer(globalManager)
+		return nil
+	}
+
+	// InitMetrics is idempotent; notify a running collector about the interval (observer pattern)
+	metrics.InitMetrics()
+	metrics.UpdateMetricsUpdateInterval()
+	if cfg.MetricsURL == "" {
+		// Collect periodically without serving - StartCollector is idempotent
+		metrics.StartCollector()
+		return nil
+	}
+	if current.Metrics && current.MetricsURL != cfg.MetricsURL {
+		// Move the server to the new address
+		stopMetricsServer(globalManager)
+	}
+	// A server that is already running keeps serving (idempotent behavior)
+	if err := metrics.StartMetricsServer(cfg.MetricsURL); err != nil && !errors.Is(err, metrics.ErrServerRunning) {
+		return err
+	}
+	return nil
+}
+
+// applyTreeMetrics starts or stops the metrics server of an isolated tree, which serves the tree's
+// own registry. The process-wide collector and server are left alone, and there is nothing to collect
+// periodically: the tree's gauges are collected when scraped.
+func applyTreeMetrics(globalManager *types.GlobalManager, current, cfg types.Config) error {
+	if !cfg.Metrics || cfg.MetricsURL == "" {
+		stopMetricsServer(globalManager)
+		return nil
+	}
+	if current.Metrics && current.MetricsURL != cfg.MetricsURL {
+		// Move the server to the new address
+		stopMetricsServer(globalManager)
+	}
+	// A server that is already running keeps serving (idempotent behavior)
+	if err := metrics.StartTreeMetricsServer(globalManager, cfg.MetricsURL); err != nil && !errors.Is(err, metrics.ErrServerRunning) {
+		return err
+	}
+	return nil
+}
+
+// metricsServerStopTimeout bounds the graceful stop of the metrics server
+const metricsServerStopTimeout = 5 * time.Second
+
+// stopMetricsServer stops the metrics server of the tree (the process-wide one for the package-level
+// singleton) if it is running. The stop deadline does not derive from the global context, which is
+// already cancelled when the tree has been shut down.
+func stopMetricsServer(globalManager *types.GlobalManager) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsServerStopTimeout)
+	defer cancel()
+	if globalManager.IsIsolated() {
+		// StopTreeMetricsServer only fails if the tree's server is not running
+		_ = metrics.StopTreeMetricsServer(ctx, globalManager)
+		return
+	}
+	if !metrics.IsServerRunning() {
+		return
+	}
+	// StopMetricsServer only fails if the server is not running, which was checked
+	_ = metrics.StopMetricsServer(ctx)
+}
+
+// installSignals (re)installs the signal handler of the package-level singleton with its current
+// policy (the default policy if none was set), so signals run this manager's shutdown
+func (GM *GlobalManagerStruct) installSignals(globalManager *types.GlobalManager) {
+	policy := types.SignalPolicy{}
+	if current := globalManager.GetSignalPolicy(); current != nil {
+		policy = *current
+	}
+	globalManager.HandleSignals(policy, GM.signalShutdown)
+}
+
+// signalShutdown shuts down the global manager for a signal within the global ShutdownTimeout,
+// stopping to wait for app managers once ctx is cancelled
+func (GM *GlobalManagerStruct) signalShutdown(ctx context.Context, safe bool) (*types.ShutdownReport, error) {
+	return GM.shutdown(ctx, safe, types.ShutdownTimeout)
+}
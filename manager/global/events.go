@@ -0,0 +1,73 @@
+package global
+
+import (
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// Subscribe registers a subscriber for routine and manager lifecycle events (routine started,
+// completed, errored, panicked, timed out, cancelled; app/local created, shutdown began, shutdown
+// finished, force cancel) across all app and local managers of the tree. Subscribers of one tree do
+// not receive the events of other trees (see orchestrator.New); for the package-level singleton it is
+// types.Subscribe. Prometheus metrics are recorded by metrics.EventRecorder, which is subscribed the
+// same way when metrics are initialized.
+//
+// Subscribers are called synchronously and must not block. The returned function unsubscribes.
+//
+// Example:
+//
+//	unsubscribe := globalMgr.Subscribe(types.SubscriberFunc(func(event types.Event) {
+//	    if event.Type == types.EventRoutinePanicked {
+//	        log.Printf("%s/%s/%s panicked: %v", event.AppName, event.LocalName, event.FunctionName, event.Err)
+//	    }
+//	}))
+//	defer unsubscribe()
+func (GM *GlobalManagerStruct) Subscribe(subscriber types.Subscriber) (unsubscribe func()) {
+	globalManager, _ := GM.getGlobalManager()
+	return globalManager.Subscribe(subscriber)
+}
+
+// SetPanicHandler sets the handler called with the value and stack of every panic recovered from a
+// routine of the tree that has no handler of its own (local.WithPanicHandler). nil removes it. Each
+// tree has its own handler; for the package-level singleton it is types.SetPanicHandler.
+//
+// Example:
+//
+//	globalMgr.SetPanicHandler(func(info *types.PanicInfo) {
+//	    log.Printf("%s/%s/%s panicked: %v\n%s", info.AppName, info.LocalName, info.FunctionName, info.Value, info.Stack)
+//	})
+func (GM *GlobalManagerStruct) SetPanicHandler(handler types.PanicHandler) {
+	globalManager, _ := GM.getGlobalManager()
+	globalManager.SetPanicHandler(handler)
+}
+
+// Use adds interceptors wrapping every worker run of every routine of the tree, after those added
+// before. They are the outermost after the built-in panic recovery and metrics interceptors, outside
+// those of the app and local managers (see LocalManagerStruct.Use).
+//
+// Returns:
+//   - func(): Removes the interceptors again - routines already running keep their chain
+//   - error: Returns error if global manager is not initialized, or an error wrapping ErrInvalidConfig
+//     if an interceptor is nil
+//
+// Example:
+//
+//	globalMgr.Use(func(next types.WorkerFunc) types.WorkerFunc {
+//	    return func(ctx context.Context) error {
+//	        if err := next(ctx); err != nil {
+//	            info, _ := types.RoutineInfoFromContext(ctx)
+//	            reporter.Report(err, info.AppName, info.FunctionName)
+//	            return err
+//	        }
+//	        return nil
+//	    }
+//	})
+func (GM *GlobalManagerStruct) Use(interceptors ...types.Interceptor) (remove func(), err error) {
+	if err := types.ValidateInterceptors(interceptors); err != nil {
+		return nil, err
+	}
+	globalManager, err := GM.getGlobalManager()
+	if err != nil {
+		return nil, err
+	}
+	return globalManager.UseInterceptors(interceptors...), nil
+}
@@ -1,6 +1,9 @@
 package global
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	AppHelper "github.com/JupiterMetaLabs/goroutine-orchestrator/internal/helper/app"
@@ -15,7 +18,10 @@ import (
 // It manages all app-level managers, provides process-wide context with signal handling,
 // and coordinates global shutdown operations. This is the top-level component in the
 // hierarchical manager system (Global → App → Local → Routine).
-type GlobalManagerStruct struct{}
+type GlobalManagerStruct struct {
+	// Global is the manager tree this manager operates on - nil means the package-level types.Global
+	Global *types.GlobalManager
+}
 
 // NewGlobalManager creates and returns a new GlobalManager instance.
 // This is a lightweight constructor that only creates the struct - call Init() to
@@ -32,55 +38,117 @@ func NewGlobalManager() interfaces.GlobalGoroutineManagerInterface {
 	return &GlobalManagerStruct{}
 }
 
-// Init initializes the global manager and sets up signal handling for graceful shutdown.
+// NewGlobalManagerFor returns a GlobalManager operating on globalManager instead of the package-level
+// singleton, typically one created by types.NewIsolatedGlobalManager. Init returns globalManager as is.
+//
+// Example:
+//
+//	globalMgr := global.NewGlobalManagerFor(types.NewIsolatedGlobalManager())
+//	appMgr, err := globalMgr.NewAppManager("api-server")
+func NewGlobalManagerFor(globalManager *types.GlobalManager) interfaces.GlobalGoroutineManagerInterface {
+	return &GlobalManagerStruct{Global: globalManager}
+}
+
+// getGlobalManager returns the manager tree this manager operates on
+func (GM *GlobalManagerStruct) getGlobalManager() (*types.GlobalManager, error) {
+	if GM.Global != nil {
+		return GM.Global, nil
+	}
+	return types.GetGlobalManager()
+}
+
+// publish delivers event to the subscribers of the manager tree (the default bus if Global is nil)
+func (GM *GlobalManagerStruct) publish(event types.Event) {
+	GM.Global.Publish(event)
+}
+
+// Init initializes the global manager, applies the configuration options and sets up signal handling.
 // This method is idempotent - calling it multiple times returns the existing global manager.
+// A stopped global manager is reopened (with a fresh root context if it was cancelled).
 //
 // The method performs the following operations:
-//   - Checks if global manager already exists (returns existing if found)
-//   - Creates global context with signal handling (SIGINT, SIGTERM)
-//   - Initializes global mutex for thread-safe operations
-//   - Sets up global wait group for coordinated shutdown
-//   - Initializes metadata with default values
+//   - Validates the configuration built from DefaultConfig and opts (nothing is created if it is invalid)
+//   - Checks if global manager already exists (returns existing if found, applying opts through Reconfigure)
+//   - Creates the global context, mutex and wait group
+//   - Initializes metadata from the configuration
+//   - Installs the signal handler of the signal policy
 //   - Records metrics for the init operation
 //
 // Signal Handling:
 //
-//	The global context automatically listens for SIGINT (Ctrl+C) and SIGTERM signals
-//	and triggers graceful shutdown when received.
+//	The package-level singleton traps SIGINT and SIGTERM unless WithSignalPolicy says otherwise:
+//	the first signal runs a graceful Shutdown(true), a second one forces Shutdown(false).
+//	Isolated trees (orchestrator.New) only handle signals when WithSignalPolicy is passed.
+//
+// Parameters:
+//   - opts: Configuration options (WithShutdownTimeout, WithMaxRoutines, WithMetrics, WithSignalPolicy, ...)
 //
 // Returns:
 //   - *types.GlobalManager: The initialized global manager instance
-//   - error: nil on success, error if initialization fails
+//   - error: nil on success, an error wrapping ErrInvalidConfig if the configuration is invalid
 //
 // Example:
 //
 //	globalMgr := global.NewGlobalManager()
-//	_, err := globalMgr.Init()
+//	_, err := globalMgr.Init(
+//	    global.WithShutdownTimeout(30*time.Second),
+//	    global.WithMetrics(":9090", 5*time.Second),
+//	)
 //	if err != nil {
 //	    log.Fatalf("Failed to initialize global manager: %v", err)
 //	}
-func (GM *GlobalManagerStruct) Init() (*types.GlobalManager, error) {
+func (GM *GlobalManagerStruct) Init(opts ...types.ConfigOption) (*types.GlobalManager, error) {
 	startTime := time.Now()
 	defer func() {
 		duration := time.Since(startTime)
 		metrics.RecordManagerOperationDuration("global", "init", duration, "")
 	}()
 
-	if types.IsIntilized().Global() {
-		return types.GetGlobalManager()
+	if GM.Global != nil || types.IsIntilized().Global() {
+		globalManager, err := GM.getGlobalManager()
+		if err != nil {
+			return nil, err
+		}
+		if len(opts) > 0 {
+			if _, err := GM.reconfigure(globalManager, globalManager.GetConfig().With(opts...)); err != nil {
+				return nil, err
+			}
+		}
+		// Reopen a stopped global manager so it accepts app managers again
+		globalManager.Reopen()
+		if GM.Global == nil {
+			GM.installSignals(globalManager)
+		}
+		return globalManager, nil
+	}
+
+	cfg := types.NewConfig(opts...)
+	if err := cfg.Validate(); err != nil {
+		metrics.RecordOperationError("manager", "init", "invalid_config")
+		return nil, err
 	}
 
 	Global := types.NewGlobalManager().SetGlobalMutex().SetGlobalWaitGroup().SetGlobalContext()
 	types.SetGlobalManager(Global)
 
+	globalManager, err := types.GetGlobalManager()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := GM.reconfigure(globalManager, cfg); err != nil {
+		return nil, err
+	}
+	GM.installSignals(globalManager)
+
 	// Record operation
 	metrics.RecordManagerOperation("global", "init", "")
 
-	return types.GetGlobalManager()
+	return globalManager, nil
 }
 
 // Shutdown gracefully or forcefully shuts down all app managers and the global context.
 // This is the top-level shutdown method that coordinates shutdown across the entire application.
+// Use ShutdownWithReport to learn which routines exited gracefully and which had to be force-cancelled.
 //
 // Parameters:
 //   - safe: If true, performs graceful shutdown (waits for goroutines with timeout, then force cancels).
@@ -101,6 +169,9 @@ func (GM *GlobalManagerStruct) Init() (*types.GlobalManager, error) {
 //	- No waiting for goroutines to complete
 //	- Risk of data loss or incomplete cleanup
 //
+// The global manager is draining while it shuts down and stopped afterwards (see GetState) - in both
+// states CreateApp and the Go() of all local managers are rejected until Init reopens it.
+//
 // Returns:
 //   - error: nil on success, error if global manager not found or shutdown fails
 //
@@ -114,79 +185,286 @@ func (GM *GlobalManagerStruct) Init() (*types.GlobalManager, error) {
 //	    log.Printf("Shutdown error: %v", err)
 //	}
 func (GM *GlobalManagerStruct) Shutdown(safe bool) error {
-	startTime := time.Now()
-	shutdownType := "unsafe"
-	if safe {
-		shutdownType = "safe"
+	_, err := GM.ShutdownWithReport(safe)
+	return err
+}
+
+// ShutdownWithReport shuts down all app managers like Shutdown and returns the full shutdown report
+// tree (global → app → local → function), listing which routines exited gracefully, which were
+// force-cancelled and which were still running afterwards, with the duration of each phase.
+//
+// Returns:
+//   - *types.ShutdownReport: The global level report (never nil)
+//   - error: nil on success, error if global manager not found
+//
+// Example:
+//
+//	report, err := globalMgr.ShutdownWithReport(true)
+//	if err != nil {
+//	    log.Printf("Shutdown error: %v", err)
+//	}
+//	log.Printf("Shutdown report: %s", report) // JSON for deploy logs
+func (GM *GlobalManagerStruct) ShutdownWithReport(safe bool) (*types.ShutdownReport, error) {
+	return GM.ShutdownWithTimeout(safe, types.ShutdownTimeout)
+}
+
+// ShutdownWithTimeout shuts down all app managers like ShutdownWithReport within timeout instead of
+// the global ShutdownTimeout. App managers shut down phase by phase in their declared shutdown order
+// (see AppManagerStruct.SetShutdownPriority and ShutdownAfter), app managers of the same phase
+// concurrently; each phase gets an equal slice of timeout and is recorded in the report as
+// "app_managers_phase_N".
+//
+// Example:
+//
+//	report, err := globalMgr.ShutdownWithTimeout(true, 30*time.Second)
+func (GM *GlobalManagerStruct) ShutdownWithTimeout(safe bool, timeout time.Duration) (*types.ShutdownReport, error) {
+	return GM.shutdown(context.Background(), safe, timeout)
+}
+
+// ShutdownContext safely shuts down the whole manager tree like ShutdownWithReport, driven by the
+// caller's context instead of the global ShutdownTimeout - e.g. a Kubernetes preStop budget or the
+// context passed to http.Server.Shutdown. Each shutdown phase gets an equal slice of the time left
+// until the deadline of ctx, and all waiting stops when ctx is done (a ctx without deadline waits
+// until it is cancelled). Routines still running then are force-cancelled.
+//
+// Returns:
+//   - *types.ShutdownReport: The global level report with one child per app manager (never nil)
+//   - error: nil on success, error if global manager is not initialized, ctx.Err() if ctx ended
+//     before every routine exited gracefully
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 25*time.Second)
+//	defer cancel()
+//	server.Shutdown(ctx)
+//	if _, err := globalMgr.ShutdownContext(ctx); err != nil {
+//	    log.Printf("Shutdown did not finish in time: %v", err)
+//	}
+func (GM *GlobalManagerStruct) ShutdownContext(ctx context.Context) (*types.ShutdownReport, error) {
+	report, err := GM.shutdown(ctx, true, types.ContextTimeout(ctx))
+	if err == nil && ctx.Err() != nil && !report.Clean() {
+		err = ctx.Err()
 	}
+	return report, err
+}
+
+// shutdown shuts down the global manager within timeout, waiting for app managers unless ctx is done first
+func (GM *GlobalManagerStruct) shutdown(ctx context.Context, safe bool, timeout time.Duration) (*types.ShutdownReport, error) {
+	startTime := time.Now()
+	report := types.NewShutdownReport(types.ShutdownLevelGlobal, "global", safe)
 
 	defer func() {
-		duration := time.Since(startTime)
-		metrics.RecordShutdownDuration("global", shutdownType, duration, "", "")
+		report.Finish()
+		GM.publish(types.Event{
+			Type:     types.EventShutdownFinished,
+			Manager:  types.EventManagerGlobal,
+			Safe:     safe,
+			Duration: time.Since(startTime),
+			Report:   report,
+		})
 	}()
 
-	globalMgr, err := types.GetGlobalManager()
+	globalMgr, err := GM.getGlobalManager()
 	if err != nil {
 		metrics.RecordOperationError("manager", "shutdown", "get_global_manager_failed")
-		return err
+		report.AddError(err)
+		return report, err
+	}
+
+	// New app managers and routines are rejected from now on
+	globalMgr.SetState(types.StateDraining)
+	defer globalMgr.SetState(types.StateStopped)
+	if globalMgr.IsIsolated() {
+		// The metrics server of an isolated tree serves the tree only - it stops with it
+		defer stopMetricsServer(globalMgr)
 	}
 
 	// Get all app managers
 	appManagers, err := GM.GetAllAppManagers()
 	if err != nil {
 		metrics.RecordOperationError("manager", "shutdown", "get_app_managers_failed")
-		return err
+		report.AddError(err)
+		return report, err
+	}
+
+	GM.publish(types.Event{
+		Type:    types.EventShutdownBegan,
+		Manager: types.EventManagerGlobal,
+		Safe:    safe,
+	})
+
+	// App reports are collected in app manager order, whichever finishes first
+	appReports := make([]*types.ShutdownReport, len(appManagers))
+
+	// Group the app managers into shutdown phases
+	phases, indexes := appShutdownPhases(globalMgr, appManagers)
+	phaseTimeout := timeout
+	if len(phases) > 1 {
+		phaseTimeout = timeout / time.Duration(len(phases))
 	}
 
-	// Record shutdown operation
-	metrics.RecordManagerOperation("global", "shutdown", "")
-
-	if safe {
-		// Safe shutdown: trigger shutdown on all app managers and wait
-		if globalMgr.Wg != nil {
-			// Add all app managers to the wait group
-			for _, appMgr := range appManagers {
-				globalMgr.Wg.Add(1)
-				go func(am *types.AppManager) {
-					defer globalMgr.Wg.Done()
-
-					// Create an AppManager instance to call Shutdown
-					amInstance := app.NewAppManager(am.AppName)
-
-					// Call Shutdown on the app manager
-					// This will trigger AppManager.Shutdown -> LocalManager.Shutdown
-					_ = amInstance.Shutdown(true)
-
-					// Wait for app manager's wait group (redundant but safe)
-					// Lock to safely read Wg pointer to avoid race condition
-					am.LockAppReadMutex()
-					wg := am.Wg
-					am.UnlockAppReadMutex()
-					if wg != nil {
-						wg.Wait()
-					}
-				}(appMgr)
+	for phase, appNames := range phases {
+		phaseStart := time.Now()
+
+		if safe {
+			// Safe shutdown: trigger shutdown on all app managers of the phase and wait
+			// The phase ends after its slice of the timeout, or when ctx is done
+			phaseCtx, cancelPhase := context.WithTimeout(ctx, phaseTimeout)
+			if globalMgr.Wg != nil {
+				// Add the phase's app managers to the wait group
+				for _, appName := range appNames {
+					i := indexes[appName]
+					globalMgr.Wg.Add(1)
+					go func(i int, am *types.AppManager) {
+						defer globalMgr.Wg.Done()
+
+						// Create an AppManager instance to call Shutdown
+						amInstance := app.NewAppManagerFor(GM.Global, am.AppName)
+
+						// Call Shutdown on the app manager, within its own shutdown timeout if it has one
+						// This will trigger AppManager.Shutdown -> LocalManager.Shutdown
+						appCtx, cancelApp := types.BoundContext(phaseCtx, am.GetManagerConfig().ShutdownTimeout)
+						defer cancelApp()
+						appReports[i], _ = amInstance.ShutdownContext(appCtx)
+
+						// Wait for app manager's wait group (redundant but safe) - routines that
+						// ignored the force cancel must not block the shutdown past its timeout
+						// Lock to safely read Wg pointer to avoid race condition
+						am.LockAppReadMutex()
+						wg := am.Wg
+						am.UnlockAppReadMutex()
+						if wg != nil {
+							types.WaitContext(appCtx, wg)
+						}
+					}(i, appManagers[i])
+				}
+				// Wait for the phase's app managers to shutdown before starting the next phase
+				globalMgr.Wg.Wait()
+			}
+			cancelPhase()
+		} else {
+			// Unsafe shutdown: cancel all app manager contexts forcefully
+			for _, appName := range appNames {
+				i := indexes[appName]
+				// Create an AppManager instance to call Shutdown
+				amInstance := app.NewAppManagerFor(GM.Global, appName)
+
+				// Call Shutdown(false) which handles cancellation
+				appReports[i], _ = amInstance.ShutdownWithTimeout(false, types.BoundTimeout(phaseTimeout, appManagers[i].GetManagerConfig().ShutdownTimeout))
 			}
-			// Wait for all app managers to shutdown
-			globalMgr.Wg.Wait()
-		}
-	} else {
-		// Unsafe shutdown: cancel all app manager contexts forcefully
-		for _, appMgr := range appManagers {
-			// Create an AppManager instance to call Shutdown
-			amInstance := app.NewAppManager(appMgr.AppName)
-
-			// Call Shutdown(false) which handles cancellation
-			_ = amInstance.Shutdown(false)
 		}
 
-		// Cancel the global manager's context
-		if globalMgr.Cancel != nil {
-			globalMgr.Cancel()
+		report.AddPhase(fmt.Sprintf("app_managers_phase_%d", phase+1), phaseStart)
+	}
+
+	// Cancel the global manager's context
+	if !safe && globalMgr.Cancel != nil {
+		globalMgr.Cancel()
+	}
+
+	for _, appReport := range appReports {
+		report.AddChild(appReport)
+	}
+	report.SortChildren()
+
+	return report, nil
+}
+
+// Drain drains every app manager for a rolling deploy: new app managers and routines are rejected,
+// and each local manager lets its running routines finish before escalating to cancellation and then
+// to force removal (see LocalManagerStruct.Drain). App managers drain phase by phase in their declared
+// shutdown order, app managers of the same phase concurrently; every phase gets the full config
+// timeouts and is recorded in the report as "app_managers_phase_N".
+//
+// Returns:
+//   - *types.ShutdownReport: The global level report with one child per app manager (never nil)
+//   - error: nil on success, error if global manager is not initialized
+//
+// Example:
+//
+//	report, err := globalMgr.Drain(types.DrainConfig{
+//	    WaitTimeout:   30 * time.Second,
+//	    CancelTimeout: 5 * time.Second,
+//	})
+//	if err == nil && !report.Clean() {
+//	    log.Printf("Routines had to be cancelled: %s", report)
+//	}
+func (GM *GlobalManagerStruct) Drain(config types.DrainConfig) (*types.ShutdownReport, error) {
+	startTime := time.Now()
+	report := types.NewShutdownReport(types.ShutdownLevelGlobal, "global", true)
+
+	defer func() {
+		report.Finish()
+		GM.publish(types.Event{
+			Type:     types.EventShutdownFinished,
+			Manager:  types.EventManagerGlobal,
+			Safe:     true,
+			Duration: time.Since(startTime),
+			Report:   report,
+		})
+	}()
+
+	globalMgr, err := GM.getGlobalManager()
+	if err != nil {
+		metrics.RecordOperationError("manager", "drain", "get_global_manager_failed")
+		report.AddError(err)
+		return report, err
+	}
+
+	// New app managers and routines are rejected from now on
+	globalMgr.SetState(types.StateDraining)
+	defer globalMgr.SetState(types.StateStopped)
+
+	appManagers, err := GM.GetAllAppManagers()
+	if err != nil {
+		metrics.RecordOperationError("manager", "drain", "get_app_managers_failed")
+		report.AddError(err)
+		return report, err
+	}
+
+	GM.publish(types.Event{
+		Type:    types.EventShutdownBegan,
+		Manager: types.EventManagerGlobal,
+		Safe:    true,
+	})
+
+	appReports := make([]*types.ShutdownReport, len(appManagers))
+	phases, indexes := appShutdownPhases(globalMgr, appManagers)
+	for phase, appNames := range phases {
+		phaseStart := time.Now()
+
+		var wg sync.WaitGroup
+		for _, appName := range appNames {
+			wg.Add(1)
+			go func(i int, appName string) {
+				defer wg.Done()
+				appReports[i], _ = app.NewAppManagerFor(GM.Global, appName).Drain(config)
+			}(indexes[appName], appName)
 		}
+		wg.Wait()
+
+		report.AddPhase(fmt.Sprintf("app_managers_phase_%d", phase+1), phaseStart)
+	}
+
+	for _, appReport := range appReports {
+		report.AddChild(appReport)
 	}
+	report.SortChildren()
 
-	return nil
+	return report, nil
+}
+
+// appShutdownPhases groups the app managers into their shutdown phases and returns each app
+// manager's index in appManagers
+func appShutdownPhases(globalMgr *types.GlobalManager, appManagers []*types.AppManager) ([][]string, map[string]int) {
+	indexes := make(map[string]int, len(appManagers))
+	declaredOrders := globalMgr.GetAppShutdownOrders()
+	orders := make(map[string]types.ShutdownOrder, len(appManagers))
+	for i, appMgr := range appManagers {
+		indexes[appMgr.AppName] = i
+		orders[appMgr.AppName] = declaredOrders[appMgr.AppName]
+	}
+	return types.ShutdownPhases(orders), indexes
 }
 
 // GetAllAppManagers retrieves all app managers registered with the global manager.
@@ -208,7 +486,7 @@ func (GM *GlobalManagerStruct) Shutdown(safe bool) error {
 //	    fmt.Printf("App: %s\n", app.AppName)
 //	}
 func (GM *GlobalManagerStruct) GetAllAppManagers() ([]*types.AppManager, error) {
-	Global, err := types.GetGlobalManager()
+	Global, err := GM.getGlobalManager()
 	if err != nil {
 		return nil, err
 	}
@@ -229,7 +507,7 @@ func (GM *GlobalManagerStruct) GetAllAppManagers() ([]*types.AppManager, error)
 //	count := globalMgr.GetAppManagerCount()
 //	log.Printf("Total app managers: %d", count)
 func (GM *GlobalManagerStruct) GetAppManagerCount() int {
-	Global, err := types.GetGlobalManager()
+	Global, err := GM.getGlobalManager()
 	if err != nil {
 		return 0
 	}
@@ -379,6 +657,8 @@ func (GM *GlobalManagerStruct) GetGoroutineCount() int {
 // This method allows runtime configuration of shutdown timeouts, metrics settings,
 // goroutine limits, and other global parameters.
 //
+// Deprecated: Use Reconfigure with a typed types.Config.
+//
 // Parameters:
 //   - flag: Configuration flag (e.g., "SET_SHUTDOWN_TIMEOUT", "SET_METRICS_URL", "SET_MAX_ROUTINES")
 //   - value: Configuration value (type depends on flag)
@@ -437,7 +717,23 @@ func (GM *GlobalManagerStruct) GetMetadata() (*types.Metadata, error) {
 //	    log.Printf("Error: %v", err)
 //	}
 func (GM *GlobalManagerStruct) Get() (*types.GlobalManager, error) {
-	return types.GetGlobalManager()
+	return GM.getGlobalManager()
+}
+
+// GetState returns the lifecycle state of the global manager: running, draining while it shuts down,
+// or stopped once it has shut down. An uninitialized global manager is reported as stopped.
+//
+// Example:
+//
+//	if globalMgr.GetState() != types.StateRunning {
+//	    return
+//	}
+func (GM *GlobalManagerStruct) GetState() types.ManagerState {
+	globalManager, err := GM.getGlobalManager()
+	if err != nil {
+		return types.StateStopped
+	}
+	return globalManager.GetState()
 }
 
 // NewAppManager creates a new app manager within the global manager.
@@ -457,7 +753,7 @@ func (GM *GlobalManagerStruct) Get() (*types.GlobalManager, error) {
 //	    log.Fatalf("Failed to create app manager: %v", err)
 //	}
 func (GM *GlobalManagerStruct) NewAppManager(appName string) (interfaces.AppGoroutineManagerInterface, error) {
-	AppManager := app.NewAppManager(appName)
+	AppManager := app.NewAppManagerFor(GM.Global, appName)
 	_, err := AppManager.CreateApp()
 	if err != nil {
 		return nil, err
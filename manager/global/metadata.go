@@ -4,8 +4,6 @@ import (
 	"errors"
 	"time"
 
-	"github.com/JupiterMetaLabs/goroutine-orchestrator/ctxo"
-	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
 )
 
@@ -29,7 +27,7 @@ const (
 	SET_SHUTDOWN_TIMEOUT = "SET_SHUTDOWN_TIMEOUT"
 
 	// SET_MAX_ROUTINES configures the maximum number of concurrent goroutines allowed.
-	// This is a soft limit used for monitoring and alerting.
+	// This is enforced by Go() across all app and local managers (0 = unlimited).
 	// Accepted value types:
 	//   - int, int32, int64: maximum goroutine count
 	//   - *int: pointer to maximum goroutine count
@@ -58,6 +56,10 @@ type metricsConfig struct {
 
 // UpdateGlobalMetadata updates global configuration settings using the specified flag and value.
 // This method provides runtime configuration of timeouts, metrics, goroutine limits, and update intervals.
+// The new value is validated and applied through Reconfigure.
+//
+// Deprecated: Use Reconfigure with a typed types.Config, or pass options such as WithShutdownTimeout,
+// WithMaxRoutines and WithMetrics to Init.
 //
 // Parameters:
 //   - flag: Configuration flag constant (SET_METRICS_URL, SET_SHUTDOWN_TIMEOUT, SET_MAX_ROUTINES, SET_UPDATE_INTERVAL)
@@ -75,7 +77,7 @@ type metricsConfig struct {
 //	  - time.Duration: 30*time.Second
 //
 //	SET_MAX_ROUTINES:
-//	  - int: 1000 (soft limit for monitoring)
+//	  - int: 1000 (enforced by Go(), 0 = unlimited)
 //
 //	SET_UPDATE_INTERVAL:
 //	  - time.Duration: 10*time.Second (metrics collection frequency)
@@ -88,7 +90,7 @@ type metricsConfig struct {
 //
 // Returns:
 //   - *types.Metadata: Updated metadata configuration
-//   - error: Returns error if flag is unknown or value type is incorrect
+//   - error: Returns error if flag is unknown, value type is incorrect, or the value is invalid (ErrInvalidConfig)
 //
 // Examples:
 //
@@ -106,12 +108,12 @@ type metricsConfig struct {
 //	metadata, err := globalMgr.UpdateGlobalMetadata(SET_MAX_ROUTINES, 1000)
 func (GM *GlobalManagerStruct) UpdateGlobalMetadata(flag string, value interface{}) (*types.Metadata, error) {
 	// Get the global manager first
-	g, err := types.GetGlobalManager()
+	g, err := GM.getGlobalManager()
 	if err != nil {
 		return nil, err
 	}
 
-	metadata := g.NewMetadata()
+	cfg := g.GetConfig()
 
 	switch flag {
 	case SET_METRICS_URL:
@@ -122,32 +124,20 @@ func (GM *GlobalManagerStruct) UpdateGlobalMetadata(flag string, value interface
 		//  - []interface{}{bool, string, time.Duration} or [3]interface{}{bool, string, time.Duration} (with interval)
 		var enabled bool
 		var url string
-		var interval = types.UpdateInterval
+		var interval time.Duration
 
 		switch v := value.(type) {
 		case string:
 			enabled = true
 			url = v
-			interval = types.UpdateInterval
-			metadata.SetMetrics(enabled, url, interval)
 		case metricsConfig:
 			enabled = v.Enabled
 			url = v.URL
-			if v.Interval > 0 {
-				interval = v.Interval
-			} else {
-				interval = types.UpdateInterval
-			}
-			metadata.SetMetrics(v.Enabled, v.URL, interval)
+			interval = v.Interval
 		case *metricsConfig:
 			enabled = v.Enabled
 			url = v.URL
-			if v.Interval > 0 {
-				interval = v.Interval
-			} else {
-				interval = types.UpdateInterval
-			}
-			metadata.SetMetrics(v.Enabled, v.URL, interval)
+			interval = v.Interval
 		case []interface{}:
 			if len(v) == 2 {
 				// [enabled(bool), url(string)] - use default interval
@@ -158,8 +148,6 @@ func (GM *GlobalManagerStruct) UpdateGlobalMetadata(flag string, value interface
 				}
 				enabled = enabledVal
 				url = urlVal
-				interval = types.UpdateInterval
-				metadata.SetMetrics(enabledVal, urlVal, interval)
 			} else if len(v) == 3 {
 				// [enabled(bool), url(string), interval(time.Duration)] - with interval
 				enabledVal, ok1 := v[0].(bool)
@@ -171,7 +159,6 @@ func (GM *GlobalManagerStruct) UpdateGlobalMetadata(flag string, value interface
 				enabled = enabledVal
 				url = urlVal
 				interval = intervalVal
-				metadata.SetMetrics(enabledVal, urlVal, interval)
 			} else {
 				return nil, errors.New("metrics: expected slice of length 2 or 3: [enabled(bool), url(string)] or [enabled(bool), url(string), interval(time.Duration)]")
 			}
@@ -184,8 +171,6 @@ func (GM *GlobalManagerStruct) UpdateGlobalMetadata(flag string, value interface
 			}
 			enabled = enabledVal
 			url = urlVal
-			interval = types.UpdateInterval
-			metadata.SetMetrics(enabledVal, urlVal, interval)
 		case [3]interface{}:
 			// [enabled(bool), url(string), interval(time.Duration)] - with interval
 			enabledVal, ok1 := v[0].(bool)
@@ -197,59 +182,24 @@ func (GM *GlobalManagerStruct) UpdateGlobalMetadata(flag string, value interface
 			enabled = enabledVal
 			url = urlVal
 			interval = intervalVal
-			metadata.SetMetrics(enabledVal, urlVal, interval)
 		default:
 			return nil, errors.New("metrics: unsupported value type; expected string, metricsConfig, [bool,string], or [bool,string,time.Duration]")
 		}
 
-		// Handle metrics enable/disable
-		if enabled {
-			// Always initialize metrics if enabled (InitMetrics is idempotent)
-			metrics.InitMetrics()
-			// The interval is now stored in metadata and will be used by the collector
-			// via types.UpdateInterval (set by SetMetrics)
-			// Notify collector about interval change (Observer pattern)
-			metrics.UpdateMetricsUpdateInterval()
-			if url != "" {
-				// Start the metrics server if a URL is provided
-				// If server is already running, ignore the error (idempotent behavior)
-				if err := metrics.StartMetricsServer(url); err != nil {
-					// If server is already running, continue (metrics are already active)
-					// Only return error if it's a different error
-					if err.Error() != "metrics server is already running" {
-						return nil, err
-					}
-				}
-			} else {
-				// Start the collector (metrics will be collected periodically)
-				// The collector uses types.UpdateInterval which is set by SetMetrics
-				// StartCollector is idempotent - it checks if collector is already running
-				metrics.StartCollector()
-			}
+		cfg.Metrics = enabled
+		cfg.MetricsURL = url
+		if interval > 0 {
+			cfg.MetricsInterval = interval
 		} else {
-			// Disable metrics only if they are currently enabled
-			// Check if collector is running before stopping
-			if metrics.IsCollectorRunning() {
-				metrics.StopCollector()
-			}
-			// Check if server is running before stopping
-			if metrics.IsServerRunning() {
-				// Get the parent context for this - dont create a new context
-				// spawn a child context from global context
-				ctx, cancel := ctxo.SpawnChild(g.Ctx)
-				// StopMetricsServer may return error if not running, but we checked, so ignore errors
-				_ = metrics.StopMetricsServer(ctx)
-				cancel()
-			}
-			// If metrics are not running, continue (no-op)
+			cfg.MetricsInterval = types.UpdateInterval
 		}
 
 	case SET_SHUTDOWN_TIMEOUT:
 		switch t := value.(type) {
 		case time.Duration:
-			metadata.SetShutdownTimeout(t)
+			cfg.ShutdownTimeout = t
 		case *time.Duration:
-			metadata.SetShutdownTimeout(*t)
+			cfg.ShutdownTimeout = *t
 		default:
 			return nil, errors.New("shutdown timeout: expected time.Duration")
 		}
@@ -257,13 +207,13 @@ func (GM *GlobalManagerStruct) UpdateGlobalMetadata(flag string, value interface
 	case SET_MAX_ROUTINES:
 		switch n := value.(type) {
 		case int:
-			metadata.SetMaxRoutines(n)
+			cfg.MaxRoutines = n
 		case int32:
-			metadata.SetMaxRoutines(int(n))
+			cfg.MaxRoutines = int(n)
 		case int64:
-			metadata.SetMaxRoutines(int(n))
+			cfg.MaxRoutines = int(n)
 		case *int:
-			metadata.SetMaxRoutines(*n)
+			cfg.MaxRoutines = *n
 		default:
 			return nil, errors.New("max routines: expected integer type")
 		}
@@ -271,9 +221,9 @@ func (GM *GlobalManagerStruct) UpdateGlobalMetadata(flag string, value interface
 	case SET_UPDATE_INTERVAL:
 		switch t := value.(type) {
 		case time.Duration:
-			metadata.UpdateIntervalTime(t)
+			cfg.MetricsInterval = t
 		case *time.Duration:
-			metadata.UpdateIntervalTime(*t)
+			cfg.MetricsInterval = *t
 		default:
 			return nil, errors.New("update interval: expected time.Duration")
 		}
@@ -282,7 +232,11 @@ func (GM *GlobalManagerStruct) UpdateGlobalMetadata(flag string, value interface
 		return nil, errors.New("unknown update flag")
 	}
 
-	return metadata, nil
+	// Validate and apply the change like any other reconfiguration
+	if _, err := GM.reconfigure(g, cfg); err != nil {
+		return nil, err
+	}
+	return g.NewMetadata(), nil
 }
 
 // GetGlobalMetadata retrieves the current global metadata configuration.
@@ -305,7 +259,7 @@ func (GM *GlobalManagerStruct) UpdateGlobalMetadata(flag string, value interface
 //	}
 //	log.Printf("Shutdown timeout: %v", metadata.GetShutdownTimeout())
 func (GM *GlobalManagerStruct) GetGlobalMetadata() (*types.Metadata, error) {
-	g, err := types.GetGlobalManager()
+	g, err := GM.getGlobalManager()
 	if err != nil {
 		return nil, err
 	}
@@ -15,7 +15,7 @@ type GoroutineOption interface{}
 
 // Initializer initializes the manager
 type GlobalInitializer interface {
-	Init() (*types.GlobalManager, error)
+	Init(opts ...types.ConfigOption) (*types.GlobalManager, error)
 	Get() (*types.GlobalManager, error)
 }
 
@@ -24,6 +24,46 @@ type Shutdowner interface {
 	Shutdown(safe bool) error
 }
 
+// ShutdownReporter shuts down a manager and reports what happened to each routine
+type ShutdownReporter interface {
+	ShutdownWithReport(safe bool) (*types.ShutdownReport, error)
+	ShutdownWithTimeout(safe bool, timeout time.Duration) (*types.ShutdownReport, error)
+	ShutdownContext(ctx context.Context) (*types.ShutdownReport, error)
+}
+
+// StateGetter reports the lifecycle state of a manager (running, draining or stopped)
+type StateGetter interface {
+	GetState() types.ManagerState
+}
+
+// Drainer drains a manager: running routines are left to finish before they are cancelled and then removed
+type Drainer interface {
+	Drain(config types.DrainConfig) (*types.ShutdownReport, error)
+}
+
+// ShutdownOrderer declares when a manager shuts down relative to its sibling managers
+type ShutdownOrderer interface {
+	SetShutdownPriority(priority int) error
+	ShutdownAfter(names ...string) error
+	GetShutdownOrder() types.ShutdownOrder
+}
+
+// EventSubscriber registers subscribers for routine and manager lifecycle events
+type EventSubscriber interface {
+	Subscribe(subscriber types.Subscriber) (unsubscribe func())
+}
+
+// PanicHandlerSetter sets the global handler for panics recovered from routines
+type PanicHandlerSetter interface {
+	SetPanicHandler(handler types.PanicHandler)
+}
+
+// Configurer reads and changes the typed configuration of the Global manager
+type Configurer interface {
+	GetConfig() (types.Config, error)
+	Reconfigure(cfg types.Config) ([]types.ConfigChange, error)
+}
+
 // MetadataManager handles metadata of the Global manager
 type MetadataManager interface {
 	// NewMetadata() *types.Metadata
@@ -42,6 +82,7 @@ type GoroutineSpawner interface {
 // FunctionShutdowner handles shutdown of specific functions
 type FunctionShutdowner interface {
 	ShutdownFunction(functionName string, timeout time.Duration) error
+	ShutdownFunctionContext(ctx context.Context, functionName string) error
 }
 
 // GoroutineLister lists all tracked goroutines
@@ -87,6 +128,7 @@ type FunctionWaitGroupCreator interface {
 type FunctionWaitGroupManager interface {
 	WaitForFunction(functionName string) error
 	WaitForFunctionWithTimeout(functionName string, timeout time.Duration) bool
+	WaitForFunctionContext(ctx context.Context, functionName string) error
 	GetFunctionGoroutineCount(functionName string) int
 }
 
@@ -94,6 +136,7 @@ type FunctionWaitGroupManager interface {
 type RoutineManager interface {
 	CancelRoutine(routineID string) error
 	WaitForRoutine(routineID string, timeout time.Duration) bool
+	WaitForRoutineContext(ctx context.Context, routineID string) error
 	IsRoutineDone(routineID string) bool
 	GetRoutineContext(routineID string) context.Context
 	GetRoutineStartedAt(routineID string) int64
@@ -101,6 +144,79 @@ type RoutineManager interface {
 	IsRoutineContextCancelled(routineID string) bool
 	GetRoutine(routineID string) (*types.Routine, error)
 	GetRoutinesByFunctionName(functionName string) ([]*types.Routine, error)
+	GetRoutineResult(routineID string) (*types.RoutineResult, error)
+	WaitForRoutineResult(routineID string, timeout time.Duration) (*types.RoutineResult, error)
+}
+
+// RoutineLimiter sets the routine quota enforced by Go() at this manager level
+type RoutineLimiter interface {
+	SetMaxRoutines(maxRoutines int) error
+	GetMaxRoutines() int
+}
+
+// ManagerConfigurer overrides the inherited shutdown timeout and routine defaults at this manager level
+type ManagerConfigurer interface {
+	SetShutdownTimeout(timeout time.Duration) error
+	SetRoutineDefaults(defaults types.FunctionConfig) error
+	GetEffectiveConfig() types.ManagerConfig
+}
+
+// OptionDefaulter registers the options Go() applies before the options passed to it
+type OptionDefaulter interface {
+	SetDefaultOptions(opts ...GoroutineOption) error
+	SetFunctionOptions(functionName string, opts ...GoroutineOption) error
+	GetEffectiveOptions(functionName string, opts ...GoroutineOption) types.RoutineOptions
+}
+
+// InterceptorUser registers interceptors wrapping the worker runs of the routines at this manager level
+type InterceptorUser interface {
+	Use(interceptors ...types.Interceptor) (remove func(), err error)
+}
+
+// RoutineGroup runs a set of tracked goroutines with first-error cancellation (errgroup style)
+type RoutineGroup interface {
+	Go(functionName string, workerFunc func(ctx context.Context) error, opts ...GoroutineOption) error
+	Wait() error
+	SetLimit(n int)
+	Context() context.Context
+}
+
+// GroupCreator creates routine groups
+type GroupCreator interface {
+	NewGroup(ctx context.Context, name string) RoutineGroup
+}
+
+// WorkerPool runs submitted tasks on a bounded set of tracked worker routines
+type WorkerPool interface {
+	types.Pool
+
+	Submit(ctx context.Context, task func(ctx context.Context) error) error
+	TrySubmit(task func(ctx context.Context) error) error
+	SubmitWait(ctx context.Context, task func(ctx context.Context) error) error
+	GetWorkerCount() int
+	GetQueueDepth() int
+}
+
+// WorkerPoolCreator creates and looks up worker pools owned by a local manager
+type WorkerPoolCreator interface {
+	NewWorkerPool(name string, config types.WorkerPoolConfig) (WorkerPool, error)
+	GetWorkerPool(name string) (WorkerPool, error)
+}
+
+// Schedule runs a worker function as tracked routines on an interval, fixed delay or cron schedule
+type Schedule interface {
+	types.Schedule
+
+	NextRun() time.Time
+	LastRun() time.Time
+	LastError() error
+	Done() <-chan struct{}
+}
+
+// ScheduleCreator creates and looks up schedules owned by a local manager
+type ScheduleCreator interface {
+	NewSchedule(name string, config types.ScheduleConfig, workerFunc func(ctx context.Context) error, opts ...GoroutineOption) (Schedule, error)
+	GetSchedule(name string) (Schedule, error)
 }
 
 // ----------------------
@@ -111,8 +227,16 @@ type RoutineManager interface {
 type GlobalGoroutineManagerInterface interface {
 	GlobalInitializer
 	Shutdowner
+	ShutdownReporter
+	Drainer
+	StateGetter
 
 	MetadataManager
+	Configurer
+
+	EventSubscriber
+	PanicHandlerSetter
+	InterceptorUser
 
 	AppManagerLister
 
@@ -126,6 +250,10 @@ type GlobalGoroutineManagerInterface interface {
 // AppGoroutineManagerInterface defines the complete interface for app manager
 type AppGoroutineManagerInterface interface {
 	Shutdowner
+	ShutdownReporter
+	Drainer
+	StateGetter
+	ShutdownOrderer
 
 	AppManagerCreator
 
@@ -135,6 +263,10 @@ type AppGoroutineManagerInterface interface {
 
 	LocalManagerGetter
 
+	RoutineLimiter
+	ManagerConfigurer
+	InterceptorUser
+
 	// NewLocalManager creates a new local manager within this app manager
 	NewLocalManager(localName string) (LocalGoroutineManagerInterface, error)
 }
@@ -142,6 +274,10 @@ type AppGoroutineManagerInterface interface {
 // LocalGoroutineManagerInterface defines the complete interface for local manager
 type LocalGoroutineManagerInterface interface {
 	Shutdowner
+	ShutdownReporter
+	Drainer
+	StateGetter
+	ShutdownOrderer
 	FunctionShutdowner
 
 	LocalManagerCreator
@@ -153,4 +289,13 @@ type LocalGoroutineManagerInterface interface {
 	GoroutineLister
 	FunctionWaitGroupCreator
 	FunctionWaitGroupManager
+
+	RoutineLimiter
+	ManagerConfigurer
+	OptionDefaulter
+	InterceptorUser
+
+	GroupCreator
+	WorkerPoolCreator
+	ScheduleCreator
 }
@@ -0,0 +1,191 @@
+package local
+
+import (
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// SetMaxRoutines sets the routine quota for this local manager.
+// Go() refuses (or waits, depending on the admission policy) once this many routines are tracked.
+// A value of 0 disables the local quota - the app and global limits still apply.
+//
+// Example:
+//
+//	localMgr.SetMaxRoutines(50)
+func (LM *LocalManagerStruct) SetMaxRoutines(maxRoutines int) error {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return err
+	}
+	localManager.SetMaxRoutines(maxRoutines)
+	// Wake waiters in case the quota was raised
+	LM.Global.Admission().Release()
+	return nil
+}
+
+// GetMaxRoutines returns the routine quota for this local manager (0 = unlimited).
+func (LM *LocalManagerStruct) GetMaxRoutines() int {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return 0
+	}
+	return localManager.GetMaxRoutines()
+}
+
+// admitRoutine enforces the global, app and local MaxRoutines limits and the function's
+// WithMaxConcurrent limit before a routine is spawned, and looks up the running routine to join
+// for WithSingleflight.
+//
+// On success the admission gate is still held and the returned function must be called once the
+// routine has been registered, so that the limit check and the registration are atomic.
+// When no limit or singleflight key is configured the gate is not taken at all.
+//
+// Returns:
+//   - func(): Releases the admission gate (nil if a routine is joined, never nil otherwise)
+//   - *types.Routine: The running routine with the same singleflight key, if any - nothing is spawned
+//   - error: An *errors.AdmissionRejectedError wrapping ErrMaxRoutinesReached or ErrMaxConcurrentReached
+//     (reject policy), ErrAdmissionTimeout (wait deadline expired), or the limit sentinel and a
+//     ManagerClosedError if the local manager shut down while waiting
+func (LM *LocalManagerStruct) admitRoutine(localManager *types.LocalManager, functionName string, opts *goroutineOptions) (func(), *types.Routine, error) {
+	appManager, err := LM.getAppManager()
+	if err != nil {
+		return nil, nil, err
+	}
+	globalManager, err := LM.getGlobalManager()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Fast path - no limits configured at any level
+	globalLimit := 0
+	if metadata := globalManager.GetMetadata(); metadata != nil {
+		globalLimit = metadata.GetMaxRoutines()
+	}
+	if globalLimit <= 0 && appManager.GetMaxRoutines() <= 0 && localManager.GetMaxRoutines() <= 0 &&
+		opts.maxConcurrent <= 0 && opts.singleflight == "" {
+		return func() {}, nil, nil
+	}
+
+	var deadline <-chan time.Time
+	if opts.admissionTimeout != nil {
+		timer := time.NewTimer(*opts.admissionTimeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	managerCtx, _ := localManager.GetLocalContext()
+	var managerDone <-chan struct{}
+	if managerCtx != nil {
+		managerDone = managerCtx.Done()
+	}
+
+	gate := globalManager.Admission()
+	waited := false
+	for {
+		gate.Lock()
+		// Join a running routine of the function with the same key - also after waiting for a slot
+		if opts.singleflight != "" {
+			if routine, ok := localManager.GetSingleflightRoutine(functionName, opts.singleflight); ok {
+				gate.Unlock()
+				metrics.RecordGoroutineOperation("singleflight_join", LM.AppName, LM.LocalName, functionName)
+				return nil, routine, nil
+			}
+		}
+
+		limitErr := LM.checkRoutineLimits(globalManager, appManager, localManager, functionName)
+		if limitErr == nil && opts.maxConcurrent > 0 {
+			limitErr = LM.checkConcurrencyLimit(localManager, functionName, opts.maxConcurrent)
+		}
+		if limitErr == nil {
+			if waited {
+				metrics.RecordGoroutineOperation("admission_wait", LM.AppName, LM.LocalName, functionName)
+			}
+			return gate.Unlock, nil, nil
+		}
+
+		if opts.admissionPolicy == AdmissionReject {
+			gate.Unlock()
+			metrics.RecordOperationError("goroutine", "admission", limitErr.Reason+"_reached")
+			metrics.RecordAdmissionRejection(LM.AppName, LM.LocalName, functionName, limitErr.Reason)
+			return nil, nil, limitErr
+		}
+
+		// Wait for a slot to free up, the deadline to expire or the manager to shut down
+		released := gate.Released()
+		gate.Unlock()
+		waited = true
+
+		select {
+		case <-released:
+			// A routine was removed - check the limits again
+		case <-deadline:
+			metrics.RecordOperationError("goroutine", "admission", errors.ReasonAdmissionTimeout)
+			metrics.RecordAdmissionRejection(LM.AppName, LM.LocalName, functionName, errors.ReasonAdmissionTimeout)
+			limitErr.Reason, limitErr.Err = errors.ReasonAdmissionTimeout, errors.ErrAdmissionTimeout
+			return nil, nil, limitErr
+		case <-managerDone:
+			metrics.RecordOperationError("goroutine", "admission", errors.ReasonManagerShutdown)
+			metrics.RecordAdmissionRejection(LM.AppName, LM.LocalName, functionName, errors.ReasonManagerShutdown)
+			limitErr.Reason = errors.ReasonManagerShutdown
+			limitErr.Cause = &errors.ManagerClosedError{App: LM.AppName, Local: LM.LocalName, Cause: managerCtx.Err()}
+			return nil, nil, limitErr
+		}
+	}
+}
+
+// checkRoutineLimits returns an AdmissionRejectedError wrapping ErrMaxRoutinesReached
+// if adding one more routine would exceed the global, app or local limit.
+// Must be called with the admission gate held.
+func (LM *LocalManagerStruct) checkRoutineLimits(globalManager *types.GlobalManager, appManager *types.AppManager, localManager *types.LocalManager, functionName string) *errors.AdmissionRejectedError {
+	rejection := func(scope string, limit int) *errors.AdmissionRejectedError {
+		return &errors.AdmissionRejectedError{
+			App:      LM.AppName,
+			Local:    LM.LocalName,
+			Function: functionName,
+			Reason:   errors.ReasonMaxRoutines,
+			Scope:    scope,
+			Limit:    limit,
+			Err:      errors.ErrMaxRoutinesReached,
+		}
+	}
+	if limit := localManager.GetMaxRoutines(); limit > 0 && localManager.GetRoutineCount() >= limit {
+		return rejection(errors.ScopeLocal, limit)
+	}
+	if limit := appManager.GetMaxRoutines(); limit > 0 && appManager.GetRoutineCount() >= limit {
+		return rejection(errors.ScopeApp, limit)
+	}
+	if metadata := globalManager.GetMetadata(); metadata != nil {
+		if limit := metadata.GetMaxRoutines(); limit > 0 && globalManager.GetRoutineCount() >= limit {
+			return rejection(errors.ScopeGlobal, limit)
+		}
+	}
+	return nil
+}
+
+// checkConcurrencyLimit returns an AdmissionRejectedError wrapping ErrMaxConcurrentReached if adding
+// one more routine of the function would exceed its WithMaxConcurrent limit in the local manager.
+// Must be called with the admission gate held.
+func (LM *LocalManagerStruct) checkConcurrencyLimit(localManager *types.LocalManager, functionName string, limit int) *errors.AdmissionRejectedError {
+	if localManager.GetFunctionRoutineCount(functionName) >= limit {
+		return &errors.AdmissionRejectedError{
+			App:      LM.AppName,
+			Local:    LM.LocalName,
+			Function: functionName,
+			Reason:   errors.ReasonMaxConcurrent,
+			Scope:    errors.ScopeFunction,
+			Limit:    limit,
+			Err:      errors.ErrMaxConcurrentReached,
+		}
+	}
+	return nil
+}
+
+// removeRoutine removes routine from the local manager and wakes the Go() calls of the manager tree
+// waiting for a routine slot. Must be called without the admission gate held.
+func (LM *LocalManagerStruct) removeRoutine(localManager *types.LocalManager, routine *types.Routine) {
+	localManager.RemoveRoutine(routine, false)
+	LM.Global.Admission().Release()
+}
@@ -0,0 +1,212 @@
+package local
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+)
+
+// cronSearchYears bounds the search for the next matching time of expressions like "0 0 30 2 *"
+const cronSearchYears = 5
+
+// cronDescriptors are the predefined schedules accepted in place of the five fields
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	dayNames = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// CronSchedule is a parsed cron expression
+type CronSchedule struct {
+	expr     string
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	domStar  bool // Day of month starts with "*" - days must match both fields
+	dowStar  bool // Day of week starts with "*" - days must match both fields
+	location *time.Location
+}
+
+// ParseCron parses a standard five field cron expression:
+//
+//	┌───────────── minute (0-59)
+//	│ ┌───────────── hour (0-23)
+//	│ │ ┌───────────── day of month (1-31)
+//	│ │ │ ┌───────────── month (1-12 or JAN-DEC)
+//	│ │ │ │ ┌───────────── day of week (0-6 or SUN-SAT, 7 is also Sunday)
+//	* * * * *
+//
+// Fields accept "*", values, ranges ("1-5"), lists ("1,15") and steps ("*/10", "0-30/5").
+// As in Vixie cron, a day matches if either the day of month or the day of week matches when both
+// are restricted. The descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight and
+// @hourly are accepted as well. Times are evaluated in location (time.Local if nil).
+func ParseCron(expr string, location *time.Location) (*CronSchedule, error) {
+	if location == nil {
+		location = time.Local
+	}
+
+	spec := strings.TrimSpace(expr)
+	if descriptor, ok := cronDescriptors[strings.ToLower(spec)]; ok {
+		spec = descriptor
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: cron expression %q must have 5 fields", errors.ErrInvalidSchedule, expr)
+	}
+
+	cron := &CronSchedule{
+		expr:     expr,
+		domStar:  strings.HasPrefix(fields[2], "*"),
+		dowStar:  strings.HasPrefix(fields[4], "*"),
+		location: location,
+	}
+	var err error
+	if cron.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("%w: cron expression %q: minute: %v", errors.ErrInvalidSchedule, expr, err)
+	}
+	if cron.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("%w: cron expression %q: hour: %v", errors.ErrInvalidSchedule, expr, err)
+	}
+	if cron.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("%w: cron expression %q: day of month: %v", errors.ErrInvalidSchedule, expr, err)
+	}
+	if cron.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("%w: cron expression %q: month: %v", errors.ErrInvalidSchedule, expr, err)
+	}
+	if cron.dow, err = parseCronField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("%w: cron expression %q: day of week: %v", errors.ErrInvalidSchedule, expr, err)
+	}
+	// 7 is Sunday as well
+	if cron.dow&(1<<7) != 0 {
+		cron.dow = cron.dow&^(1<<7) | 1
+	}
+
+	if cron.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("%w: cron expression %q never matches", errors.ErrInvalidSchedule, expr)
+	}
+	return cron, nil
+}
+
+// String returns the cron expression as given to ParseCron
+func (CS *CronSchedule) String() string {
+	return CS.expr
+}
+
+// Next returns the first matching time after t, or the zero time if there is none within five years
+func (CS *CronSchedule) Next(t time.Time) time.Time {
+	t = t.In(CS.location)
+	// Cron has minute resolution - start at the next whole minute
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, CS.location).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		if CS.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, CS.location)
+			continue
+		}
+		if !CS.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, CS.location)
+			continue
+		}
+		if CS.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, CS.location)
+			continue
+		}
+		if CS.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches applies the day of month and day of week fields
+func (CS *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := CS.dom&(1<<uint(t.Day())) != 0
+	dowMatch := CS.dow&(1<<uint(t.Weekday())) != 0
+	if CS.domStar || CS.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// parseCronField parses one comma separated field into a bit set of the allowed values
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangePart == "*":
+			low, high = min, max
+		case strings.Contains(rangePart, "-"):
+			lowPart, highPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseCronValue(lowPart, names); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(highPart, names); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if low, err = parseCronValue(rangePart, names); err != nil {
+				return 0, err
+			}
+			high = low
+			if hasStep {
+				// "5/15" means every 15 starting at 5
+				high = max
+			}
+		}
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue parses a number or a month/day name
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if n, ok := names[strings.ToUpper(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	return n, nil
+}
@@ -0,0 +1,247 @@
+package local
+
+import (
+	"context"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// Drain shuts the local manager down for a rolling deploy without interrupting its work.
+// Unlike Shutdown(true), which cancels every routine first and then waits, Drain lets the running
+// routines finish on their own and only escalates once the deadlines pass.
+//
+// Drain Flow:
+//  1. Rejects new routines (the local manager is draining, see GetState)
+//  2. Stops starting schedule runs and closes worker pools to new tasks - runs in progress and
+//     queued tasks still finish
+//  3. Waits up to config.WaitTimeout for the routines to finish - no context is cancelled
+//  4. Cancels the contexts of the routines still running and waits up to config.CancelTimeout
+//     (worker pools that did not drain are stopped)
+//  5. Removes the routines still running from tracking and cancels the local manager's context
+//
+// config.OnProgress is called when each phase begins and every config.ProgressInterval with the
+// routines still running per function. The report lists routines that finished on their own as
+// graceful, and the others as force-cancelled or still running, with the "drain_schedules",
+// "drain_wait", "drain_cancel" and "force_remove" phases.
+//
+// Returns:
+//   - *types.ShutdownReport: The local level report (never nil)
+//   - error: nil on success, error if local manager not found
+//
+// Example:
+//
+//	report, err := localMgr.Drain(types.DrainConfig{
+//	    WaitTimeout:   30 * time.Second,
+//	    CancelTimeout: 5 * time.Second,
+//	    OnProgress: func(progress types.DrainProgress) {
+//	        log.Printf("%s: %d routines left %v", progress.Phase, progress.Remaining, progress.Functions)
+//	    },
+//	})
+func (LM *LocalManagerStruct) Drain(config types.DrainConfig) (*types.ShutdownReport, error) {
+	startTime := time.Now()
+	report := types.NewShutdownReport(types.ShutdownLevelLocal, LM.LocalName, true)
+
+	defer func() {
+		report.Finish()
+		LM.publish(types.Event{
+			Type:      types.EventShutdownFinished,
+			Manager:   types.EventManagerLocal,
+			AppName:   LM.AppName,
+			LocalName: LM.LocalName,
+			Safe:      true,
+			Duration:  time.Since(startTime),
+			Report:    report,
+		})
+	}()
+
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		metrics.RecordOperationError("manager", "drain", "get_local_manager_failed")
+		report.AddError(err)
+		return report, err
+	}
+
+	// Fill in defaults - the shutdown timeout in effect for this local manager
+	shutdownTimeout := LM.GetEffectiveConfig().ShutdownTimeout
+	if config.WaitTimeout <= 0 {
+		config.WaitTimeout = shutdownTimeout
+	}
+	if config.CancelTimeout <= 0 {
+		config.CancelTimeout = shutdownTimeout
+	}
+	if config.ProgressInterval <= 0 {
+		config.ProgressInterval = types.DefaultDrainProgressInterval
+	}
+
+	// Go() rejects new routines from now on - the routines collected below are all there are
+	localManager.SetState(types.StateDraining)
+	defer localManager.SetState(types.StateStopped)
+
+	LM.publish(types.Event{
+		Type:      types.EventShutdownBegan,
+		Manager:   types.EventManagerLocal,
+		AppName:   LM.AppName,
+		LocalName: LM.LocalName,
+		Safe:      true,
+	})
+
+	// Stop starting schedule runs - the runs in progress are waited for like any other routine
+	phaseStart := time.Now()
+	for _, schedule := range localManager.GetSchedules() {
+		schedule.Drain()
+	}
+	report.AddPhase("drain_schedules", phaseStart)
+
+	routines, err := LM.GetAllGoroutines()
+	if err != nil {
+		metrics.RecordOperationError("manager", "drain", "get_goroutines_failed")
+		report.AddError(err)
+		return report, err
+	}
+	defer func() {
+		for _, routine := range routines {
+			localManager.RemoveFunctionWg(routine.GetFunctionName())
+		}
+	}()
+
+	// Worker pools run their queued tasks alongside the wait - pools still busy at the wait deadline
+	// are stopped, which cancels their workers
+	poolReport := types.NewShutdownReport(types.ShutdownLevelLocal, LM.LocalName, true)
+	poolsDone := make(chan struct{})
+	go func() {
+		defer close(poolsDone)
+		LM.drainPools(context.Background(), localManager, config.WaitTimeout, poolReport)
+	}()
+
+	// Phase 1: wait for the routines to finish on their own
+	phaseStart = time.Now()
+	pending := LM.waitForDrain(routines, types.DrainPhaseWait, config.WaitTimeout, config, startTime)
+	<-poolsDone
+	report.Errors = append(report.Errors, poolReport.Errors...)
+	report.AddPhase("drain_wait", phaseStart)
+
+	// Routines that have not finished by now are force-cancelled
+	forced := make(map[string]bool, len(pending))
+	for id := range pending {
+		forced[id] = true
+	}
+
+	// Phase 2: cancel the routines still running and wait for them
+	if len(pending) > 0 {
+		phaseStart = time.Now()
+		var remaining []*types.Routine
+		for _, routine := range routines {
+			if !pending[routine.GetID()] {
+				continue
+			}
+			remaining = append(remaining, routine)
+			if cancel := routine.GetCancel(); cancel != nil {
+				cancel()
+			}
+		}
+		pending = LM.waitForDrain(remaining, types.DrainPhaseCancel, config.CancelTimeout, config, startTime)
+		report.AddPhase("drain_cancel", phaseStart)
+	}
+
+	// Phase 3: stop tracking the routines that ignored the cancellation
+	if len(pending) > 0 {
+		phaseStart = time.Now()
+		var remaining []*types.Routine
+		for _, routine := range routines {
+			if pending[routine.GetID()] {
+				remaining = append(remaining, routine)
+			}
+		}
+		LM.reportDrainProgress(config, types.DrainPhaseForce, remaining, startTime)
+		for _, routine := range remaining {
+			LM.removeRoutine(localManager, routine)
+		}
+
+		LM.publish(types.Event{
+			Type:      types.EventForceCancel,
+			Manager:   types.EventManagerLocal,
+			AppName:   LM.AppName,
+			LocalName: LM.LocalName,
+			Safe:      true,
+			Count:     len(remaining),
+		})
+
+		if localManager.Cancel != nil {
+			localManager.Cancel()
+		}
+		report.AddPhase("force_remove", phaseStart)
+	}
+
+	addRoutineReports(report, routines, forced)
+	return report, nil
+}
+
+// waitForDrain waits up to timeout for routines to finish, reporting progress when it starts and
+// every config.ProgressInterval. Returns the IDs of the routines still running.
+func (LM *LocalManagerStruct) waitForDrain(routines []*types.Routine, phase string, timeout time.Duration, config types.DrainConfig, startTime time.Time) map[string]bool {
+	remaining := LM.reportDrainProgress(config, phase, routines, startTime)
+	if len(remaining) == 0 {
+		return pendingRoutines(routines)
+	}
+
+	// Closed once every routine has finished - stop ends the watcher if the timeout expires first
+	finished := make(chan struct{})
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for _, routine := range remaining {
+			doneChan := routine.DoneChan()
+			if doneChan == nil {
+				continue
+			}
+			select {
+			case <-doneChan:
+			case <-stop:
+				return
+			}
+		}
+		close(finished)
+	}()
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(config.ProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-finished:
+			return pendingRoutines(routines)
+		case <-deadline.C:
+			return pendingRoutines(routines)
+		case <-ticker.C:
+			LM.reportDrainProgress(config, phase, routines, startTime)
+		}
+	}
+}
+
+// reportDrainProgress calls config.OnProgress with the routines that are still running and returns them
+func (LM *LocalManagerStruct) reportDrainProgress(config types.DrainConfig, phase string, routines []*types.Routine, startTime time.Time) []*types.Routine {
+	var remaining []*types.Routine
+	functions := make(map[string]int)
+	for _, routine := range routines {
+		if !isRoutineDone(routine) {
+			remaining = append(remaining, routine)
+			functions[routine.GetFunctionName()]++
+		}
+	}
+
+	if config.OnProgress != nil {
+		config.OnProgress(types.DrainProgress{
+			AppName:   LM.AppName,
+			LocalName: LM.LocalName,
+			Phase:     phase,
+			Remaining: len(remaining),
+			Functions: functions,
+			Elapsed:   time.Since(startTime),
+		})
+	}
+	return remaining
+}
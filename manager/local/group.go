@@ -0,0 +1,169 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+)
+
+// Group runs a set of tracked goroutines as one unit, in the style of errgroup.
+// The first member that returns an error (or panics) cancels the group's context,
+// and Wait returns that first error once every member has finished.
+//
+// Every member is a normal tracked routine of the local manager: it shows up in
+// GetAllGoroutines and metrics under its own function name, and is cancelled by
+// ShutdownFunction and Shutdown like any other routine.
+type Group struct {
+	LM   *LocalManagerStruct
+	Name string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	sem    chan struct{} // nil means no concurrency limit
+
+	errOnce sync.Once
+	err     error
+}
+
+// Ensure Group satisfies interfaces.RoutineGroup
+var _ interfaces.RoutineGroup = (*Group)(nil)
+
+// NewGroup creates a group whose members run in this local manager.
+// The group's context is derived from ctx - cancelling ctx cancels every member.
+//
+// Example:
+//
+//	group := localMgr.NewGroup(ctx, "fetchers")
+//	for _, url := range urls {
+//	    url := url
+//	    group.Go("fetch", func(ctx context.Context) error { return fetch(ctx, url) })
+//	}
+//	if err := group.Wait(); err != nil {
+//	    log.Printf("fetch failed: %v", err)
+//	}
+func (LM *LocalManagerStruct) NewGroup(ctx context.Context, name string) interfaces.RoutineGroup {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	groupCtx, cancel := context.WithCancel(ctx)
+	return &Group{
+		LM:     LM,
+		Name:   name,
+		ctx:    groupCtx,
+		cancel: cancel,
+	}
+}
+
+// Context returns the group's context, cancelled on the first member error or when Wait returns.
+func (G *Group) Context() context.Context {
+	return G.ctx
+}
+
+// SetLimit limits the number of active members to n. A negative value removes the limit.
+// Once the limit is reached, Go blocks until a member finishes.
+//
+// SetLimit must not be called while members are running - it panics in that case.
+func (G *Group) SetLimit(n int) {
+	if G.sem != nil && len(G.sem) != 0 {
+		panic(fmt.Errorf("group %s: modify limit while %d members are still active", G.Name, len(G.sem)))
+	}
+	if n < 0 {
+		G.sem = nil
+		return
+	}
+	G.sem = make(chan struct{}, n)
+}
+
+// Go spawns workerFunc as a tracked member of the group under functionName.
+// The worker's context is cancelled when the routine is cancelled or the group's context is done.
+//
+// Go blocks while the group's limit is reached. It returns an error without spawning if the
+// group's context is already done, or if the local manager refuses the routine (e.g. admission).
+// A spawn error is not recorded as the group's error.
+//
+// Members run once: options (passed or defaulted for functionName) that supervise the routine
+// (WithRestart) or join a running routine (WithSingleflight) return an error wrapping ErrInvalidConfig.
+func (G *Group) Go(functionName string, workerFunc func(ctx context.Context) error, opts ...interfaces.GoroutineOption) error {
+	options := G.LM.resolveOptions(functionName, opts)
+	if options.supervised() {
+		metrics.RecordOperationError("group", "go", "invalid_options")
+		return fmt.Errorf("%w: group %s: member %s cannot be supervised", errors.ErrInvalidConfig, G.Name, functionName)
+	}
+	if options.singleflight != "" {
+		metrics.RecordOperationError("group", "go", "invalid_options")
+		return fmt.Errorf("%w: group %s: member %s cannot join a running routine", errors.ErrInvalidConfig, G.Name, functionName)
+	}
+
+	if G.sem != nil {
+		select {
+		case G.sem <- struct{}{}:
+		case <-G.ctx.Done():
+			return G.ctx.Err()
+		}
+	}
+	if err := G.ctx.Err(); err != nil {
+		G.release()
+		return err
+	}
+
+	G.wg.Add(1)
+	err := G.LM.spawnGoroutine(functionName, func(routineCtx context.Context) error {
+		returned := false
+		defer func() {
+			if !returned {
+				// Member panicked - the routine itself recovers (or re-raises) the panic
+				G.setErr(fmt.Errorf("group %s: %w", G.Name, &errors.RoutinePanicError{App: G.LM.AppName, Local: G.LM.LocalName, Function: functionName}))
+			}
+			G.release()
+			G.wg.Done()
+		}()
+
+		// Combine cancellations: routine cancel (shutdown) or group cancel (first error)
+		memberCtx, cancel := context.WithCancel(routineCtx)
+		stop := context.AfterFunc(G.ctx, cancel)
+		defer stop()
+		defer cancel()
+
+		err := workerFunc(memberCtx)
+		returned = true
+		if err != nil {
+			G.setErr(err)
+		}
+		return err
+	}, options)
+	if err != nil {
+		G.release()
+		G.wg.Done()
+		metrics.RecordOperationError("group", "go", "spawn_failed")
+		return err
+	}
+	return nil
+}
+
+// Wait blocks until every member has finished, then returns the first member error (if any).
+// The group's context is cancelled when Wait returns.
+func (G *Group) Wait() error {
+	G.wg.Wait()
+	G.cancel()
+	return G.err
+}
+
+// setErr records the first error and cancels the remaining members
+func (G *Group) setErr(err error) {
+	G.errOnce.Do(func() {
+		G.err = err
+		G.cancel()
+	})
+}
+
+// release frees a concurrency slot taken by Go
+func (G *Group) release() {
+	if G.sem != nil {
+		<-G.sem
+	}
+}
@@ -0,0 +1,126 @@
+package local
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// Use adds interceptors wrapping every worker run of the routines spawned by this local manager,
+// after those added before. Each run is wrapped by, outermost first: the built-in panic recovery and
+// metrics interceptors, the interceptors of the global manager, of the app manager, of this local
+// manager and those passed to Go() (WithInterceptors). Routines already running keep their chain.
+//
+// Returns:
+//   - func(): Removes the interceptors again - routines already running keep their chain
+//   - error: Returns error if local manager is not found, or an error wrapping ErrInvalidConfig if an
+//     interceptor is nil
+//
+// Example:
+//
+//	localMgr.Use(func(next types.WorkerFunc) types.WorkerFunc {
+//	    return func(ctx context.Context) error {
+//	        info, _ := types.RoutineInfoFromContext(ctx)
+//	        log.Printf("Running %s (%s)", info.FunctionName, info.RoutineID)
+//	        return next(ctx)
+//	    }
+//	})
+func (LM *LocalManagerStruct) Use(interceptors ...types.Interceptor) (remove func(), err error) {
+	if err := types.ValidateInterceptors(interceptors); err != nil {
+		return nil, err
+	}
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return nil, err
+	}
+	return localManager.UseInterceptors(interceptors...), nil
+}
+
+// resolveInterceptors returns the interceptors of the global, app and local manager followed by
+// those passed to Go(), outermost first. Routines of the local manager itself (pool workers,
+// schedule loops) only get those passed to goInternal.
+func (LM *LocalManagerStruct) resolveInterceptors(localManager *types.LocalManager, opts *goroutineOptions) []types.Interceptor {
+	if opts.internal {
+		return opts.interceptors
+	}
+	var globalInterceptors, appInterceptors []types.Interceptor
+	if globalManager, err := LM.getGlobalManager(); err == nil {
+		globalInterceptors = globalManager.GetInterceptors()
+	}
+	if appManager, err := LM.getAppManager(); err == nil {
+		appInterceptors = appManager.GetInterceptors()
+	}
+	localInterceptors := localManager.GetInterceptors()
+
+	count := len(globalInterceptors) + len(appInterceptors) + len(localInterceptors) + len(opts.interceptors)
+	if count == 0 {
+		return nil
+	}
+	interceptors := make([]types.Interceptor, 0, count)
+	interceptors = append(interceptors, globalInterceptors...)
+	interceptors = append(interceptors, appInterceptors...)
+	interceptors = append(interceptors, localInterceptors...)
+	return append(interceptors, opts.interceptors...)
+}
+
+// recoverPanics is the built-in interceptor recovering a panic of the rest of the chain. A recovered
+// panic is stored in panicInfo, passed to the panic handler and returned as an
+// *errors.RoutinePanicError (wrapping ErrRoutinePanicked) including the stack.
+func (LM *LocalManagerStruct) recoverPanics(handler types.PanicHandler, panicInfo **types.PanicInfo) types.Interceptor {
+	return func(next types.WorkerFunc) types.WorkerFunc {
+		return func(ctx context.Context) (err error) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+				// Panic is recovered, keep it as the routine's error and continue with normal cleanup
+				info, _ := types.RoutineInfoFromContext(ctx)
+				*panicInfo = &types.PanicInfo{
+					AppName:      LM.AppName,
+					LocalName:    LM.LocalName,
+					FunctionName: info.FunctionName,
+					RoutineID:    info.RoutineID,
+					Value:        r,
+					Stack:        debug.Stack(),
+					RecoveredAt:  time.Now(),
+				}
+				err = &errors.RoutinePanicError{
+					App:       LM.AppName,
+					Local:     LM.LocalName,
+					Function:  info.FunctionName,
+					RoutineID: info.RoutineID,
+					Value:     r,
+					Stack:     (*panicInfo).Stack,
+				}
+				LM.handlePanic(*panicInfo, handler)
+				LM.publish(types.Event{
+					Type:         types.EventRoutinePanicked,
+					AppName:      LM.AppName,
+					LocalName:    LM.LocalName,
+					FunctionName: info.FunctionName,
+					RoutineID:    info.RoutineID,
+					Err:          &errors.RoutinePanicError{App: LM.AppName, Local: LM.LocalName, Function: info.FunctionName, RoutineID: info.RoutineID, Value: r},
+					Panic:        *panicInfo,
+				})
+			}()
+			return next(ctx)
+		}
+	}
+}
+
+// recordRunMetrics is the built-in interceptor recording the duration of each worker run,
+// including the interceptors it wraps
+func (LM *LocalManagerStruct) recordRunMetrics(next types.WorkerFunc) types.WorkerFunc {
+	return func(ctx context.Context) error {
+		start := time.Now()
+		err := next(ctx)
+		info, _ := types.RoutineInfoFromContext(ctx)
+		metrics.RecordGoroutineOperationDuration("run", time.Since(start), LM.AppName, LM.LocalName, info.FunctionName)
+		return err
+	}
+}
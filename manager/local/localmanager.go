@@ -3,9 +3,11 @@ package local
 import (
 	"context"
 	"fmt"
+	"runtime/pprof"
 	"sync"
 	"time"
 
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/ctxo"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
@@ -21,6 +23,8 @@ type LocalManagerStruct struct {
 	AppName string
 	// LocalName is the unique identifier for this local manager within the app
 	LocalName string
+	// Global is the manager tree the local manager belongs to - nil means the package-level types.Global
+	Global *types.GlobalManager
 }
 
 // NewLocalManager creates and returns a new LocalManager instance.
@@ -45,8 +49,56 @@ func NewLocalManager(appName, localName string) interfaces.LocalGoroutineManager
 	}
 }
 
+// NewLocalManagerFor returns a LocalManager of the app manager appName within globalManager instead
+// of the package-level singleton (nil means the singleton). Call CreateLocal() to register it.
+//
+// Example:
+//
+//	localMgr := local.NewLocalManagerFor(globalManager, "api-server", "http-handlers")
+//	local, err := localMgr.CreateLocal("http-handlers")
+func NewLocalManagerFor(globalManager *types.GlobalManager, appName, localName string) interfaces.LocalGoroutineManagerInterface {
+	return &LocalManagerStruct{
+		AppName:   appName,
+		LocalName: localName,
+		Global:    globalManager,
+	}
+}
+
+// getGlobalManager returns the manager tree the local manager belongs to
+func (LM *LocalManagerStruct) getGlobalManager() (*types.GlobalManager, error) {
+	if LM.Global != nil {
+		return LM.Global, nil
+	}
+	return types.GetGlobalManager()
+}
+
+// publish delivers event to the subscribers of the manager tree. A nil Global publishes to the default
+// bus without reading types.Global, which routines finishing after a reset would race with.
+func (LM *LocalManagerStruct) publish(event types.Event) {
+	LM.Global.Publish(event)
+}
+
+// getAppManager returns the parent app manager from the manager tree
+func (LM *LocalManagerStruct) getAppManager() (*types.AppManager, error) {
+	globalManager, err := LM.getGlobalManager()
+	if err != nil {
+		return nil, &errors.NotFoundError{Name: LM.AppName, Err: errors.ErrAppManagerNotFound}
+	}
+	return globalManager.GetAppManager(LM.AppName)
+}
+
+// getLocalManager returns the local manager from the manager tree
+func (LM *LocalManagerStruct) getLocalManager() (*types.LocalManager, error) {
+	appManager, err := LM.getAppManager()
+	if err != nil {
+		return nil, err
+	}
+	return appManager.GetLocalManager(LM.LocalName)
+}
+
 // CreateLocal initializes and registers the local manager with its parent app manager.
 // This method is idempotent - calling it multiple times returns the existing local manager.
+// A stopped local manager is reopened with a fresh context, so it accepts routines again.
 //
 // Parameters:
 //   - localName: The name for this local manager (must match the name used in constructor)
@@ -61,7 +113,8 @@ func NewLocalManager(appName, localName string) interfaces.LocalGoroutineManager
 //
 // Returns:
 //   - *types.LocalManager: The initialized local manager instance
-//   - error: nil on success, error if app manager not found or creation fails
+//   - error: nil on success, error if app manager not found, or an *errors.ManagerClosedError
+//     if the app manager or the local manager is shutting down (or the app manager was stopped)
 //
 // Example:
 //
@@ -78,55 +131,87 @@ func (LM *LocalManagerStruct) CreateLocal(localName string) (*types.LocalManager
 	}()
 
 	// First get the app manager
-	appManager, err := types.GetAppManager(LM.AppName)
+	appManager, err := LM.getAppManager()
 	if err != nil {
 		metrics.RecordOperationError("manager", "create_local", "get_app_manager_failed")
 		return nil, err
 	}
 
+	// No new local managers while the app manager shuts down
+	if state := appManager.GetState(); state != types.StateRunning {
+		metrics.RecordOperationError("manager", "create_local", "manager_closed")
+		return nil, &errors.ManagerClosedError{App: LM.AppName, State: state.String()}
+	}
+
+	// Local managers of isolated trees derive their context from the app manager
+	var parentCtx context.Context
+	if LM.Global != nil && LM.Global.IsIsolated() {
+		parentCtx, _ = appManager.GetAppContext()
+	}
+
 	// Directly call the CreateLocal method of the app manager
 	// CreateLocal function will handle the checking and creation of the local manager
-	localManager, err := appManager.CreateLocal(localName)
-	switch err {
-	case errors.ErrLocalManagerNotFound:
-		metrics.RecordOperationError("manager", "create_local", "local_manager_not_found")
-		return nil, fmt.Errorf("%w: %s", errors.ErrLocalManagerNotFound, localName)
-	case errors.WrngLocalManagerAlreadyExists:
-		// Return the existing local manager and also return error as nil
-		return localManager, nil
-	default:
+	localManager, created := appManager.CreateLocal(localName)
+	if created {
 		// Fill the structs
-		localManager.SetLocalContext().
-			SetLocalMutex().
+		if parentCtx != nil {
+			localManager.SetLocalContextFrom(parentCtx)
+		} else {
+			localManager.SetLocalContext()
+		}
+		localManager.SetLocalMutex().
 			SetLocalWaitGroup()
-		// Record operation
-		metrics.RecordManagerOperation("local", "create", LM.AppName)
+		// Apply the local settings of the global configuration
+		if globalManager, err := LM.getGlobalManager(); err == nil && globalManager.GetMetadata() != nil {
+			if localConfig, ok := globalManager.GetMetadata().GetLocalConfig(LM.AppName, localName); ok {
+				localManager.Configure(localConfig)
+			}
+		}
+	} else if localManager.GetState() == types.StateDraining {
+		metrics.RecordOperationError("manager", "create_local", "manager_closed")
+		return nil, &errors.ManagerClosedError{App: LM.AppName, Local: localName, State: types.StateDraining.String()}
+	} else if !localManager.Reopen(parentCtx) {
+		// Already running
+		return localManager, nil
 	}
+
+	LM.publish(types.Event{
+		Type:      types.EventLocalCreated,
+		Manager:   types.EventManagerLocal,
+		AppName:   LM.AppName,
+		LocalName: localName,
+	})
 	return localManager, nil
 }
 
 // Shutdown gracefully or forcefully shuts down all goroutines managed by this local manager.
 // This method implements a sophisticated shutdown strategy with graceful → timeout → force cancellation.
+// Use ShutdownWithReport to learn which routines exited gracefully and which had to be force-cancelled.
 //
 // Parameters:
 //   - safe: If true, performs graceful shutdown with timeout protection.
 //     If false, immediately cancels all goroutines without waiting.
 //
 // Safe Shutdown Flow (safe=true):
-//  1. Collects all tracked goroutines and their function names
-//  2. Attempts graceful shutdown per function with timeout
-//  3. Waits for main wait group with global shutdown timeout
-//  4. If timeout occurs: force cancels remaining goroutines
-//  5. Cleans up all function wait groups (via defer)
-//  6. Removes all routines from tracking map
-//  7. Cancels local manager's context
+//  1. Drains worker pools (queued tasks still run), stopping pools that exceed the timeout
+//  2. Collects all tracked goroutines and their function names
+//  3. Attempts graceful shutdown per function with timeout
+//  4. Waits for main wait group with global shutdown timeout
+//  5. If timeout occurs: force cancels remaining goroutines
+//  6. Cleans up all function wait groups (via defer)
+//  7. Removes all routines from tracking map
+//  8. Cancels local manager's context
 //
 // Unsafe Shutdown Flow (safe=false):
-//  1. Immediately cancels all routine contexts
-//  2. Removes all routines from tracking map
-//  3. Cancels local manager's context
-//  4. Cleans up all function wait groups
-//  5. No waiting for goroutines to complete
+//  1. Stops worker pools, discarding queued tasks
+//  2. Immediately cancels all routine contexts
+//  3. Removes all routines from tracking map
+//  4. Cancels local manager's context
+//  5. Cleans up all function wait groups
+//  6. No waiting for goroutines to complete
+//
+// The local manager is draining while it shuts down and stopped afterwards (see GetState) - in both
+// states Go() rejects new routines until CreateLocal reopens it.
 //
 // Returns:
 //   - error: nil on success, error if local manager not found
@@ -140,25 +225,114 @@ func (LM *LocalManagerStruct) CreateLocal(localName string) (*types.LocalManager
 //	    log.Printf("Shutdown error: %v", err)
 //	}
 func (LM *LocalManagerStruct) Shutdown(safe bool) error {
+	_, err := LM.ShutdownWithReport(safe)
+	return err
+}
+
+// ShutdownWithReport shuts down the local manager like Shutdown and returns a report of the shutdown.
+// The report has one child per function name, listing which routines exited gracefully, which were
+// force-cancelled and which were still running afterwards (forced routines get up to 100ms to exit
+// before they count as still running), plus the duration of each phase
+// ("stop_schedules", "drain_pools", "graceful", "force_cancel" for safe shutdowns,
+// "stop_schedules", "stop_pools", "cancel" otherwise).
+// ShutdownFunction timeouts are recorded in the report's Errors instead of being discarded.
+// The local manager's shutdown timeout (SetShutdownTimeout) applies, else its app manager's, else
+// the global one.
+//
+// Returns:
+//   - *types.ShutdownReport: The local level report (never nil)
+//   - error: nil on success, error if local manager not found
+//
+// Example:
+//
+//	report, err := localMgr.ShutdownWithReport(true)
+//	if err == nil && !report.Clean() {
+//	    log.Printf("Unclean shutdown: %s", report)
+//	}
+func (LM *LocalManagerStruct) ShutdownWithReport(safe bool) (*types.ShutdownReport, error) {
+	return LM.ShutdownWithTimeout(safe, LM.GetEffectiveConfig().ShutdownTimeout)
+}
+
+// ShutdownWithTimeout shuts down the local manager like ShutdownWithReport, using timeout instead of
+// the global ShutdownTimeout as the deadline of pool draining and the graceful phase together (they
+// share it - the safe shutdown force-cancels what is left once it has passed). App managers use it to give
+// each shutdown phase its own slice of the shutdown timeout (see SetShutdownPriority).
+//
+// Example:
+//
+//	report, err := localMgr.ShutdownWithTimeout(true, 5*time.Second)
+func (LM *LocalManagerStruct) ShutdownWithTimeout(safe bool, timeout time.Duration) (*types.ShutdownReport, error) {
+	return LM.shutdown(context.Background(), safe, timeout)
+}
+
+// ShutdownContext safely shuts down the local manager like ShutdownWithReport, driven by the caller's
+// context instead of the global ShutdownTimeout: pool draining and the graceful phase stop waiting
+// when ctx is done (a ctx without deadline waits until it is cancelled), then the routines still
+// running are force-cancelled.
+//
+// Returns:
+//   - *types.ShutdownReport: The local level report (never nil)
+//   - error: nil on success, error if local manager not found, ctx.Err() if ctx ended before every
+//     routine exited gracefully
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	report, err := localMgr.ShutdownContext(ctx)
+func (LM *LocalManagerStruct) ShutdownContext(ctx context.Context) (*types.ShutdownReport, error) {
+	report, err := LM.shutdown(ctx, true, types.NoTimeout)
+	if err == nil && ctx.Err() != nil && !report.Clean() {
+		err = ctx.Err()
+	}
+	return report, err
+}
+
+// shutdown shuts down the local manager, waiting up to timeout in total for pools and routines unless
+// ctx is done first
+func (LM *LocalManagerStruct) shutdown(ctx context.Context, safe bool, timeout time.Duration) (*types.ShutdownReport, error) {
 	startTime := time.Now()
-	shutdownType := "unsafe"
-	if safe {
-		shutdownType = "safe"
+	report := types.NewShutdownReport(types.ShutdownLevelLocal, LM.LocalName, safe)
+
+	if timeout != types.NoTimeout {
+		// One deadline for every phase, so that pool draining, the function shutdowns and the wait
+		// for the local wait group don't each get the whole timeout
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+		defer cancelTimeout()
 	}
 
 	defer func() {
-		duration := time.Since(startTime)
-		metrics.RecordShutdownDuration("local", shutdownType, duration, LM.AppName, LM.LocalName)
+		report.Finish()
+		LM.publish(types.Event{
+			Type:      types.EventShutdownFinished,
+			Manager:   types.EventManagerLocal,
+			AppName:   LM.AppName,
+			LocalName: LM.LocalName,
+			Safe:      safe,
+			Duration:  time.Since(startTime),
+			Report:    report,
+		})
 	}()
 
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		metrics.RecordOperationError("manager", "shutdown", "get_local_manager_failed")
-		return err
+		report.AddError(err)
+		return report, err
 	}
 
-	// Record shutdown operation
-	metrics.RecordManagerOperation("local", "shutdown", LM.AppName)
+	// Go() rejects new routines from now on - the routines collected below are all there are
+	localManager.SetState(types.StateDraining)
+	defer localManager.SetState(types.StateStopped)
+
+	LM.publish(types.Event{
+		Type:      types.EventShutdownBegan,
+		Manager:   types.EventManagerLocal,
+		AppName:   LM.AppName,
+		LocalName: LM.LocalName,
+		Safe:      safe,
+	})
 
 	// Track all function names for cleanup
 	var functionNames map[string]bool
@@ -188,13 +362,25 @@ func (LM *LocalManagerStruct) Shutdown(safe bool) error {
 
 	if safe {
 		// Safe shutdown: try graceful shutdown first, then force cancel hanging goroutines
+		shutdownTimeout := timeout
+
+		// Step 0: Stop schedules so no new runs start, then drain worker pools so queued tasks
+		// run before their workers are cancelled
+		phaseStart := time.Now()
+		LM.stopSchedules(localManager)
+		report.AddPhase("stop_schedules", phaseStart)
+
+		phaseStart = time.Now()
+		LM.drainPools(ctx, localManager, shutdownTimeout, report)
+		report.AddPhase("drain_pools", phaseStart)
 
 		// Step 1: Get all routines and function names
 		var err error
 		routines, err = LM.GetAllGoroutines()
 		if err != nil {
 			metrics.RecordOperationError("manager", "shutdown", "get_goroutines_failed")
-			return err
+			report.AddError(err)
+			return report, err
 		}
 
 		functionNames = make(map[string]bool)
@@ -202,12 +388,23 @@ func (LM *LocalManagerStruct) Shutdown(safe bool) error {
 			functionNames[routine.GetFunctionName()] = true
 		}
 
-		// Step 2: Try to shutdown each function gracefully with timeout
-		shutdownTimeout := types.ShutdownTimeout
+		// Step 2: Try to shutdown each function gracefully with timeout - concurrently, so a function
+		// that ignores cancellation doesn't use up the timeout (or the caller's deadline) of the others
+		phaseStart = time.Now()
+		var functionsWg sync.WaitGroup
+		functionErrs := make(chan error, len(functionNames))
 		for functionName := range functionNames {
-			// Try graceful shutdown with timeout
-			_ = LM.ShutdownFunction(functionName, shutdownTimeout)
-			// Note: ShutdownFunction handles cleanup on success, but we'll clean up all in defer
+			functionsWg.Add(1)
+			go func(functionName string) {
+				defer functionsWg.Done()
+				// Note: ShutdownFunction handles cleanup on success, but we'll clean up all in defer
+				functionErrs <- LM.shutdownFunction(ctx, functionName, shutdownTimeout)
+			}(functionName)
+		}
+		functionsWg.Wait()
+		close(functionErrs)
+		for err := range functionErrs {
+			report.AddError(err)
 		}
 
 		// Step 3: Wait for main wait group with timeout
@@ -225,39 +422,73 @@ func (LM *LocalManagerStruct) Shutdown(safe bool) error {
 		case <-done:
 			// All goroutines completed gracefully
 			// Cleanup will happen in defer
-			return nil
-		case <-time.After(shutdownTimeout):
-			// Timeout - some goroutines are still hanging
+			report.AddPhase("graceful", phaseStart)
+			addRoutineReports(report, routines, nil)
+			return report, nil
+		case <-ctx.Done():
+			// Timeout or the caller's context ended the wait - some goroutines are still hanging
 			// Fall through to force cancel
 		}
+		report.AddPhase("graceful", phaseStart)
+
+		// Routines that have not exited by now are force-cancelled
+		forced := pendingRoutines(routines)
 
 		// Step 4: Force cancel any remaining hanging goroutines
+		phaseStart = time.Now()
 		remainingRoutines, err := LM.GetAllGoroutines()
 		if err == nil {
-			// Record remaining goroutines after timeout
-			metrics.RecordShutdownGoroutinesRemaining("local", LM.AppName, LM.LocalName, len(remainingRoutines))
 			for _, routine := range remainingRoutines {
 				cancel := routine.GetCancel()
 				if cancel != nil {
 					cancel()
 				}
 				// Remove routine from map to prevent memory leak
-				localManager.RemoveRoutine(routine, false)
+				LM.removeRoutine(localManager, routine)
+				if !forced[routine.GetID()] {
+					// Spawned after the routines were collected
+					routines = append(routines, routine)
+					forced[routine.GetID()] = true
+				}
 			}
 		}
 
+		// Record the routines that had not exited by the timeout
+		LM.publish(types.Event{
+			Type:      types.EventForceCancel,
+			Manager:   types.EventManagerLocal,
+			AppName:   LM.AppName,
+			LocalName: LM.LocalName,
+			Safe:      safe,
+			Count:     len(forced),
+		})
+
 		// Cancel the local manager's context
 		if localManager.Cancel != nil {
 			localManager.Cancel()
 		}
+		report.AddPhase("force_cancel", phaseStart)
+		addRoutineReports(report, routines, forced)
 
 	} else {
 		// Unsafe shutdown: cancel all contexts immediately
+		// Stop schedules, and worker pools without running their queued tasks
+		phaseStart := time.Now()
+		LM.stopSchedules(localManager)
+		report.AddPhase("stop_schedules", phaseStart)
+
+		phaseStart = time.Now()
+		for _, pool := range localManager.GetPools() {
+			pool.Stop()
+		}
+		report.AddPhase("stop_pools", phaseStart)
+
 		// Get all routines and cancel their contexts
 		var err error
 		routines, err = LM.GetAllGoroutines()
 		if err != nil {
-			return err
+			report.AddError(err)
+			return report, err
 		}
 
 		// Track function names for cleanup
@@ -266,23 +497,70 @@ func (LM *LocalManagerStruct) Shutdown(safe bool) error {
 			functionNames[routine.GetFunctionName()] = true
 		}
 
+		// Every routine is force-cancelled - there is no graceful phase
+		forced := make(map[string]bool, len(routines))
+
 		// Cancel all routine contexts and remove from map
+		phaseStart = time.Now()
 		for _, routine := range routines {
+			forced[routine.GetID()] = true
 			cancel := routine.GetCancel()
 			if cancel != nil {
 				cancel()
 			}
 			// Remove routine from map to prevent memory leak
-			localManager.RemoveRoutine(routine, false)
+			LM.removeRoutine(localManager, routine)
 		}
 
 		// Cancel the local manager's context
 		if localManager.Cancel != nil {
 			localManager.Cancel()
 		}
+		report.AddPhase("cancel", phaseStart)
+		addRoutineReports(report, routines, forced)
 	}
 
-	return nil
+	return report, nil
+}
+
+// stopSchedules stops all schedules of the local manager and cancels their runs in progress.
+// The runs are then shut down with the other routines of their function.
+func (LM *LocalManagerStruct) stopSchedules(localManager *types.LocalManager) {
+	for _, schedule := range localManager.GetSchedules() {
+		schedule.Stop()
+	}
+}
+
+// drainPools drains all worker pools of the local manager in parallel, waiting up to timeout.
+// Pools that do not drain in time, or before ctx is done, are stopped, which cancels their workers
+// and running tasks. Drain timeouts are recorded in the report.
+func (LM *LocalManagerStruct) drainPools(ctx context.Context, localManager *types.LocalManager, timeout time.Duration, report *types.ShutdownReport) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, pool := range localManager.GetPools() {
+		wg.Add(1)
+		go func(pool types.Pool) {
+			defer wg.Done()
+			drained := make(chan error, 1)
+			go func() {
+				drained <- pool.Drain(timeout)
+			}()
+
+			var err error
+			select {
+			case err = <-drained:
+			case <-ctx.Done():
+				err = fmt.Errorf("worker pool %s in %s/%s not drained: %w", pool.GetName(), LM.AppName, LM.LocalName, ctx.Err())
+			}
+			if err != nil {
+				pool.Stop()
+				mu.Lock()
+				report.AddError(err)
+				mu.Unlock()
+			}
+		}(pool)
+	}
+	wg.Wait()
 }
 
 // ShutdownFunction gracefully shuts down all goroutines with a specific function name.
@@ -295,14 +573,14 @@ func (LM *LocalManagerStruct) Shutdown(safe bool) error {
 // Shutdown Process:
 //  1. Retrieves all goroutines with the specified function name
 //  2. Cancels their contexts to signal shutdown
-//  3. Waits for function wait group with timeout
+//  3. Waits for function wait group (or the routines, without one) with timeout
 //  4. If timeout: removes routines from tracking and cleans up wait group
 //  5. If success: cleans up wait group
 //  6. Records metrics for shutdown duration
 //
 // Returns:
 //   - error: nil if all goroutines shutdown within timeout
-//     Returns error with timeout message if timeout occurs
+//     Returns an *errors.ShutdownTimeoutError (wrapping ErrShutdownTimeout) if timeout occurs
 //
 // Example:
 //
@@ -312,13 +590,37 @@ func (LM *LocalManagerStruct) Shutdown(safe bool) error {
 //	    log.Printf("Function shutdown timeout: %v", err)
 //	}
 func (LM *LocalManagerStruct) ShutdownFunction(functionName string, timeout time.Duration) error {
+	return LM.shutdownFunction(context.Background(), functionName, timeout)
+}
+
+// ShutdownFunctionContext shuts down all goroutines with a specific function name like
+// ShutdownFunction, waiting until ctx is done instead of a timeout.
+//
+// Returns:
+//   - error: nil if all goroutines shutdown before ctx ended
+//     Returns an *errors.ShutdownTimeoutError wrapping ErrShutdownTimeout and ctx.Err() otherwise
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	if err := localMgr.ShutdownFunctionContext(ctx, "worker"); errors.Is(err, context.DeadlineExceeded) {
+//	    log.Printf("Function shutdown timeout: %v", err)
+//	}
+func (LM *LocalManagerStruct) ShutdownFunctionContext(ctx context.Context, functionName string) error {
+	return LM.shutdownFunction(ctx, functionName, types.NoTimeout)
+}
+
+// shutdownFunction cancels the goroutines of a function and waits up to timeout for them unless
+// ctx is done first
+func (LM *LocalManagerStruct) shutdownFunction(ctx context.Context, functionName string, timeout time.Duration) error {
 	startTime := time.Now()
 	defer func() {
 		duration := time.Since(startTime)
 		metrics.RecordGoroutineOperationDuration("shutdown_function", duration, LM.AppName, LM.LocalName, functionName)
 	}()
 
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		metrics.RecordOperationError("function", "shutdown", "get_local_manager_failed")
 		return err
@@ -348,17 +650,33 @@ func (LM *LocalManagerStruct) ShutdownFunction(functionName string, timeout time
 		}
 	}
 
-	// Wait for completion with timeout
-	completed := LM.WaitForFunctionWithTimeout(functionName, timeout)
+	// Wait for completion with timeout - without a function wait group, wait for the routines themselves
+	var completed bool
+	if _, err := localManager.GetFunctionWg(functionName); err == nil {
+		completed = LM.waitForFunction(ctx, functionName, timeout)
+	} else {
+		completed = waitForRoutines(ctx, functionRoutines, timeout)
+	}
 	if !completed {
+		timeoutErr := &errors.ShutdownTimeoutError{
+			App:       LM.AppName,
+			Local:     LM.LocalName,
+			Function:  functionName,
+			Timeout:   timeout,
+			Remaining: localManager.GetFunctionRoutineCount(functionName),
+		}
+		if err := ctx.Err(); err != nil {
+			timeoutErr.Timeout = time.Since(startTime)
+			timeoutErr.Cause = err
+		}
 		// Timeout occurred - clean up routines and wait group
 		for _, routine := range functionRoutines {
 			// Remove routine from map to prevent memory leak
-			localManager.RemoveRoutine(routine, false)
+			LM.removeRoutine(localManager, routine)
 		}
 		// Clean up the wait group even on timeout
 		localManager.RemoveFunctionWg(functionName)
-		return fmt.Errorf("shutdown timeout for function: %s", functionName)
+		return timeoutErr
 	}
 
 	// Clean up the wait group on success
@@ -367,6 +685,27 @@ func (LM *LocalManagerStruct) ShutdownFunction(functionName string, timeout time
 	return nil
 }
 
+// waitForRoutines waits up to timeout for routines to finish unless ctx is done first.
+// Returns true if all finished.
+func waitForRoutines(ctx context.Context, routines []*types.Routine, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for _, routine := range routines {
+		doneChan := routine.DoneChan()
+		if doneChan == nil {
+			continue
+		}
+		select {
+		case <-doneChan:
+		case <-timer.C:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
 // Go spawns a new tracked goroutine with advanced lifecycle management.
 // The goroutine is automatically tracked, monitored via metrics, and cleaned up on completion.
 //
@@ -379,6 +718,28 @@ func (LM *LocalManagerStruct) ShutdownFunction(functionName string, timeout time
 //   - WithTimeout(duration): Auto-cancels goroutine after specified duration
 //   - WithPanicRecovery(bool): Enable/disable panic recovery (enabled by default)
 //   - AddToWaitGroup(name): Add to function-level wait group for coordinated shutdown
+//   - WithAdmissionPolicy(policy): Reject (default) or wait when a MaxRoutines limit is reached
+//   - WithAdmissionTimeout(duration): Wait up to duration for a routine slot
+//   - CaptureRoutineID(&id): Receive the routine ID (e.g. for WaitForRoutineResult)
+//   - WithRestart(policy): Supervise the goroutine and restart it when its worker returns or panics
+//   - WithMaxConcurrent(n): Limit the running routines of this function name in the local manager
+//   - WithSingleflight(key): Join a running routine of this function started with the same key
+//   - WithInterceptors(interceptors...): Wrap the worker runs, inside the interceptors of the managers (Use)
+//   - WithContextValues(ctx): Make the values of a caller's context visible to the worker, without its cancellation
+//
+// Defaults apply first, so these options override them: the defaults for the function name set in
+// the global configuration (global.WithFunctionDefaults or a config file's functions section), and
+// where those set nothing the routine defaults of the local and app manager (SetRoutineDefaults).
+// The default options of the local manager (SetDefaultOptions), then those of the function name
+// (SetFunctionOptions), apply on top of the defaults. GetEffectiveOptions shows the result.
+//
+// Admission:
+//
+//	Before spawning, Go() checks the global MaxRoutines limit (Metadata) and the app and
+//	local quotas (SetMaxRoutines), plus the function's WithMaxConcurrent limit. If any limit is
+//	reached, Go() returns ErrMaxRoutinesReached (ErrMaxConcurrentReached) or waits for a slot,
+//	depending on the admission policy. With WithSingleflight, Go() first looks for a running
+//	routine to join.
 //
 // Goroutine Lifecycle:
 //  1. Creates child context derived from local manager's context
@@ -387,9 +748,17 @@ func (LM *LocalManagerStruct) ShutdownFunction(functionName string, timeout time
 //  4. Adds to tracking map and wait groups
 //  5. Spawns goroutine with context
 //  6. Executes worker function
-//  7. On completion/panic: records metrics, decrements wait groups, closes done channel
+//  7. On completion/panic: records metrics and the routine result, decrements wait groups, closes done channel
 //  8. Automatically removes from tracking map to prevent memory leaks
 //
+// Worker Errors:
+//
+//	The error returned by workerFunc (or a recovered panic converted to an error wrapping
+//	ErrRoutinePanicked, with its stack) is stored as the routine's result. It can be read with
+//	GetRoutineResult / WaitForRoutineResult, also after the routine has left the tracking map.
+//	Recovered panics are also kept as result.Panic (value and stack) and passed to the panic
+//	handler (WithPanicHandler, or the global handler set with SetPanicHandler).
+//
 // Context Cancellation:
 //
 //	The context passed to workerFunc will be cancelled when:
@@ -399,8 +768,16 @@ func (LM *LocalManagerStruct) ShutdownFunction(functionName string, timeout time
 //	- Global manager shuts down
 //	- Manual cancellation via CancelRoutine()
 //
+// Lifecycle:
+//
+//	Go() returns an *errors.ManagerClosedError (wrapping ErrManagerClosed) once the local manager,
+//	its app manager or the global manager has begun to shut down. A stopped local manager accepts
+//	routines again after CreateLocal (or the app manager's NewLocalManager) reopens it.
+//
 // Returns:
-//   - error: nil on success, error if local manager not found
+//   - error: nil on success, error if local manager not found, ManagerClosedError if it is shutting
+//     down or stopped, ErrMaxRoutinesReached, ErrMaxConcurrentReached or ErrAdmissionTimeout if
+//     admission failed
 //
 // Example:
 //
@@ -415,17 +792,188 @@ func (LM *LocalManagerStruct) ShutdownFunction(functionName string, timeout time
 //	    }
 //	}, local.WithTimeout(5*time.Minute), local.AddToWaitGroup("handlers"))
 func (LM *LocalManagerStruct) Go(functionName string, workerFunc func(ctx context.Context) error, opts ...interfaces.GoroutineOption) error {
-	// Apply default options
+	return LM.spawnGoroutine(functionName, workerFunc, LM.resolveOptions(functionName, opts))
+}
+
+// SetDefaultOptions sets the options applied to every routine spawned by Go() in this local manager,
+// replacing those set before (none = cleared). Function options (SetFunctionOptions) and options
+// passed to Go() override them; they override the configured routine defaults.
+//
+// Returns:
+//   - error: Returns error if local manager is not found, or an error wrapping ErrInvalidConfig if an
+//     option is not a local.Option
+//
+// Example:
+//
+//	localMgr.SetDefaultOptions(local.WithTimeout(time.Minute), local.WithPanicRecovery(true))
+func (LM *LocalManagerStruct) SetDefaultOptions(opts ...interfaces.GoroutineOption) error {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return err
+	}
+	options, err := checkOptions(opts)
+	if err != nil {
+		return err
+	}
+	localManager.SetDefaultOptions(options)
+	return nil
+}
+
+// SetFunctionOptions sets the options applied to the routines of functionName spawned by Go() in this
+// local manager, replacing those set before (none = removed). They override the default options of
+// the local manager; options passed to Go() override them.
+//
+// Returns:
+//   - error: Returns error if local manager is not found, or an error wrapping ErrInvalidConfig if an
+//     option is not a local.Option
+//
+// Example:
+//
+//	localMgr.SetFunctionOptions("http-handler", local.WithTimeout(30*time.Second), local.AddToWaitGroup("handlers"))
+func (LM *LocalManagerStruct) SetFunctionOptions(functionName string, opts ...interfaces.GoroutineOption) error {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return err
+	}
+	options, err := checkOptions(opts)
+	if err != nil {
+		return err
+	}
+	localManager.SetFunctionOptions(functionName, options)
+	return nil
+}
+
+// GetEffectiveOptions returns the options a routine of functionName spawned by Go() with opts would
+// run with, without spawning it - e.g. to audit which functions run without a timeout. The options
+// of running routines are in Routine.GetOptions and in snapshots.
+func (LM *LocalManagerStruct) GetEffectiveOptions(functionName string, opts ...interfaces.GoroutineOption) types.RoutineOptions {
+	return LM.resolveOptions(functionName, opts).describe()
+}
+
+// checkOptions returns opts as stored on the local manager, or an error wrapping ErrInvalidConfig if
+// one of them is not a local.Option
+func checkOptions(opts []interfaces.GoroutineOption) ([]interface{}, error) {
+	options := make([]interface{}, 0, len(opts))
+	for i, opt := range opts {
+		if localOpt, ok := opt.(Option); !ok || localOpt == nil {
+			return nil, fmt.Errorf("%w: option %d is %T, not a local.Option", errors.ErrInvalidConfig, i, opt)
+		}
+		options = append(options, opt)
+	}
+	return options, nil
+}
+
+// resolveOptions merges the options of a Go() call for functionName, each overriding the previous:
+// the built-in defaults, the configured routine defaults, the default options of the local manager,
+// those of the function name and opts
+func (LM *LocalManagerStruct) resolveOptions(functionName string, opts []interfaces.GoroutineOption) *goroutineOptions {
 	options := defaultGoroutineOptions()
+	LM.applyRoutineDefaults(functionName, options)
+	if localManager, err := LM.getLocalManager(); err == nil {
+		for _, opt := range localManager.GetDefaultOptions(functionName) {
+			opt.(Option)(options)
+		}
+	}
 	for _, opt := range opts {
 		// Type assert to Option (defined in this package)
 		if localOpt, ok := opt.(Option); ok {
 			localOpt(options)
 		}
 	}
+	return options
+}
+
+// SetShutdownTimeout overrides the shutdown timeout of the app manager (or the global one) for this
+// local manager: Shutdown uses it, and within an app shutdown the local manager is not waited for
+// longer than its timeout. A value of 0 restores the inherited timeout.
+//
+// Returns:
+//   - error: Returns error if local manager is not found, or an error wrapping ErrInvalidConfig if
+//     timeout is negative
+//
+// Example:
+//
+//	handlersMgr.SetShutdownTimeout(5 * time.Second)
+func (LM *LocalManagerStruct) SetShutdownTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("%w: shutdown timeout must not be negative, got %v", errors.ErrInvalidConfig, timeout)
+	}
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return err
+	}
+	localManager.SetShutdownTimeout(timeout)
+	return nil
+}
+
+// SetRoutineDefaults sets the defaults for every routine spawned by this local manager; zero fields
+// inherit the routine defaults of the app manager. Function defaults (global.WithFunctionDefaults)
+// and options passed to Go() override them.
+//
+// Returns:
+//   - error: Returns error if local manager is not found, or an error wrapping ErrInvalidConfig if a
+//     value is negative
+//
+// Example:
+//
+//	localMgr.SetRoutineDefaults(types.FunctionConfig{Timeout: time.Minute, MaxConcurrent: 10})
+func (LM *LocalManagerStruct) SetRoutineDefaults(defaults types.FunctionConfig) error {
+	if defaults.Timeout < 0 || defaults.MaxConcurrent < 0 {
+		return fmt.Errorf("%w: routine defaults must not be negative, got %+v", errors.ErrInvalidConfig, defaults)
+	}
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return err
+	}
+	localManager.SetDefaults(defaults)
+	return nil
+}
+
+// GetEffectiveConfig returns the settings in effect for this local manager: its quota, and its
+// shutdown timeout and routine defaults or those inherited from the app manager and the global
+// configuration where it has none.
+func (LM *LocalManagerStruct) GetEffectiveConfig() types.ManagerConfig {
+	globalManager, err := LM.getGlobalManager()
+	if err != nil {
+		return types.ManagerConfig{ShutdownTimeout: types.ShutdownTimeout}
+	}
+	return globalManager.GetEffectiveConfig(LM.AppName, LM.LocalName)
+}
+
+// goInternal spawns a long-running routine of the local manager itself (pool worker, schedule loop).
+// Function defaults and the interceptors of the managers do not apply to it - a default timeout would
+// stop the pool or schedule.
+func (LM *LocalManagerStruct) goInternal(functionName string, workerFunc func(ctx context.Context) error, opts ...Option) error {
+	options := defaultGoroutineOptions()
+	options.internal = true
+	for _, opt := range opts {
+		opt(options)
+	}
 	return LM.spawnGoroutine(functionName, workerFunc, options)
 }
 
+// applyRoutineDefaults applies the defaults in effect for functionName: those of the function name
+// in the global configuration (global.WithFunctionDefaults), inheriting from the routine defaults of
+// the local and app manager - options passed to Go() override them
+func (LM *LocalManagerStruct) applyRoutineDefaults(functionName string, options *goroutineOptions) {
+	globalManager, err := LM.getGlobalManager()
+	if err != nil {
+		return
+	}
+	defaults := globalManager.GetEffectiveConfig(LM.AppName, LM.LocalName).Defaults
+	if metadata := globalManager.GetMetadata(); metadata != nil {
+		if functionDefaults, ok := metadata.GetFunctionConfig(functionName); ok {
+			defaults = functionDefaults.Inherit(defaults)
+		}
+	}
+	if defaults.Timeout > 0 {
+		WithTimeout(defaults.Timeout)(options)
+	}
+	if defaults.MaxConcurrent > 0 {
+		WithMaxConcurrent(defaults.MaxConcurrent)(options)
+	}
+}
+
 // spawnGoroutine is the internal implementation for spawning and tracking goroutines.
 // It handles context creation, wait group management, panic recovery, and cleanup.
 //
@@ -447,16 +995,37 @@ func (LM *LocalManagerStruct) Go(functionName string, workerFunc func(ctx contex
 //   - error: nil on successful spawn, error if local manager not found
 func (LM *LocalManagerStruct) spawnGoroutine(functionName string, workerFunc func(ctx context.Context) error, opts *goroutineOptions) error {
 	// Get the types.LocalManager instance
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		return err
 	}
 
+	// Reject new routines once the local manager or one of its parents has begun to shut down
+	if err := LM.checkRunning(localManager); err != nil {
+		metrics.RecordOperationError("goroutine", "spawn", "manager_closed")
+		return err
+	}
+
+	// Enforce MaxRoutines limits (global, app, local) and the function's concurrency limit before doing any work
+	// On success the admission gate is held until the routine is registered below
+	releaseAdmission, joined, err := LM.admitRoutine(localManager, functionName, opts)
+	if err != nil {
+		return err
+	}
+	if joined != nil {
+		// Singleflight - the running routine stands in for this call
+		if opts.routineID != nil {
+			*opts.routineID = joined.GetID()
+		}
+		return nil
+	}
+
 	var wg *sync.WaitGroup
 	if opts.waitGroupName != "" {
 		// Get or create function wait group using the specified function name
 		wg, err = LM.NewFunctionWaitGroup(context.Background(), opts.waitGroupName)
 		if err != nil {
+			releaseAdmission()
 			return err
 		}
 		// Increment wait group BEFORE spawning goroutine
@@ -468,21 +1037,20 @@ func (LM *LocalManagerStruct) spawnGoroutine(functionName string, workerFunc fun
 		localManager.Wg.Add(1)
 	}
 
-	// Create a child context with cancel for this routine
-	routineCtx, cancel := localManager.SpawnChild()
-
-	// Apply timeout if specified
-	var timeoutCancel context.CancelFunc
-	if opts.timeout != nil {
-		routineCtx, timeoutCancel = context.WithTimeout(routineCtx, *opts.timeout)
-		// Combine cancellations: when timeout expires or explicit cancel is called
-		originalCancel := cancel
-		cancel = func() {
-			originalCancel()
-			if timeoutCancel != nil {
-				timeoutCancel()
-			}
-		}
+	// Resolve the interceptor chain once - supervised restarts run the same chain
+	opts.interceptors = LM.resolveInterceptors(localManager, opts)
+
+	// Create a child context with cancel (and optional timeout) for this routine
+	// Supervised routines get a fresh context per run - their cancel stops the supervisor
+	var routineCtx context.Context
+	var cancel context.CancelFunc
+	var supervised *supervisedContext
+	if opts.supervised() {
+		supervised = newSupervisedContext(localManager, opts.timeout)
+		routineCtx = supervised.next()
+		cancel = supervised.stop
+	} else {
+		routineCtx, cancel = newRoutineContext(localManager, opts.timeout)
 	}
 
 	// Create the done channel (bidirectional, buffered size 1)
@@ -493,28 +1061,91 @@ func (LM *LocalManagerStruct) spawnGoroutine(functionName string, workerFunc fun
 	routine := localManager.NewGoRoutine(functionName).
 		SetContext(routineCtx).
 		SetCancel(cancel).
+		SetWaitGroup(opts.waitGroupName).
+		SetSingleflight(opts.singleflight).
+		SetOptions(opts.describe()).
 		SetDone(doneChan) // Override the channel created in NewGoRoutine
 
+	// The local manager may have begun to shut down since the check above - the shutdown only sees
+	// routines registered before it started draining, so back out instead of escaping it
+	if err := checkLocalRunning(LM.AppName, localManager); err != nil {
+		releaseAdmission()
+		LM.removeRoutine(localManager, routine)
+		if wg != nil {
+			wg.Done()
+		}
+		if localManager.Wg != nil {
+			localManager.Wg.Done()
+		}
+		metrics.RecordOperationError("goroutine", "spawn", "manager_closed")
+		return err
+	}
+
+	// Routine is registered - other Go() calls may now check the limits
+	releaseAdmission()
+
+	if opts.routineID != nil {
+		*opts.routineID = routine.GetID()
+	}
+
 	// Record goroutine creation and measure creation duration
 	createStartTime := time.Now()
-	metrics.RecordGoroutineOperation("create", LM.AppName, LM.LocalName, functionName)
+	LM.publish(types.Event{
+		Type:         types.EventRoutineStarted,
+		AppName:      LM.AppName,
+		LocalName:    LM.LocalName,
+		FunctionName: functionName,
+		RoutineID:    routine.GetID(),
+	})
 
 	// Spawn the goroutine
 	go func() {
+		// Label the goroutine so profiles and the leak detector can attribute it to the routine,
+		// also outside of the worker runs (cleanup, supervisor backoff)
+		pprof.SetGoroutineLabels(pprof.WithLabels(context.Background(), LM.routineLabels(routine, opts)))
+
 		startTimeNano := time.Now().UnixNano()
+		var workerErr error
+		var panicInfo *types.PanicInfo
+		panicked := false
+		returned := false
 		defer func() {
-			// Handle panic recovery (enabled by default for production safety)
-			if opts.panicRecovery {
-				if r := recover(); r != nil {
-					// Log panic details via metrics
-					metrics.RecordOperationError("goroutine", "panic", fmt.Sprintf("function: %s, panic: %v", functionName, r))
-					// Panic is recovered, continue with normal cleanup
+			if !returned {
+				// Worker panicked with recovery disabled - the panic keeps unwinding after cleanup
+				panicked = true
+				workerErr = &errors.RoutinePanicError{
+					App:       LM.AppName,
+					Local:     LM.LocalName,
+					Function:  functionName,
+					RoutineID: routine.GetID(),
 				}
+				LM.publish(types.Event{
+					Type:         types.EventRoutinePanicked,
+					AppName:      LM.AppName,
+					LocalName:    LM.LocalName,
+					FunctionName: functionName,
+					RoutineID:    routine.GetID(),
+					Err:          workerErr,
+				})
 			}
 
-			// Record goroutine completion
-			metrics.RecordGoroutineCompletion(LM.AppName, LM.LocalName, functionName, startTimeNano)
-			metrics.RecordGoroutineOperation("complete", LM.AppName, LM.LocalName, functionName)
+			// Publish the outcome - the routine's context is checked before it is cancelled below
+			LM.publishCompletion(routine, startTimeNano, panicked, workerErr)
+
+			// Keep the outcome on the routine and in the recent results store
+			// Both happen before the done channel is closed so waiters always see the result,
+			// and before RemoveRoutine so the result is reachable once the routine leaves the map
+			result := &types.RoutineResult{
+				RoutineID:    routine.GetID(),
+				FunctionName: functionName,
+				Err:          workerErr,
+				Panicked:     panicked,
+				Panic:        panicInfo,
+				StartedAt:    startTimeNano,
+				FinishedAt:   time.Now().UnixNano(),
+			}
+			routine.SetResult(result)
+			localManager.AddRoutineResult(result)
 
 			if opts.waitGroupName != "" && wg != nil {
 				// Decrement function wait group when routine completes
@@ -540,12 +1171,18 @@ func (LM *LocalManagerStruct) spawnGoroutine(functionName string, workerFunc fun
 			// Using safe=false since the routine is already completing naturally
 			// Note: RemoveRoutine also cancels the context, but we've already done it above
 			// for explicit cleanup. RemoveRoutine's cancel is idempotent (safe to call twice).
-			localManager.RemoveRoutine(routine, false)
+			LM.removeRoutine(localManager, routine)
 		}()
 
 		// Execute the worker function with the routine's context
-		// Panics will be caught and recovered by the defer block above (enabled by default)
-		_ = workerFunc(routineCtx)
+		// Panics are recovered by runWorker (enabled by default)
+		if supervised != nil {
+			panicInfo, workerErr = LM.superviseWorker(localManager, routine, supervised, functionName, workerFunc, opts)
+		} else {
+			panicInfo, workerErr = LM.runWorker(routineCtx, routine, workerFunc, opts)
+		}
+		panicked = panicInfo != nil
+		returned = true
 	}()
 
 	// Record creation operation duration (time to spawn goroutine, should be very fast)
@@ -555,6 +1192,119 @@ func (LM *LocalManagerStruct) spawnGoroutine(functionName string, workerFunc fun
 	return nil
 }
 
+// newRoutineContext creates a child context of the local manager's context for a routine,
+// applying the timeout if one is set. The returned cancel releases both contexts.
+func newRoutineContext(localManager *types.LocalManager, timeout *time.Duration) (context.Context, context.CancelFunc) {
+	routineCtx, cancel := localManager.SpawnChild()
+	if timeout == nil {
+		return routineCtx, cancel
+	}
+
+	// Combine cancellations: when timeout expires or explicit cancel is called
+	routineCtx, timeoutCancel := context.WithTimeout(routineCtx, *timeout)
+	return routineCtx, func() {
+		cancel()
+		timeoutCancel()
+	}
+}
+
+// publishCompletion publishes the events of a finished routine: errored, timed out or cancelled
+// depending on its outcome, followed by completed. Panics are published by the run that panicked.
+func (LM *LocalManagerStruct) publishCompletion(routine *types.Routine, startTimeNano int64, panicked bool, workerErr error) {
+	event := types.Event{
+		AppName:      LM.AppName,
+		LocalName:    LM.LocalName,
+		FunctionName: routine.GetFunctionName(),
+		RoutineID:    routine.GetID(),
+		Err:          workerErr,
+	}
+	if workerErr != nil && !panicked {
+		event.Type = types.EventRoutineErrored
+		LM.publish(event)
+	}
+	if ctx := routine.GetContext(); ctx != nil {
+		switch ctx.Err() {
+		case context.DeadlineExceeded:
+			event.Type = types.EventRoutineTimedOut
+			LM.publish(event)
+		case context.Canceled:
+			event.Type = types.EventRoutineCancelled
+			LM.publish(event)
+		}
+	}
+	event.Type = types.EventRoutineCompleted
+	event.Duration = time.Since(time.Unix(0, startTimeNano))
+	LM.publish(event)
+}
+
+// runWorker executes one run of a worker function, wrapped by the built-in interceptors - panic
+// recovery when it is enabled, and run metrics - and by the interceptors of the routine. A recovered
+// panic is returned as its PanicInfo and an *errors.RoutinePanicError (wrapping ErrRoutinePanicked),
+// including the stack, after it has been passed to the panic handler.
+func (LM *LocalManagerStruct) runWorker(ctx context.Context, routine *types.Routine, workerFunc func(ctx context.Context) error, opts *goroutineOptions) (panicInfo *types.PanicInfo, err error) {
+	builtins := make([]types.Interceptor, 0, 2)
+	if opts.panicRecovery {
+		builtins = append(builtins, LM.recoverPanics(opts.panicHandler, &panicInfo))
+	}
+	builtins = append(builtins, LM.recordRunMetrics)
+	run := types.Chain(types.Chain(workerFunc, opts.interceptors...), builtins...)
+
+	info := types.RoutineInfo{
+		AppName:      LM.AppName,
+		LocalName:    LM.LocalName,
+		FunctionName: routine.GetFunctionName(),
+		RoutineID:    routine.GetID(),
+		Restarts:     routine.GetRestarts(),
+	}
+	if opts.values != nil {
+		ctx = ctxo.WithValuesFrom(ctx, opts.values)
+	}
+	// Run under pprof.Do so the worker's context carries the labels too
+	pprof.Do(types.WithRoutineInfo(ctx, info), LM.routineLabels(routine, opts), func(ctx context.Context) {
+		err = run(ctx)
+	})
+	return panicInfo, err
+}
+
+// routineLabels returns the pprof labels of a routine: the user-defined labels (WithLabels)
+// plus the app, local, function and routine ID labels, which cannot be overridden
+func (LM *LocalManagerStruct) routineLabels(routine *types.Routine, opts *goroutineOptions) pprof.LabelSet {
+	args := make([]string, 0, 2*len(opts.labels)+8)
+	for key, value := range opts.labels {
+		switch key {
+		case types.LabelApp, types.LabelLocal, types.LabelFunction, types.LabelRoutineID, types.LabelTree:
+			continue
+		}
+		args = append(args, key, value)
+	}
+	args = append(args,
+		types.LabelApp, LM.AppName,
+		types.LabelLocal, LM.LocalName,
+		types.LabelFunction, routine.GetFunctionName(),
+		types.LabelRoutineID, routine.GetID(),
+	)
+	if treeID := LM.Global.TreeID(); treeID != "" {
+		args = append(args, types.LabelTree, treeID)
+	}
+	return pprof.Labels(args...)
+}
+
+// handlePanic passes a recovered panic to the routine's panic handler, or to the one of the manager
+// tree. A panicking handler is recovered so that the routine still completes its cleanup.
+func (LM *LocalManagerStruct) handlePanic(info *types.PanicInfo, handler types.PanicHandler) {
+	if handler == nil {
+		// Like publish, a nil Global uses the default handler
+		handler = LM.Global.GetPanicHandler()
+	}
+	if handler == nil {
+		return
+	}
+	defer func() {
+		_ = recover()
+	}()
+	handler(info)
+}
+
 // GetAllGoroutines retrieves all tracked goroutines in this local manager.
 // This returns a slice of all routine instances managed by this local manager.
 //
@@ -575,7 +1325,7 @@ func (LM *LocalManagerStruct) spawnGoroutine(functionName string, workerFunc fun
 //	}
 func (LM *LocalManagerStruct) GetAllGoroutines() ([]*types.Routine, error) {
 
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		return nil, err
 	}
@@ -603,7 +1353,7 @@ func (LM *LocalManagerStruct) GetAllGoroutines() ([]*types.Routine, error) {
 //	count := localMgr.GetGoroutineCount()
 //	log.Printf("Active goroutines: %d", count)
 func (LM *LocalManagerStruct) GetGoroutineCount() int {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		return 0
 	}
@@ -634,7 +1384,7 @@ func (LM *LocalManagerStruct) GetGoroutineCount() int {
 //	}
 //	// Use this WaitGroup is typically handled automatically by Go() with AddToWaitGroup option
 func (LM *LocalManagerStruct) NewFunctionWaitGroup(ctx context.Context, functionName string) (*sync.WaitGroup, error) {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		metrics.RecordOperationError("function", "wait_group_create", "get_local_manager_failed")
 		return nil, err
@@ -701,5 +1451,5 @@ func (LM *LocalManagerStruct) GetRoutinesByFunctionName(functionName string) ([]
 //	    log.Printf("Local manager not found: %v", err)
 //	}
 func (LM *LocalManagerStruct) Get() (*types.LocalManager, error) {
-	return types.GetLocalManager(LM.AppName, LM.LocalName)
+	return LM.getLocalManager()
 }
@@ -1,9 +1,11 @@
 package local
 
 import (
+	"context"
 	"time"
 
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
 )
 
 // Option is a function that configures goroutine options.
@@ -14,22 +16,67 @@ type Option func(*goroutineOptions)
 // Ensure Option satisfies interfaces.GoroutineOption
 var _ interfaces.GoroutineOption = Option(nil)
 
+// AdmissionPolicy controls what Go() does when a MaxRoutines limit (global, app or local) is reached.
+type AdmissionPolicy int
+
+const (
+	// AdmissionReject makes Go() return ErrMaxRoutinesReached immediately (default)
+	AdmissionReject AdmissionPolicy = iota
+	// AdmissionWait makes Go() block until a routine slot frees up
+	AdmissionWait
+)
+
 // goroutineOptions holds configuration for spawning goroutines
 type goroutineOptions struct {
-	timeout       *time.Duration // nil means no timeout
-	panicRecovery bool           // whether to recover from panics
-	waitGroupName string         // function name for wait group (empty means no wait group)
+	timeout          *time.Duration      // nil means no timeout
+	panicRecovery    bool                // whether to recover from panics
+	waitGroupName    string              // function name for wait group (empty means no wait group)
+	admissionPolicy  AdmissionPolicy     // behaviour when a max routines limit is reached
+	admissionTimeout *time.Duration      // nil means wait without deadline (AdmissionWait only)
+	routineID        *string             // receives the spawned routine's ID (nil means not captured)
+	restartPolicy    *RestartPolicy      // nil means the goroutine is not supervised
+	panicHandler     types.PanicHandler  // nil means the global panic handler is used
+	labels           map[string]string   // user-defined pprof labels
+	maxConcurrent    int                 // per-function concurrency limit in this local manager (0 = unlimited)
+	singleflight     string              // key a running routine of the function is joined on ("" = always spawn)
+	interceptors     []types.Interceptor // wrap the worker runs, inside the interceptors of the managers
+	values           context.Context     // context whose values the worker runs see (nil = none)
+	internal         bool                // spawned by the local manager itself (no function defaults or manager interceptors)
 }
 
 // defaultGoroutineOptions returns the default options
 func defaultGoroutineOptions() *goroutineOptions {
 	return &goroutineOptions{
-		timeout:       nil,
-		panicRecovery: true, // Enabled by default for production safety
-		waitGroupName: "",
+		timeout:          nil,
+		panicRecovery:    true, // Enabled by default for production safety
+		waitGroupName:    "",
+		admissionPolicy:  AdmissionReject,
+		admissionTimeout: nil,
 	}
 }
 
+// describe returns the options as recorded on the routine for introspection
+func (opts *goroutineOptions) describe() types.RoutineOptions {
+	described := types.RoutineOptions{
+		PanicRecovery: opts.panicRecovery,
+		WaitGroup:     opts.waitGroupName,
+		MaxConcurrent: opts.maxConcurrent,
+		Singleflight:  opts.singleflight,
+		AdmissionWait: opts.admissionPolicy == AdmissionWait,
+		Supervised:    opts.supervised(),
+		Labels:        opts.labels,
+	}
+	if opts.timeout != nil {
+		described.Timeout = types.ReportDuration(*opts.timeout)
+	}
+	return described
+}
+
+// supervised reports whether the goroutine is restarted by a supervisor
+func (opts *goroutineOptions) supervised() bool {
+	return opts.restartPolicy != nil && opts.restartPolicy.Mode != RestartNever
+}
+
 // WithTimeout sets a timeout for the goroutine.
 // When the timeout expires, the context will be cancelled automatically.
 // The worker function should check ctx.Done() to handle timeout gracefully.
@@ -41,8 +88,9 @@ func WithTimeout(timeout time.Duration) Option {
 
 // WithPanicRecovery enables or disables panic recovery for the goroutine.
 // Panic recovery is enabled by default for production safety.
-// When enabled, panics in the worker function will be recovered,
-// logged via metrics, and the goroutine will complete normally with cleanup.
+// When enabled, panics in the worker function will be recovered, passed to the panic handler
+// (WithPanicHandler, or the manager tree's - see SetPanicHandler), stored on the routine's result
+// with their stack, and the goroutine will complete normally with cleanup.
 // Set to false only if you want panics to crash the goroutine (not recommended).
 func WithPanicRecovery(enabled bool) Option {
 	return func(opts *goroutineOptions) {
@@ -50,6 +98,41 @@ func WithPanicRecovery(enabled bool) Option {
 	}
 }
 
+// WithPanicHandler sets the handler called with the value and stack of every panic recovered
+// from this goroutine, instead of the panic handler of the manager tree (SetPanicHandler).
+// The handler runs on the panicking goroutine before the routine completes. It has no effect
+// when panic recovery is disabled.
+//
+// Example:
+//
+//	localMgr.Go("worker", worker, WithPanicHandler(func(info *types.PanicInfo) {
+//	    log.Printf("%s panicked: %v\n%s", info.FunctionName, info.Value, info.Stack)
+//	}))
+func WithPanicHandler(handler types.PanicHandler) Option {
+	return func(opts *goroutineOptions) {
+		opts.panicHandler = handler
+	}
+}
+
+// WithLabels adds user-defined pprof labels to the goroutine, next to the app, local, function and
+// routine ID labels every tracked routine runs with (types.LabelApp etc., which cannot be overridden).
+// The worker runs under pprof.Do, so the labels show up in goroutine and CPU profiles and are
+// carried by the worker's context. Calling WithLabels again adds to the labels.
+//
+// Example:
+//
+//	localMgr.Go("consumer", consume, WithLabels(map[string]string{"topic": "orders"}))
+func WithLabels(labels map[string]string) Option {
+	return func(opts *goroutineOptions) {
+		if opts.labels == nil {
+			opts.labels = make(map[string]string, len(labels))
+		}
+		for key, value := range labels {
+			opts.labels[key] = value
+		}
+	}
+}
+
 // AddToWaitGroup adds the goroutine to a function wait group.
 // The functionName parameter specifies which function wait group to use.
 // The wait group will be created if it doesn't exist, and the goroutine
@@ -64,3 +147,93 @@ func AddToWaitGroup(functionName string) Option {
 		opts.waitGroupName = functionName
 	}
 }
+
+// WithAdmissionPolicy sets what Go() does when a max routines limit is reached.
+// AdmissionReject (default) returns ErrMaxRoutinesReached, AdmissionWait blocks until a slot frees up
+// or the local manager is shut down.
+func WithAdmissionPolicy(policy AdmissionPolicy) Option {
+	return func(opts *goroutineOptions) {
+		opts.admissionPolicy = policy
+	}
+}
+
+// WithAdmissionTimeout makes Go() wait up to timeout for a routine slot when a max routines
+// limit is reached. If no slot frees up in time, Go() returns ErrAdmissionTimeout.
+// This implies the AdmissionWait policy.
+func WithAdmissionTimeout(timeout time.Duration) Option {
+	return func(opts *goroutineOptions) {
+		opts.admissionPolicy = AdmissionWait
+		opts.admissionTimeout = &timeout
+	}
+}
+
+// WithMaxConcurrent limits the number of routines of this function name running at once in the
+// local manager to n. Once n are running, Go() returns ErrMaxConcurrentReached or - with
+// WithAdmissionPolicy(AdmissionWait) or WithAdmissionTimeout - waits for one of them to finish.
+// The limit is checked on every Go() call that passes the option, so pass the same n on each call.
+//
+// Example:
+//
+//	err := localMgr.Go("reindex", reindex, WithMaxConcurrent(1))
+func WithMaxConcurrent(n int) Option {
+	return func(opts *goroutineOptions) {
+		opts.maxConcurrent = n
+	}
+}
+
+// WithSingleflight deduplicates the goroutine by key: if a routine of the same function name started
+// with the same key is still running in the local manager, Go() joins it instead of spawning - it
+// returns nil and CaptureRoutineID receives the running routine's ID, so the caller can wait for
+// its result (WaitForRoutineResult).
+//
+// Example:
+//
+//	var id string
+//	_ = localMgr.Go("refresh-token", refresh, WithSingleflight(userID), CaptureRoutineID(&id))
+//	result, err := localMgr.WaitForRoutineResult(id, 10*time.Second)
+func WithSingleflight(key string) Option {
+	return func(opts *goroutineOptions) {
+		opts.singleflight = key
+	}
+}
+
+// WithInterceptors wraps the worker runs of the goroutine with interceptors, inside those registered
+// on the global, app and local manager (Use). The first interceptor is the outermost.
+//
+// Example:
+//
+//	localMgr.Go("import", importFile, WithInterceptors(withRequestID(requestID)))
+func WithInterceptors(interceptors ...types.Interceptor) Option {
+	return func(opts *goroutineOptions) {
+		opts.interceptors = append(opts.interceptors[:len(opts.interceptors):len(opts.interceptors)], interceptors...)
+	}
+}
+
+// WithContextValues makes the values of ctx - request IDs, loggers, trace spans - visible in the
+// goroutine's context, behind those the orchestrator sets. The goroutine is not cancelled with ctx:
+// its lifetime stays tied to the local manager and its timeout.
+//
+// Example:
+//
+//	func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+//	    localMgr.Go("audit", s.audit, WithContextValues(r.Context()))
+//	}
+func WithContextValues(ctx context.Context) Option {
+	return func(opts *goroutineOptions) {
+		opts.values = ctx
+	}
+}
+
+// CaptureRoutineID stores the ID of the spawned routine in dst before Go() returns.
+// Use it to look up the routine (GetRoutine, WaitForRoutineResult, CancelRoutine) afterwards.
+//
+// Example:
+//
+//	var id string
+//	_ = localMgr.Go("job", worker, CaptureRoutineID(&id))
+//	result, err := localMgr.WaitForRoutineResult(id, time.Minute)
+func CaptureRoutineID(dst *string) Option {
+	return func(opts *goroutineOptions) {
+		opts.routineID = dst
+	}
+}
@@ -0,0 +1,441 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// poolTask is a task waiting in a worker pool's queue
+type poolTask struct {
+	fn          func(ctx context.Context) error
+	submittedAt time.Time
+	result      chan error // Receives the task's error (SubmitWait only, nil otherwise)
+}
+
+// WorkerPool runs submitted tasks on a fixed or auto-scaling set of tracked worker routines.
+// Tasks are plain functions, not tracked routines - only the workers are, so submitting a task
+// costs a channel send instead of a routine ID, a map insert and a lock.
+//
+// The pool is owned by its local manager: LocalManagerStruct.Shutdown(true) drains it (queued
+// tasks still run) before cancelling the workers, and Shutdown(false) stops it immediately.
+type WorkerPool struct {
+	LM     *LocalManagerStruct
+	Name   string
+	config types.WorkerPoolConfig
+
+	localManager *types.LocalManager
+	tasks        chan poolTask
+	ctx          context.Context // Cancelled when the pool is stopped
+	cancel       context.CancelFunc
+
+	intakeMu   sync.RWMutex  // Held for reading while sending on tasks, for writing while closing it
+	closed     bool          // Whether tasks has been closed
+	closing    chan struct{} // Closed when the pool stops accepting tasks
+	closeOnce  sync.Once
+	stopped    chan struct{} // Closed once the pool is drained or stopped
+	finishOnce sync.Once
+
+	workersMu sync.Mutex
+	workers   int // Running workers
+	busy      int // Workers currently running a task
+	wg        sync.WaitGroup
+}
+
+// Ensure WorkerPool satisfies interfaces.WorkerPool
+var _ interfaces.WorkerPool = (*WorkerPool)(nil)
+
+// NewWorkerPool creates a worker pool owned by this local manager and starts its MinWorkers workers.
+// Workers are tracked routines named after the pool and added to the pool's function wait group.
+// This method is idempotent - calling it again with the same name returns the existing pool.
+//
+// Example:
+//
+//	pool, err := localMgr.NewWorkerPool("jobs", types.WorkerPoolConfig{
+//	    MinWorkers: 2,
+//	    MaxWorkers: 16,
+//	    QueueSize:  1024,
+//	})
+//	err = pool.Submit(ctx, func(ctx context.Context) error { return process(ctx, job) })
+func (LM *LocalManagerStruct) NewWorkerPool(name string, config types.WorkerPoolConfig) (interfaces.WorkerPool, error) {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		metrics.RecordOperationError("pool", "create", "get_local_manager_failed")
+		return nil, err
+	}
+
+	// Return the existing pool
+	if existing, ok := localManager.GetPool(name); ok {
+		if pool, ok := existing.(*WorkerPool); ok {
+			return pool, nil
+		}
+	}
+
+	// Fill in defaults
+	if config.MinWorkers <= 0 {
+		config.MinWorkers = types.DefaultPoolWorkers
+	}
+	if config.MaxWorkers < config.MinWorkers {
+		config.MaxWorkers = config.MinWorkers
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = config.MaxWorkers
+	}
+	if config.IdleTimeout <= 0 {
+		config.IdleTimeout = types.DefaultPoolIdleTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &WorkerPool{
+		LM:           LM,
+		Name:         name,
+		config:       config,
+		localManager: localManager,
+		tasks:        make(chan poolTask, config.QueueSize),
+		ctx:          ctx,
+		cancel:       cancel,
+		closing:      make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+
+	// Register the pool before starting workers - a concurrent call creating the same pool returns
+	// the pool registered first and starts none
+	if existing, added := localManager.AddPoolIfAbsent(pool); !added {
+		cancel()
+		if existing, ok := existing.(*WorkerPool); ok {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("%w: %s is not a worker pool", errors.ErrInvalidConfig, name)
+	}
+
+	// Start the fixed part of the pool
+	pool.workersMu.Lock()
+	for i := 0; i < config.MinWorkers; i++ {
+		if err := pool.spawnWorker(); err != nil {
+			pool.workersMu.Unlock()
+			pool.Stop()
+			return nil, err
+		}
+	}
+	pool.workersMu.Unlock()
+
+	metrics.RecordFunctionOperation("pool_create", LM.AppName, LM.LocalName, name)
+	return pool, nil
+}
+
+// GetWorkerPool returns a worker pool of this local manager by name.
+// Returns ErrWorkerPoolNotFound if no running pool has that name.
+func (LM *LocalManagerStruct) GetWorkerPool(name string) (interfaces.WorkerPool, error) {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return nil, err
+	}
+	existing, ok := localManager.GetPool(name)
+	if !ok {
+		return nil, &errors.NotFoundError{Name: name, Err: errors.ErrWorkerPoolNotFound}
+	}
+	pool, ok := existing.(*WorkerPool)
+	if !ok {
+		return nil, &errors.NotFoundError{Name: name, Err: errors.ErrWorkerPoolNotFound}
+	}
+	return pool, nil
+}
+
+// GetName returns the pool name
+func (WP *WorkerPool) GetName() string {
+	return WP.Name
+}
+
+// GetWorkerCount returns the number of running workers
+func (WP *WorkerPool) GetWorkerCount() int {
+	WP.workersMu.Lock()
+	defer WP.workersMu.Unlock()
+	return WP.workers
+}
+
+// GetBusyCount returns the number of workers currently running a task
+func (WP *WorkerPool) GetBusyCount() int {
+	WP.workersMu.Lock()
+	defer WP.workersMu.Unlock()
+	return WP.busy
+}
+
+// GetQueueDepth returns the number of tasks waiting in the queue
+func (WP *WorkerPool) GetQueueDepth() int {
+	return len(WP.tasks)
+}
+
+// Submit queues a task, blocking while the queue is full (backpressure).
+// Returns ctx.Err() if ctx is done first, or ErrWorkerPoolClosed if the pool is draining or stopped.
+func (WP *WorkerPool) Submit(ctx context.Context, task func(ctx context.Context) error) error {
+	return WP.submit(ctx, poolTask{fn: task, submittedAt: time.Now()}, true)
+}
+
+// TrySubmit queues a task without blocking.
+// Returns ErrWorkerPoolFull if the queue is full, or ErrWorkerPoolClosed if the pool is draining or stopped.
+func (WP *WorkerPool) TrySubmit(task func(ctx context.Context) error) error {
+	return WP.submit(context.Background(), poolTask{fn: task, submittedAt: time.Now()}, false)
+}
+
+// SubmitWait queues a task like Submit and blocks until it has run, returning the task's error.
+// A panicking task returns an error wrapping ErrRoutinePanicked. If ctx is done first, ctx.Err()
+// is returned and the task may still run. If the pool is stopped before the task runs,
+// ErrWorkerPoolClosed is returned.
+func (WP *WorkerPool) SubmitWait(ctx context.Context, task func(ctx context.Context) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result := make(chan error, 1)
+	if err := WP.submit(ctx, poolTask{fn: task, submittedAt: time.Now(), result: result}, true); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-WP.stopped:
+		// The task may have finished just before the pool stopped
+		select {
+		case err := <-result:
+			return err
+		default:
+			return errors.ErrWorkerPoolClosed
+		}
+	}
+}
+
+// Drain stops accepting tasks and waits up to timeout for the workers to finish the queued tasks.
+// On success the pool is removed from its local manager. On timeout it returns an
+// *errors.ShutdownTimeoutError and the workers keep running - call Stop to cancel them.
+func (WP *WorkerPool) Drain(timeout time.Duration) error {
+	WP.closeIntake()
+
+	done := make(chan struct{})
+	go func() {
+		WP.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		WP.finish()
+		metrics.RecordFunctionOperation("pool_drain", WP.LM.AppName, WP.LM.LocalName, WP.Name)
+		return nil
+	case <-time.After(timeout):
+		metrics.RecordOperationError("pool", "drain", "drain_timeout")
+		return &errors.ShutdownTimeoutError{
+			App:       WP.LM.AppName,
+			Local:     WP.LM.LocalName,
+			Pool:      WP.Name,
+			Timeout:   timeout,
+			Remaining: WP.GetQueueDepth() + WP.GetBusyCount(),
+		}
+	}
+}
+
+// Stop stops accepting tasks, cancels the running tasks and discards the queue without waiting.
+func (WP *WorkerPool) Stop() {
+	WP.closeIntake()
+	WP.finish()
+	metrics.RecordFunctionOperation("pool_stop", WP.LM.AppName, WP.LM.LocalName, WP.Name)
+}
+
+// submit sends a task to the queue, blocking only if wait is set
+func (WP *WorkerPool) submit(ctx context.Context, task poolTask, wait bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Hold the intake lock while sending so the queue cannot be closed underneath us
+	WP.intakeMu.RLock()
+	defer WP.intakeMu.RUnlock()
+	if WP.closed {
+		return errors.ErrWorkerPoolClosed
+	}
+
+	if wait {
+		select {
+		case WP.tasks <- task:
+		case <-WP.closing:
+			return errors.ErrWorkerPoolClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	} else {
+		select {
+		case WP.tasks <- task:
+		case <-WP.closing:
+			return errors.ErrWorkerPoolClosed
+		default:
+			metrics.RecordOperationError("pool", "submit", "queue_full")
+			return fmt.Errorf("%w: %s", errors.ErrWorkerPoolFull, WP.Name)
+		}
+	}
+
+	metrics.UpdatePoolQueueDepth(WP.LM.AppName, WP.LM.LocalName, WP.Name, len(WP.tasks))
+	WP.scaleUp()
+	return nil
+}
+
+// scaleUp starts another worker when more tasks are queued than there are idle workers
+func (WP *WorkerPool) scaleUp() {
+	WP.workersMu.Lock()
+	defer WP.workersMu.Unlock()
+
+	if WP.workers < WP.config.MaxWorkers && len(WP.tasks) > WP.workers-WP.busy {
+		// Best effort - the existing workers still pick up the task if spawning fails
+		_ = WP.spawnWorker()
+	}
+}
+
+// spawnWorker starts a tracked worker routine. Must be called with workersMu held.
+func (WP *WorkerPool) spawnWorker() error {
+	WP.workers++
+	WP.wg.Add(1)
+	err := WP.LM.goInternal(WP.Name, WP.worker, AddToWaitGroup(WP.Name))
+	if err != nil {
+		WP.workers--
+		WP.wg.Done()
+		metrics.RecordOperationError("pool", "spawn_worker", "spawn_failed")
+		return err
+	}
+	metrics.UpdatePoolWorkers(WP.LM.AppName, WP.LM.LocalName, WP.Name, WP.workers, WP.busy)
+	return nil
+}
+
+// worker runs queued tasks until the queue is closed and empty, the pool is stopped, the routine
+// is cancelled, or - for workers above MinWorkers - it has been idle for IdleTimeout.
+func (WP *WorkerPool) worker(routineCtx context.Context) error {
+	retired := false
+	defer func() {
+		WP.workersMu.Lock()
+		if !retired {
+			WP.workers--
+		}
+		metrics.UpdatePoolWorkers(WP.LM.AppName, WP.LM.LocalName, WP.Name, WP.workers, WP.busy)
+		WP.workersMu.Unlock()
+		WP.wg.Done()
+	}()
+
+	// Combine cancellations: routine cancel (shutdown) or pool stop
+	ctx, cancel := context.WithCancel(routineCtx)
+	stop := context.AfterFunc(WP.ctx, cancel)
+	defer stop()
+	defer cancel()
+
+	// Only auto-scaled pools retire idle workers
+	var idle <-chan time.Time
+	var idleTimer *time.Timer
+	if WP.config.MaxWorkers > WP.config.MinWorkers {
+		idleTimer = time.NewTimer(WP.config.IdleTimeout)
+		defer idleTimer.Stop()
+		idle = idleTimer.C
+	}
+
+	for {
+		// A stopped pool discards the queue instead of draining it
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		select {
+		case task, ok := <-WP.tasks:
+			if !ok {
+				// Queue closed and drained
+				return nil
+			}
+			WP.runTask(ctx, task)
+			if idleTimer != nil {
+				idleTimer.Reset(WP.config.IdleTimeout)
+			}
+		case <-ctx.Done():
+			return nil
+		case <-idle:
+			if retired = WP.retire(); retired {
+				return nil
+			}
+			idleTimer.Reset(WP.config.IdleTimeout)
+		}
+	}
+}
+
+// retire removes an idle worker from the count if the pool is above MinWorkers
+func (WP *WorkerPool) retire() bool {
+	WP.workersMu.Lock()
+	defer WP.workersMu.Unlock()
+	if WP.workers <= WP.config.MinWorkers {
+		return false
+	}
+	WP.workers--
+	return true
+}
+
+// runTask executes one task, recovering panics so a failing task does not take its worker down
+func (WP *WorkerPool) runTask(ctx context.Context, task poolTask) {
+	WP.setBusy(1)
+	defer WP.setBusy(-1)
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				metrics.RecordOperationError("pool", "task", "panic")
+				err = &errors.RoutinePanicError{
+					App:      WP.LM.AppName,
+					Local:    WP.LM.LocalName,
+					Function: WP.Name,
+					Value:    r,
+					Stack:    debug.Stack(),
+				}
+			}
+		}()
+		return task.fn(ctx)
+	}()
+
+	metrics.RecordPoolTaskLatency(WP.LM.AppName, WP.LM.LocalName, WP.Name, time.Since(task.submittedAt))
+	metrics.UpdatePoolQueueDepth(WP.LM.AppName, WP.LM.LocalName, WP.Name, len(WP.tasks))
+	if err != nil {
+		metrics.RecordGoroutineOperation("task_error", WP.LM.AppName, WP.LM.LocalName, WP.Name)
+	}
+	if task.result != nil {
+		task.result <- err
+	}
+}
+
+// setBusy adjusts the busy worker count and the utilisation metric
+func (WP *WorkerPool) setBusy(delta int) {
+	WP.workersMu.Lock()
+	defer WP.workersMu.Unlock()
+	WP.busy += delta
+	metrics.UpdatePoolWorkers(WP.LM.AppName, WP.LM.LocalName, WP.Name, WP.workers, WP.busy)
+}
+
+// closeIntake stops accepting tasks and closes the queue so workers exit once it is empty
+func (WP *WorkerPool) closeIntake() {
+	WP.closeOnce.Do(func() {
+		// Wake blocked submitters first - they hold the intake lock for reading
+		close(WP.closing)
+		WP.intakeMu.Lock()
+		WP.closed = true
+		close(WP.tasks)
+		WP.intakeMu.Unlock()
+	})
+}
+
+// finish cancels the workers and removes the pool from its local manager
+func (WP *WorkerPool) finish() {
+	WP.finishOnce.Do(func() {
+		WP.cancel()
+		close(WP.stopped)
+		WP.localManager.RemovePool(WP.Name)
+		metrics.RemovePoolMetrics(WP.LM.AppName, WP.LM.LocalName, WP.Name)
+	})
+}
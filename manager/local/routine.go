@@ -2,9 +2,11 @@ package local
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/ctxo"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
 )
@@ -14,7 +16,7 @@ import (
 // CancelRoutine cancels a routine's context by its ID.
 // Returns an error if the routine is not found.
 func (LM *LocalManagerStruct) CancelRoutine(routineID string) error {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		metrics.RecordOperationError("goroutine", "cancel", "get_local_manager_failed")
 		return err
@@ -40,33 +42,99 @@ func (LM *LocalManagerStruct) CancelRoutine(routineID string) error {
 // WaitForRoutine blocks until the routine's done channel is signaled or the timeout expires.
 // Returns true if the routine completed, false if timeout occurred or routine not found.
 func (LM *LocalManagerStruct) WaitForRoutine(routineID string, timeout time.Duration) bool {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return LM.WaitForRoutineContext(ctx, routineID) == nil
+}
+
+// WaitForRoutineContext blocks until the routine's done channel is signaled or ctx is done.
+// Returns nil if the routine completed, ctx.Err() if ctx ended first, or an error if the routine
+// is not found.
+//
+// Example:
+//
+//	if err := localMgr.WaitForRoutineContext(r.Context(), id); err != nil {
+//	    log.Printf("Routine %s still running: %v", id, err)
+//	}
+func (LM *LocalManagerStruct) WaitForRoutineContext(ctx context.Context, routineID string) error {
+	localManager, err := LM.getLocalManager()
 	if err != nil {
-		return false
+		return err
 	}
 
 	routine, err := localManager.GetRoutine(routineID)
 	if err != nil {
-		return false
+		return err
 	}
 
 	doneChan := routine.DoneChan()
 	if doneChan == nil {
-		return false
+		return &errors.NotFoundError{Name: routineID, Err: errors.ErrRoutineNotFound}
 	}
 
 	select {
 	case <-doneChan:
-		return true
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// GetRoutineResult returns the result of a completed routine by its ID.
+// Results stay available after the routine leaves the tracking map, until they are evicted
+// from the local manager's bounded recent results store.
+// Returns ErrRoutineNotFinished if the routine is still running, or ErrRoutineNotFound if it is unknown.
+func (LM *LocalManagerStruct) GetRoutineResult(routineID string) (*types.RoutineResult, error) {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the tracking map first - results are stored before routines are removed from it
+	routine, err := localManager.GetRoutine(routineID)
+	if err == nil {
+		select {
+		case <-routine.DoneChan():
+			return routine.GetResult(), nil
+		default:
+			return nil, fmt.Errorf("%w: %s", errors.ErrRoutineNotFinished, routineID)
+		}
+	}
+
+	return localManager.GetRoutineResult(routineID)
+}
+
+// WaitForRoutineResult blocks until the routine completes or the timeout expires, then returns its result.
+// Returns ErrRoutineNotFinished if the timeout expires, or ErrRoutineNotFound if the routine is unknown.
+func (LM *LocalManagerStruct) WaitForRoutineResult(routineID string, timeout time.Duration) (*types.RoutineResult, error) {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return nil, err
+	}
+
+	routine, err := localManager.GetRoutine(routineID)
+	if err != nil {
+		// Already completed (or unknown) - look in the recent results store
+		return localManager.GetRoutineResult(routineID)
+	}
+
+	doneChan := routine.DoneChan()
+	if doneChan == nil {
+		return nil, fmt.Errorf("%w: %s", errors.ErrRoutineNotFinished, routineID)
+	}
+
+	select {
+	case <-doneChan:
+		return routine.GetResult(), nil
 	case <-time.After(timeout):
-		return false
+		return nil, fmt.Errorf("%w: %s (waited %v)", errors.ErrRoutineNotFinished, routineID, timeout)
 	}
 }
 
 // IsRoutineDone checks if a routine's done channel has been signaled.
 // Returns false if routine is not found or done channel is nil.
 func (LM *LocalManagerStruct) IsRoutineDone(routineID string) bool {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		return false
 	}
@@ -93,7 +161,7 @@ func (LM *LocalManagerStruct) IsRoutineDone(routineID string) bool {
 // Returns context.Background() if routine is not found or context is nil.
 // Instead of returning context.Background(), spawn a background using Context.Spawnchild(localparent ctx)
 func (LM *LocalManagerStruct) GetRoutineContext(routineID string) context.Context {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		ctx, _ := ctxo.SpawnChild(localManager.ParentCtx)
 		return ctx
@@ -116,7 +184,7 @@ func (LM *LocalManagerStruct) GetRoutineContext(routineID string) context.Contex
 // GetRoutineStartedAt returns the timestamp when a routine was started.
 // Returns 0 if routine is not found.
 func (LM *LocalManagerStruct) GetRoutineStartedAt(routineID string) int64 {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		return 0
 	}
@@ -132,7 +200,7 @@ func (LM *LocalManagerStruct) GetRoutineStartedAt(routineID string) int64 {
 // GetRoutineUptime returns the duration a routine has been running.
 // Returns 0 if routine is not found or not started.
 func (LM *LocalManagerStruct) GetRoutineUptime(routineID string) time.Duration {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		return 0
 	}
@@ -154,7 +222,7 @@ func (LM *LocalManagerStruct) GetRoutineUptime(routineID string) time.Duration {
 // IsRoutineContextCancelled checks if a routine's context has been cancelled.
 // Returns false if routine is not found or context is nil.
 func (LM *LocalManagerStruct) IsRoutineContextCancelled(routineID string) bool {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		return false
 	}
@@ -180,7 +248,7 @@ func (LM *LocalManagerStruct) IsRoutineContextCancelled(routineID string) bool {
 // GetRoutine returns a routine by its ID.
 // Returns an error if the routine is not found.
 func (LM *LocalManagerStruct) GetRoutine(routineID string) (*types.Routine, error) {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		return nil, err
 	}
@@ -189,7 +257,7 @@ func (LM *LocalManagerStruct) GetRoutine(routineID string) (*types.Routine, erro
 
 // WaitForFunction waits for all goroutines of a specific function to complete.
 func (LM *LocalManagerStruct) WaitForFunction(functionName string) error {
-	localManager, err := types.GetLocalManager(LM.AppName, LM.LocalName)
+	localManager, err := LM.getLocalManager()
 	if err != nil {
 		return err
 	}
@@ -212,6 +280,38 @@ func (LM *LocalManagerStruct) WaitForFunction(functionName string) error {
 // 2. No resources are held except memory
 // 3. The function correctly returns false on timeout
 func (LM *LocalManagerStruct) WaitForFunctionWithTimeout(functionName string, timeout time.Duration) bool {
+	return LM.waitForFunction(context.Background(), functionName, timeout)
+}
+
+// WaitForFunctionContext waits for all goroutines of a function until ctx is done.
+// Returns nil if all completed, ctx.Err() if ctx ended first, or an error if the function has no
+// wait group.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := localMgr.WaitForFunctionContext(ctx, "worker"); err != nil {
+//	    log.Printf("Workers still running: %v", err)
+//	}
+func (LM *LocalManagerStruct) WaitForFunctionContext(ctx context.Context, functionName string) error {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return err
+	}
+	if _, err := localManager.GetFunctionWg(functionName); err != nil {
+		return err
+	}
+
+	if LM.waitForFunction(ctx, functionName, types.NoTimeout) {
+		return nil
+	}
+	return ctx.Err()
+}
+
+// waitForFunction waits up to timeout for all goroutines of a function unless ctx is done first.
+// Returns true if all completed.
+func (LM *LocalManagerStruct) waitForFunction(ctx context.Context, functionName string, timeout time.Duration) bool {
 	done := make(chan struct{}, 1) // Buffered to prevent goroutine leak if timeout occurs
 
 	go func() {
@@ -241,6 +341,9 @@ func (LM *LocalManagerStruct) WaitForFunctionWithTimeout(functionName string, ti
 		// The goroutine will continue running until WaitGroup completes,
 		// but this is acceptable as it will eventually exit.
 		return false
+	case <-ctx.Done():
+		// The caller's context ended the wait - same as a timeout
+		return false
 	}
 }
 
@@ -0,0 +1,508 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// maxCountedMissedRuns bounds the number of missed cron run times counted after a long pause
+const maxCountedMissedRuns = 1000
+
+// Schedule runs a worker function on a fixed interval, a fixed delay or a cron expression.
+// Every run is a tracked routine named after the schedule and added to the schedule's function
+// wait group. The schedule's own loop is a tracked routine under the same name, so
+// ShutdownFunction(name) stops the schedule together with its runs.
+//
+// The schedule is owned by its local manager: LocalManagerStruct.Shutdown stops it before the
+// routines are shut down, so no new runs start while the local manager shuts down.
+type Schedule struct {
+	LM       *LocalManagerStruct
+	Name     string
+	config   types.ScheduleConfig
+	kind     string
+	cron     *CronSchedule // Set for cron schedules
+	firstRun time.Time     // Run time the loop starts with
+	worker   func(ctx context.Context) error
+	opts     []interfaces.GoroutineOption // Options of every run
+
+	localManager *types.LocalManager
+	ctx          context.Context // Cancelled when the schedule is stopped
+	cancel       context.CancelFunc
+	drainCtx     context.Context // Cancelled when the schedule is drained or stopped
+	drainCancel  context.CancelFunc
+	stopped      chan struct{} // Closed once the loop has exited
+	finishOnce   sync.Once
+
+	mu           sync.Mutex
+	running      int
+	idle         chan struct{} // Closed while no run is in progress
+	nextRun      time.Time
+	lastRun      time.Time
+	lastDuration time.Duration
+	lastErr      error
+	runs         int64
+	failures     int64
+	skipped      int64
+	missed       int64
+}
+
+// Ensure Schedule satisfies interfaces.Schedule
+var _ interfaces.Schedule = (*Schedule)(nil)
+
+// NewSchedule creates a schedule owned by this local manager and starts it. The worker runs as a
+// tracked routine named after the schedule at every run time; opts apply to every run
+// (e.g. WithTimeout to bound a run), except WithRestart and WithSingleflight. This method is idempotent - calling it again with the same
+// name returns the existing schedule.
+//
+// Returns ErrInvalidSchedule unless exactly one of Interval, Delay and Cron is set, or if the
+// cron expression does not parse.
+//
+// Example:
+//
+//	// Every 5 minutes on weekdays, skipping a run while the previous one is still going
+//	schedule, err := localMgr.NewSchedule("report", types.ScheduleConfig{
+//	    Cron:   "*/5 * * * MON-FRI",
+//	    Jitter: 10 * time.Second,
+//	}, func(ctx context.Context) error {
+//	    return buildReport(ctx)
+//	}, local.WithTimeout(time.Minute))
+func (LM *LocalManagerStruct) NewSchedule(name string, config types.ScheduleConfig, workerFunc func(ctx context.Context) error, opts ...interfaces.GoroutineOption) (interfaces.Schedule, error) {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		metrics.RecordOperationError("schedule", "create", "get_local_manager_failed")
+		return nil, err
+	}
+
+	// Return the existing schedule
+	if existing, ok := localManager.GetSchedule(name); ok {
+		if schedule, ok := existing.(*Schedule); ok {
+			return schedule, nil
+		}
+	}
+
+	schedule := &Schedule{
+		LM:           LM,
+		Name:         name,
+		config:       config,
+		worker:       workerFunc,
+		opts:         append(append([]interfaces.GoroutineOption{}, opts...), AddToWaitGroup(name)),
+		localManager: localManager,
+		stopped:      make(chan struct{}),
+		idle:         make(chan struct{}),
+	}
+	close(schedule.idle)
+
+	kinds := 0
+	if config.Interval > 0 {
+		kinds++
+		schedule.kind = types.ScheduleKindInterval
+	}
+	if config.Delay > 0 {
+		kinds++
+		schedule.kind = types.ScheduleKindFixedDelay
+	}
+	if config.Cron != "" {
+		kinds++
+		schedule.kind = types.ScheduleKindCron
+		if schedule.cron, err = ParseCron(config.Cron, config.Location); err != nil {
+			metrics.RecordOperationError("schedule", "create", "invalid_schedule")
+			return nil, err
+		}
+	}
+	if kinds != 1 {
+		metrics.RecordOperationError("schedule", "create", "invalid_schedule")
+		return nil, fmt.Errorf("%w: exactly one of Interval, Delay and Cron must be set for schedule %s", errors.ErrInvalidSchedule, name)
+	}
+	if workerFunc == nil {
+		metrics.RecordOperationError("schedule", "create", "invalid_schedule")
+		return nil, fmt.Errorf("%w: nil worker for schedule %s", errors.ErrInvalidSchedule, name)
+	}
+
+	// The first run time is known before the loop starts
+	now := time.Now()
+	schedule.firstRun = schedule.first(now)
+	if config.RunImmediately {
+		schedule.nextRun = now
+	} else {
+		schedule.nextRun = schedule.firstRun
+	}
+
+	schedule.ctx, schedule.cancel = context.WithCancel(context.Background())
+	schedule.drainCtx, schedule.drainCancel = context.WithCancel(schedule.ctx)
+	localManager.AddSchedule(schedule)
+	if err := LM.goInternal(name, schedule.loop, AddToWaitGroup(name)); err != nil {
+		schedule.cancel()
+		schedule.finish()
+		metrics.RecordOperationError("schedule", "create", "spawn_failed")
+		return nil, err
+	}
+
+	metrics.RecordFunctionOperation("schedule_create", LM.AppName, LM.LocalName, name)
+	return schedule, nil
+}
+
+// GetSchedule returns a schedule of this local manager by name.
+// Returns ErrScheduleNotFound if no running schedule has that name.
+func (LM *LocalManagerStruct) GetSchedule(name string) (interfaces.Schedule, error) {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return nil, err
+	}
+	existing, ok := localManager.GetSchedule(name)
+	if !ok {
+		return nil, &errors.NotFoundError{Name: name, Err: errors.ErrScheduleNotFound}
+	}
+	schedule, ok := existing.(*Schedule)
+	if !ok {
+		return nil, &errors.NotFoundError{Name: name, Err: errors.ErrScheduleNotFound}
+	}
+	return schedule, nil
+}
+
+// GetName returns the schedule name
+func (S *Schedule) GetName() string {
+	return S.Name
+}
+
+// NextRun returns the time of the next run, or the zero time while no run is pending
+func (S *Schedule) NextRun() time.Time {
+	S.mu.Lock()
+	defer S.mu.Unlock()
+	return S.nextRun
+}
+
+// LastRun returns the start time of the last finished run, or the zero time before the first run finished
+func (S *Schedule) LastRun() time.Time {
+	S.mu.Lock()
+	defer S.mu.Unlock()
+	return S.lastRun
+}
+
+// LastError returns the error of the last finished run (nil if it succeeded)
+func (S *Schedule) LastError() error {
+	S.mu.Lock()
+	defer S.mu.Unlock()
+	return S.lastErr
+}
+
+// Status returns the schedule's current state
+func (S *Schedule) Status() types.ScheduleStatus {
+	S.mu.Lock()
+	defer S.mu.Unlock()
+
+	status := types.ScheduleStatus{
+		Name:         S.Name,
+		Kind:         S.kind,
+		Spec:         S.spec(),
+		LastDuration: types.ReportDuration(S.lastDuration),
+		Running:      S.running,
+		Runs:         S.runs,
+		Failures:     S.failures,
+		Skipped:      S.skipped,
+		Missed:       S.missed,
+	}
+	if !S.nextRun.IsZero() {
+		nextRun := S.nextRun
+		status.NextRun = &nextRun
+	}
+	if !S.lastRun.IsZero() {
+		lastRun := S.lastRun
+		status.LastRun = &lastRun
+	}
+	if S.lastErr != nil {
+		status.LastError = S.lastErr.Error()
+	}
+	return status
+}
+
+// Stop stops starting runs and cancels the runs in progress without waiting for them.
+// The schedule is removed from its local manager once its loop has exited.
+func (S *Schedule) Stop() {
+	S.cancel()
+	metrics.RecordFunctionOperation("schedule_stop", S.LM.AppName, S.LM.LocalName, S.Name)
+}
+
+// Drain stops starting runs and lets the runs in progress finish; Stop still cancels them.
+// The schedule is removed from its local manager once its loop has exited.
+func (S *Schedule) Drain() {
+	S.drainCancel()
+	metrics.RecordFunctionOperation("schedule_drain", S.LM.AppName, S.LM.LocalName, S.Name)
+}
+
+// Done returns a channel that is closed once the schedule has stopped
+func (S *Schedule) Done() <-chan struct{} {
+	return S.stopped
+}
+
+// spec returns the interval, delay or cron expression of the schedule
+func (S *Schedule) spec() string {
+	switch S.kind {
+	case types.ScheduleKindInterval:
+		return S.config.Interval.String()
+	case types.ScheduleKindFixedDelay:
+		return S.config.Delay.String()
+	default:
+		return S.config.Cron
+	}
+}
+
+// loop starts the runs at their run times until the schedule is stopped or its routine is cancelled
+func (S *Schedule) loop(routineCtx context.Context) error {
+	defer S.finish()
+
+	// Combine cancellations: routine cancel (ShutdownFunction, shutdown), Stop or Drain
+	ctx, cancel := context.WithCancel(routineCtx)
+	stop := context.AfterFunc(S.drainCtx, cancel)
+	defer stop()
+	defer cancel()
+	// Runs in progress are cancelled once the loop exits, unless it was drained
+	defer func() {
+		if S.drainCtx.Err() == nil {
+			S.cancel()
+		}
+	}()
+
+	due := S.firstRun
+	if S.config.RunImmediately {
+		S.trigger(ctx)
+		if S.kind == types.ScheduleKindFixedDelay && !S.waitIdle(ctx) {
+			return nil
+		}
+		due = S.first(time.Now())
+	}
+	for !due.IsZero() {
+		jitter := S.jitter()
+		runAt := due.Add(jitter)
+		S.setNextRun(runAt)
+		if !sleepUntil(ctx, runAt) {
+			return nil
+		}
+
+		if S.kind == types.ScheduleKindFixedDelay {
+			// The next run time is only known once this run has finished
+			S.setNextRun(time.Time{})
+			S.trigger(ctx)
+			if !S.waitIdle(ctx) {
+				return nil
+			}
+			due = time.Now().Add(S.config.Delay)
+			continue
+		}
+
+		// Run times that passed while waiting (e.g. for a queued run) are missed
+		next, passed := S.advance(due, time.Now().Add(-jitter))
+		due = next
+		if passed > 1 {
+			if S.config.MissedRun == types.MissedRunSkip {
+				S.recordMissed(passed)
+				continue
+			}
+			// One run stands in for all of them
+			S.recordMissed(passed - 1)
+		}
+		S.trigger(ctx)
+	}
+	return nil
+}
+
+// first returns the first run time after the schedule starts at now
+func (S *Schedule) first(now time.Time) time.Time {
+	switch S.kind {
+	case types.ScheduleKindInterval:
+		return now.Add(S.config.Interval)
+	case types.ScheduleKindFixedDelay:
+		return now.Add(S.config.Delay)
+	default:
+		return S.cron.Next(now)
+	}
+}
+
+// advance returns the first run time after now and the number of run times from due up to now
+func (S *Schedule) advance(due, now time.Time) (time.Time, int) {
+	if now.Before(due) {
+		return due, 0
+	}
+	if S.kind == types.ScheduleKindInterval {
+		passed := int(now.Sub(due)/S.config.Interval) + 1
+		return due.Add(time.Duration(passed) * S.config.Interval), passed
+	}
+
+	passed := 0
+	next := due
+	for !next.IsZero() && !next.After(now) {
+		if passed < maxCountedMissedRuns {
+			passed++
+			next = S.cron.Next(next)
+		} else {
+			// Stop counting and jump past now
+			next = S.cron.Next(now)
+		}
+	}
+	return next, passed
+}
+
+// trigger starts a run, applying the overlap policy if the previous run is still going
+func (S *Schedule) trigger(ctx context.Context) {
+	S.mu.Lock()
+	running := S.running
+	S.mu.Unlock()
+
+	if running > 0 {
+		switch S.config.Overlap {
+		case types.OverlapSkip:
+			S.mu.Lock()
+			S.skipped++
+			S.mu.Unlock()
+			metrics.RecordScheduleSkipped(S.LM.AppName, S.LM.LocalName, S.Name, "overlap", 1)
+			return
+		case types.OverlapQueue:
+			if !S.waitIdle(ctx) {
+				return
+			}
+		case types.OverlapAllow:
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	S.startRun()
+}
+
+// startRun spawns one run as a tracked routine
+func (S *Schedule) startRun() {
+	S.mu.Lock()
+	if S.running == 0 {
+		S.idle = make(chan struct{})
+	}
+	S.running++
+	S.mu.Unlock()
+
+	// A run is one worker call: a failed run is retried at the next run time, not by a supervisor,
+	// and a run joining another routine would never finish - so restart and singleflight options
+	// (passed or defaulted for the schedule's name) do not apply
+	options := S.LM.resolveOptions(S.Name, S.opts)
+	options.restartPolicy = nil
+	options.singleflight = ""
+
+	err := S.LM.spawnGoroutine(S.Name, func(routineCtx context.Context) error {
+		// Runs are cancelled when the schedule stops, including runs spawned while it was stopping
+		ctx, cancel := context.WithCancel(routineCtx)
+		stop := context.AfterFunc(S.ctx, cancel)
+		defer stop()
+		defer cancel()
+
+		startedAt := time.Now()
+		finished := false
+		var err error
+		defer func() {
+			if !finished {
+				// The worker panicked - the routine's panic recovery handles the panic itself
+				err = &errors.RoutinePanicError{App: S.LM.AppName, Local: S.LM.LocalName, Function: S.Name}
+			}
+			S.finishRun(startedAt, err)
+		}()
+		err = S.worker(ctx)
+		finished = true
+		return err
+	}, options)
+	if err != nil {
+		metrics.RecordOperationError("schedule", "run", "spawn_failed")
+		S.finishRun(time.Now(), err)
+	}
+}
+
+// finishRun records the outcome of a run
+func (S *Schedule) finishRun(startedAt time.Time, err error) {
+	duration := time.Since(startedAt)
+
+	S.mu.Lock()
+	S.lastRun = startedAt
+	S.lastDuration = duration
+	S.lastErr = err
+	S.runs++
+	if err != nil {
+		S.failures++
+	}
+	S.running--
+	if S.running == 0 {
+		close(S.idle)
+	}
+	S.mu.Unlock()
+
+	select {
+	case <-S.stopped:
+		// Cancelled run of a stopped schedule - its metrics are already removed
+	default:
+		metrics.RecordScheduleRun(S.LM.AppName, S.LM.LocalName, S.Name, startedAt, duration, err)
+	}
+}
+
+// waitIdle waits until no run is in progress. Returns false if ctx is done first.
+func (S *Schedule) waitIdle(ctx context.Context) bool {
+	S.mu.Lock()
+	idle := S.idle
+	S.mu.Unlock()
+
+	select {
+	case <-idle:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// recordMissed counts run times that passed without a run
+func (S *Schedule) recordMissed(count int) {
+	S.mu.Lock()
+	S.missed += int64(count)
+	S.mu.Unlock()
+	metrics.RecordScheduleSkipped(S.LM.AppName, S.LM.LocalName, S.Name, "missed", count)
+}
+
+// setNextRun updates the next run time and its metric
+func (S *Schedule) setNextRun(nextRun time.Time) {
+	S.mu.Lock()
+	S.nextRun = nextRun
+	S.mu.Unlock()
+	metrics.UpdateScheduleNextRun(S.LM.AppName, S.LM.LocalName, S.Name, nextRun)
+}
+
+// jitter returns a random delay in [0, Jitter)
+func (S *Schedule) jitter() time.Duration {
+	if S.config.Jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(S.config.Jitter)))
+}
+
+// finish removes the stopped schedule from its local manager
+func (S *Schedule) finish() {
+	S.finishOnce.Do(func() {
+		S.setNextRun(time.Time{})
+		S.localManager.RemoveSchedule(S.Name)
+		metrics.RemoveScheduleMetrics(S.LM.AppName, S.LM.LocalName, S.Name)
+		close(S.stopped)
+	})
+}
+
+// sleepUntil waits until t. Returns false if ctx is done first.
+func sleepUntil(ctx context.Context, t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
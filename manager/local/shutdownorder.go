@@ -0,0 +1,79 @@
+package local
+
+import (
+	"slices"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// SetShutdownPriority sets when this local manager shuts down relative to the other local managers
+// of its app. Local managers with a lower priority shut down first (default 0); each distinct
+// priority becomes its own shutdown phase.
+//
+// Example:
+//
+//	ingressMgr.SetShutdownPriority(0)  // stops first
+//	consumerMgr.SetShutdownPriority(1)
+//	writerMgr.SetShutdownPriority(2)   // stops last
+func (LM *LocalManagerStruct) SetShutdownPriority(priority int) error {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return err
+	}
+
+	types.LockShutdownOrder()
+	defer types.UnlockShutdownOrder()
+
+	order := localManager.GetShutdownOrder()
+	order.Priority = priority
+	localManager.SetShutdownOrder(order)
+	return nil
+}
+
+// ShutdownAfter declares that this local manager shuts down only after the named local managers
+// of the same app have shut down, regardless of priority. Local managers that do not exist at
+// shutdown time are ignored.
+//
+// Returns ErrShutdownDependencyCycle (and keeps the previous ordering) if the declaration would
+// create a dependency cycle.
+//
+// Example:
+//
+//	consumerMgr.ShutdownAfter("ingress")
+//	writerMgr.ShutdownAfter("consumers")
+func (LM *LocalManagerStruct) ShutdownAfter(localNames ...string) error {
+	appManager, err := LM.getAppManager()
+	if err != nil {
+		return err
+	}
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return err
+	}
+
+	types.LockShutdownOrder()
+	defer types.UnlockShutdownOrder()
+
+	orders := appManager.GetLocalShutdownOrders()
+	if err := types.CheckShutdownCycle(orders, LM.LocalName, localNames); err != nil {
+		return err
+	}
+
+	order := localManager.GetShutdownOrder()
+	for _, localName := range localNames {
+		if !slices.Contains(order.After, localName) {
+			order.After = append(order.After, localName)
+		}
+	}
+	localManager.SetShutdownOrder(order)
+	return nil
+}
+
+// GetShutdownOrder returns the declared shutdown ordering of this local manager
+func (LM *LocalManagerStruct) GetShutdownOrder() types.ShutdownOrder {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return types.ShutdownOrder{}
+	}
+	return localManager.GetShutdownOrder()
+}
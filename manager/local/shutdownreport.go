@@ -0,0 +1,95 @@
+package local
+
+import (
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// forceCancelGrace is how long a shutdown waits in total for force-cancelled routines to exit
+// before it reports the rest as still running
+const forceCancelGrace = 100 * time.Millisecond
+
+// isRoutineDone reports whether the routine's done channel has been closed
+func isRoutineDone(routine *types.Routine) bool {
+	doneChan := routine.DoneChan()
+	if doneChan == nil {
+		return false
+	}
+	select {
+	case <-doneChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// pendingRoutines returns the IDs of the routines that have not exited yet
+func pendingRoutines(routines []*types.Routine) map[string]bool {
+	pending := make(map[string]bool)
+	for _, routine := range routines {
+		if !isRoutineDone(routine) {
+			pending[routine.GetID()] = true
+		}
+	}
+	return pending
+}
+
+// waitForForced waits up to forceCancelGrace for the forced routines to exit - cooperative routines
+// return shortly after their context is cancelled
+func waitForForced(routines []*types.Routine, forced map[string]bool) {
+	if len(forced) == 0 {
+		return
+	}
+	timer := time.NewTimer(forceCancelGrace)
+	defer timer.Stop()
+	for _, routine := range routines {
+		doneChan := routine.DoneChan()
+		if !forced[routine.GetID()] || doneChan == nil {
+			continue
+		}
+		select {
+		case <-doneChan:
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+// addRoutineReports adds one function level child per function name to report and sorts the
+// routines into it: routines not in forced exited gracefully, forced routines that exit within
+// forceCancelGrace were force-cancelled, and forced routines that are still running after it are
+// listed as such.
+func addRoutineReports(report *types.ShutdownReport, routines []*types.Routine, forced map[string]bool) {
+	waitForForced(routines, forced)
+
+	functions := make(map[string]*types.ShutdownReport)
+	for _, routine := range routines {
+		functionName := routine.GetFunctionName()
+		functionReport, ok := functions[functionName]
+		if !ok {
+			functionReport = &types.ShutdownReport{
+				Level:     types.ShutdownLevelFunction,
+				Name:      functionName,
+				Safe:      report.Safe,
+				StartedAt: report.StartedAt,
+			}
+			functions[functionName] = functionReport
+		}
+
+		id := routine.GetID()
+		switch {
+		case !forced[id]:
+			functionReport.Graceful = append(functionReport.Graceful, id)
+		case isRoutineDone(routine):
+			functionReport.ForceCancelled = append(functionReport.ForceCancelled, id)
+		default:
+			functionReport.StillRunning = append(functionReport.StillRunning, id)
+		}
+	}
+
+	for _, functionReport := range functions {
+		report.AddChild(functionReport.Finish())
+	}
+	report.SortChildren()
+}
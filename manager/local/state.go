@@ -0,0 +1,50 @@
+package local
+
+import (
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// GetState returns the lifecycle state of this local manager: running, draining while it shuts
+// down, or stopped once it has shut down. A local manager that does not exist is reported as stopped.
+//
+// Example:
+//
+//	if localMgr.GetState() != types.StateRunning {
+//	    return
+//	}
+func (LM *LocalManagerStruct) GetState() types.ManagerState {
+	localManager, err := LM.getLocalManager()
+	if err != nil {
+		return types.StateStopped
+	}
+	return localManager.GetState()
+}
+
+// checkRunning returns a ManagerClosedError for the first of the global manager, the app manager
+// and this local manager that is draining or stopped
+func (LM *LocalManagerStruct) checkRunning(localManager *types.LocalManager) error {
+	globalManager, err := LM.getGlobalManager()
+	if err != nil {
+		return err
+	}
+	if state := globalManager.GetState(); state != types.StateRunning {
+		return &errors.ManagerClosedError{State: state.String()}
+	}
+	appManager, err := globalManager.GetAppManager(LM.AppName)
+	if err != nil {
+		return err
+	}
+	if state := appManager.GetState(); state != types.StateRunning {
+		return &errors.ManagerClosedError{App: LM.AppName, State: state.String()}
+	}
+	return checkLocalRunning(LM.AppName, localManager)
+}
+
+// checkLocalRunning returns a ManagerClosedError if localManager is draining or stopped
+func checkLocalRunning(appName string, localManager *types.LocalManager) error {
+	if state := localManager.GetState(); state != types.StateRunning {
+		return &errors.ManagerClosedError{App: appName, Local: localManager.LocalName, State: state.String()}
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package local
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// RestartMode decides when a supervised goroutine is restarted after its worker returns.
+type RestartMode int
+
+const (
+	// RestartNever never restarts the worker (same as not supervising it)
+	RestartNever RestartMode = iota
+	// RestartOnFailure restarts the worker when it returns an error or panics
+	RestartOnFailure
+	// RestartAlways restarts the worker whenever it returns, including with a nil error
+	RestartAlways
+)
+
+// Default backoff settings used when a RestartPolicy leaves them empty
+const (
+	DefaultRestartInitialBackoff = 100 * time.Millisecond
+	DefaultRestartMaxBackoff     = 30 * time.Second
+	DefaultRestartMultiplier     = 2.0
+
+	// restart timestamps kept for backoff when the restart budget is unlimited
+	maxTrackedRestarts = 64
+)
+
+// RestartPolicy configures supervised restarts for a goroutine spawned with WithRestart.
+//
+// A supervised goroutine keeps its routine ID, tracking entry and done channel across restarts.
+// Every run gets a fresh context from LocalManager.SpawnChild (and a fresh WithTimeout deadline).
+// Cancelling the routine (CancelRoutine, ShutdownFunction, Shutdown) or shutting down any parent
+// manager stops the supervisor - a cancelled routine is never restarted.
+type RestartPolicy struct {
+	// Mode decides which worker exits trigger a restart
+	Mode RestartMode
+	// InitialBackoff is the delay before the first restart (default 100ms)
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential backoff (default 30s). A run that stays up for longer than
+	// MaxBackoff is stable: the backoff after it starts again from InitialBackoff.
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff after each restart within the window (default 2)
+	Multiplier float64
+	// Jitter randomises each backoff by up to ±Jitter (fraction, e.g. 0.2 = ±20%)
+	Jitter float64
+	// MaxRestarts is the restart budget within Window (0 = unlimited)
+	MaxRestarts int
+	// Window is the sliding window for MaxRestarts (0 = restarts are counted forever)
+	Window time.Duration
+	// OnBudgetExhausted is called when MaxRestarts is reached and the supervisor gives up.
+	// It receives the function name, the routine ID, the restarts within the window and the last error.
+	OnBudgetExhausted func(functionName, routineID string, restarts int, lastErr error)
+}
+
+// shouldRestart reports whether a worker exit with err should be restarted under this policy
+func (RP *RestartPolicy) shouldRestart(err error) bool {
+	switch RP.Mode {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return err != nil
+	default:
+		return false
+	}
+}
+
+// maxBackoff returns MaxBackoff or its default
+func (RP *RestartPolicy) maxBackoff() time.Duration {
+	if RP.MaxBackoff <= 0 {
+		return DefaultRestartMaxBackoff
+	}
+	return RP.MaxBackoff
+}
+
+// backoff returns the delay before the next restart, given the restarts already made within the
+// window since the last stable run
+func (RP *RestartPolicy) backoff(restarts int) time.Duration {
+	initial := RP.InitialBackoff
+	if initial <= 0 {
+		initial = DefaultRestartInitialBackoff
+	}
+	maxBackoff := RP.maxBackoff()
+	multiplier := RP.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultRestartMultiplier
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(restarts))
+	if delay > float64(maxBackoff) {
+		delay = float64(maxBackoff)
+	}
+	if RP.Jitter > 0 {
+		delay += delay * RP.Jitter * (2*rand.Float64() - 1)
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	return time.Duration(delay)
+}
+
+// WithRestart supervises the goroutine: when its worker returns or panics, it is restarted
+// according to policy, with exponential backoff, jitter and an optional restart budget.
+//
+// Example:
+//
+//	localMgr.Go("consumer", consume, local.WithRestart(local.RestartPolicy{
+//	    Mode:        local.RestartOnFailure,
+//	    MaxRestarts: 5,
+//	    Window:      time.Minute,
+//	}))
+func WithRestart(policy RestartPolicy) Option {
+	return func(opts *goroutineOptions) {
+		opts.restartPolicy = &policy
+	}
+}
+
+// supervisedContext hands out a fresh context for every run of a supervised routine.
+// Its stop method is installed as the routine's cancel function, so cancelling the routine
+// stops the supervisor instead of triggering a restart.
+type supervisedContext struct {
+	mu           sync.Mutex
+	localManager *types.LocalManager
+	timeout      *time.Duration
+	cancel       context.CancelFunc // Cancels the current run
+	stopped      bool
+	stopCh       chan struct{}
+}
+
+// newSupervisedContext creates the context source for a supervised routine
+func newSupervisedContext(localManager *types.LocalManager, timeout *time.Duration) *supervisedContext {
+	return &supervisedContext{
+		localManager: localManager,
+		timeout:      timeout,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// next cancels the previous run's context and returns a fresh one for the next run
+func (SC *supervisedContext) next() context.Context {
+	SC.mu.Lock()
+	defer SC.mu.Unlock()
+
+	if SC.cancel != nil {
+		SC.cancel()
+	}
+	ctx, cancel := newRoutineContext(SC.localManager, SC.timeout)
+	if SC.stopped {
+		// Stopped between runs - hand out an already cancelled context
+		cancel()
+	}
+	SC.cancel = cancel
+	return ctx
+}
+
+// stop cancels the current run and prevents further restarts
+func (SC *supervisedContext) stop() {
+	SC.mu.Lock()
+	defer SC.mu.Unlock()
+
+	if !SC.stopped {
+		SC.stopped = true
+		close(SC.stopCh)
+	}
+	if SC.cancel != nil {
+		SC.cancel()
+	}
+}
+
+// isStopped reports whether the routine was cancelled
+func (SC *supervisedContext) isStopped() bool {
+	SC.mu.Lock()
+	defer SC.mu.Unlock()
+	return SC.stopped
+}
+
+// superviseWorker runs workerFunc and restarts it according to the restart policy.
+// It returns the outcome of the last run once the policy, the restart budget or a cancellation
+// ends supervision.
+func (LM *LocalManagerStruct) superviseWorker(localManager *types.LocalManager, routine *types.Routine, supervised *supervisedContext,
+	functionName string, workerFunc func(ctx context.Context) error, opts *goroutineOptions) (*types.PanicInfo, error) {
+	policy := opts.restartPolicy
+	localCtx, _ := localManager.GetLocalContext()
+	var localDone <-chan struct{}
+	if localCtx != nil {
+		localDone = localCtx.Done()
+	}
+
+	var restartTimes []time.Time
+	// Restarts since the last run that stayed up for longer than MaxBackoff - without a Window,
+	// restartTimes alone would keep a routine that fails once a day at MaxBackoff forever
+	unstableRestarts := 0
+	ctx := routine.GetContext()
+	for {
+		runStart := time.Now()
+		panicInfo, err := LM.runWorker(ctx, routine, workerFunc, opts)
+		if time.Since(runStart) > policy.maxBackoff() {
+			unstableRestarts = 0
+		}
+
+		// Stop when cancelled, when any parent manager shuts down, when the local manager drains
+		// (or has stopped), or when the policy says so
+		if supervised.isStopped() || (localCtx != nil && localCtx.Err() != nil) ||
+			localManager.GetState() != types.StateRunning || !policy.shouldRestart(err) {
+			return panicInfo, err
+		}
+
+		// Enforce the restart budget within the sliding window
+		now := time.Now()
+		if policy.Window > 0 {
+			kept := restartTimes[:0]
+			for _, t := range restartTimes {
+				if now.Sub(t) < policy.Window {
+					kept = append(kept, t)
+				}
+			}
+			restartTimes = kept
+		}
+		if policy.MaxRestarts > 0 && len(restartTimes) >= policy.MaxRestarts {
+			metrics.RecordGoroutineOperation("restart_budget_exhausted", LM.AppName, LM.LocalName, functionName)
+			if policy.OnBudgetExhausted != nil {
+				policy.OnBudgetExhausted(functionName, routine.GetID(), len(restartTimes), err)
+			}
+			return panicInfo, err
+		}
+
+		// Back off before restarting
+		timer := time.NewTimer(policy.backoff(min(len(restartTimes), unstableRestarts)))
+		select {
+		case <-timer.C:
+		case <-supervised.stopCh:
+			timer.Stop()
+			return panicInfo, err
+		case <-localDone:
+			timer.Stop()
+			return panicInfo, err
+		}
+		if localManager.GetState() != types.StateRunning {
+			return panicInfo, err
+		}
+
+		restartTimes = append(restartTimes, time.Now())
+		unstableRestarts++
+		if policy.MaxRestarts <= 0 && len(restartTimes) > maxTrackedRestarts {
+			// Unlimited budget - only the backoff needs the count, which saturates long before this
+			restartTimes = restartTimes[1:]
+		}
+		ctx = supervised.next()
+		routine.SetContext(ctx)
+		routine.IncrementRestarts()
+		metrics.RecordGoroutineRestart(LM.AppName, LM.LocalName, functionName)
+	}
+}
@@ -12,6 +12,9 @@ type Collector struct {
 	// stopCh is used to signal the collector to stop
 	stopCh chan struct{}
 
+	// done is closed when the collection loop has returned
+	done chan struct{}
+
 	// intervalCh is used to signal interval changes
 	intervalCh chan time.Duration
 
@@ -26,6 +29,7 @@ type Collector struct {
 func NewCollector() *Collector {
 	return &Collector{
 		stopCh:          make(chan struct{}),
+		done:            make(chan struct{}),
 		intervalCh:      make(chan time.Duration, 1), // Buffered to avoid blocking
 		running:         false,
 		currentInterval: types.UpdateInterval,
@@ -42,13 +46,14 @@ func (c *Collector) Start() {
 	go c.collectLoop()
 }
 
-// Stop stops the metrics collector
+// Stop stops the metrics collector and waits for an ongoing collection to finish
 func (c *Collector) Stop() {
 	if !c.running {
 		return
 	}
 
 	close(c.stopCh)
+	<-c.done
 	c.running = false
 }
 
@@ -69,39 +74,41 @@ func (c *Collector) UpdateInterval(newInterval time.Duration) {
 // collectLoop runs the collection loop with dynamic interval support
 // It observes changes to types.UpdateInterval and updates the ticker accordingly
 func (c *Collector) collectLoop() {
-    globalMgr, _ := types.GetGlobalManager()
-    metadata := globalMgr.GetMetadata()
-    
-    currentInterval := metadata.GetUpdateInterval()
-    c.currentInterval = currentInterval
-    ticker := time.NewTicker(currentInterval)
-    defer ticker.Stop()
-    
-    c.Collect()
-    
-    for {
-        select {
-        case <-ticker.C:
-            c.Collect()
-            
-            newInterval := metadata.GetUpdateInterval()
-            if newInterval != currentInterval {
-                ticker.Stop()
-                currentInterval = newInterval
-                c.currentInterval = currentInterval
-                ticker = time.NewTicker(currentInterval)
-            }
-        case newInterval := <-c.intervalCh:
-            if newInterval != currentInterval {
-                ticker.Stop()
-                currentInterval = newInterval
-                c.currentInterval = currentInterval
-                ticker = time.NewTicker(currentInterval)
-            }
-        case <-c.stopCh:
-            return
-        }
-    }
+	defer close(c.done)
+
+	globalMgr, _ := types.GetGlobalManager()
+	metadata := globalMgr.GetMetadata()
+
+	currentInterval := metadata.GetUpdateInterval()
+	c.currentInterval = currentInterval
+	ticker := time.NewTicker(currentInterval)
+	defer ticker.Stop()
+
+	c.Collect()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.Collect()
+
+			newInterval := metadata.GetUpdateInterval()
+			if newInterval != currentInterval {
+				ticker.Stop()
+				currentInterval = newInterval
+				c.currentInterval = currentInterval
+				ticker = time.NewTicker(currentInterval)
+			}
+		case newInterval := <-c.intervalCh:
+			if newInterval != currentInterval {
+				ticker.Stop()
+				currentInterval = newInterval
+				c.currentInterval = currentInterval
+				ticker = time.NewTicker(currentInterval)
+			}
+		case <-c.stopCh:
+			return
+		}
+	}
 }
 
 // Collect gathers all metrics from the goroutine manager
@@ -283,33 +290,33 @@ func (c *Collector) collectGoroutineMetrics() {
 
 // collectMetadataMetrics collects metrics from metadata
 func (c *Collector) collectMetadataMetrics() {
-    if !types.IsIntilized().Global() {
-        MetricsEnabled.Set(0)
-        MaxRoutines.Set(0)
-        return
-    }
-    
-    globalMgr, err := types.GetGlobalManager()
-    if err != nil {
-        return
-    }
-    
-    metadata := globalMgr.GetMetadata()
-    if metadata == nil {
-        MetricsEnabled.Set(0)
-        MaxRoutines.Set(0)
-        return
-    }
-    
-    // ✅ FIX: Use getter method
-    if metadata.GetMetrics() {
-        MetricsEnabled.Set(1)
-    } else {
-        MetricsEnabled.Set(0)
-    }
-    
-    // ✅ FIX: Use getter method
-    MaxRoutines.Set(float64(metadata.GetMaxRoutines()))
+	if !types.IsIntilized().Global() {
+		MetricsEnabled.Set(0)
+		MaxRoutines.Set(0)
+		return
+	}
+
+	globalMgr, err := types.GetGlobalManager()
+	if err != nil {
+		return
+	}
+
+	metadata := globalMgr.GetMetadata()
+	if metadata == nil {
+		MetricsEnabled.Set(0)
+		MaxRoutines.Set(0)
+		return
+	}
+
+	// ✅ FIX: Use getter method
+	if metadata.GetMetrics() {
+		MetricsEnabled.Set(1)
+	} else {
+		MetricsEnabled.Set(0)
+	}
+
+	// ✅ FIX: Use getter method
+	MaxRoutines.Set(float64(metadata.GetMaxRoutines()))
 }
 
 // collectSystemMetrics collects system-level metrics
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// DebugPath is the path StartMetricsServer serves the debug handler on
+const DebugPath = "/debug/goroutines"
+
+// GetDebugHandler returns an HTTP handler serving the live Global → App → Local → Routine tree as JSON
+// (see types.TakeSnapshot). For each routine it shows its ID, function name, age, timeout deadline,
+// whether its context is cancelled and its function wait group.
+//
+// Query parameters (all optional):
+//   - app: only this app manager
+//   - local: only local managers with this name
+//   - function: only routines with this function name
+//   - min_age: only routines running for at least this long (Go duration, e.g. "5m")
+//
+// Like GetMetricsHandler it can be registered with your own mux.
+//
+// Example:
+//
+//	mux.Handle("/metrics", metrics.GetMetricsHandler())
+//	mux.Handle("/debug/goroutines", metrics.GetDebugHandler())
+//	// curl 'localhost:8080/debug/goroutines?app=api&min_age=10m'
+func GetDebugHandler() http.Handler {
+	return debugHandler(types.TakeSnapshot)
+}
+
+// GetDebugHandlerFor returns a debug handler like GetDebugHandler serving the tree of globalManager
+// instead of the package-level singleton
+func GetDebugHandlerFor(globalManager *types.GlobalManager) http.Handler {
+	return debugHandler(globalManager.TakeSnapshot)
+}
+
+// debugHandler serves the snapshots returned by takeSnapshot
+func debugHandler(takeSnapshot func(types.SnapshotFilter) *types.ManagerSnapshot) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		filter := types.SnapshotFilter{
+			AppName:      query.Get("app"),
+			LocalName:    query.Get("local"),
+			FunctionName: query.Get("function"),
+		}
+		if minAge := query.Get("min_age"); minAge != "" {
+			age, err := time.ParseDuration(minAge)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid min_age %q: %v", minAge, err), http.StatusBadRequest)
+				return
+			}
+			filter.MinAge = age
+		}
+
+		data, err := json.MarshalIndent(takeSnapshot(filter), "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	})
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// EventRecorder is the event subscriber that records orchestrator events as Prometheus metrics.
+// InitMetrics subscribes it; like every Record function it does nothing while metrics are disabled.
+type EventRecorder struct{}
+
+// OnEvent records the metrics of one event
+func (EventRecorder) OnEvent(event types.Event) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	switch event.Type {
+	case types.EventRoutineStarted:
+		GoroutineOperationsTotal.WithLabelValues("create", event.AppName, event.LocalName, event.FunctionName).Inc()
+	case types.EventRoutineCompleted:
+		GoroutineDuration.WithLabelValues(event.AppName, event.LocalName, event.FunctionName).Observe(event.Duration.Seconds())
+		GoroutineOperationsTotal.WithLabelValues("complete", event.AppName, event.LocalName, event.FunctionName).Inc()
+		if event.Err != nil {
+			GoroutineOperationsTotal.WithLabelValues("error", event.AppName, event.LocalName, event.FunctionName).Inc()
+		}
+	case types.EventRoutinePanicked:
+		// Bounded error type - the panic value and stack are in event.Panic
+		OperationErrorsTotal.WithLabelValues("goroutine", "run", "panic").Inc()
+	case types.EventRoutineTimedOut:
+		GoroutineOperationsTotal.WithLabelValues("timeout", event.AppName, event.LocalName, event.FunctionName).Inc()
+	case types.EventAppCreated, types.EventLocalCreated:
+		ManagerOperationsTotal.WithLabelValues(event.Manager, "create", event.AppName).Inc()
+	case types.EventShutdownBegan:
+		ManagerOperationsTotal.WithLabelValues(event.Manager, "shutdown", event.AppName).Inc()
+	case types.EventShutdownFinished:
+		shutdownType := "unsafe"
+		if event.Safe {
+			shutdownType = "safe"
+		}
+		ShutdownDuration.WithLabelValues(event.Manager, shutdownType, event.AppName, event.LocalName).Observe(event.Duration.Seconds())
+	case types.EventForceCancel:
+		ShutdownGoroutinesRemaining.WithLabelValues(event.Manager, event.AppName, event.LocalName).Set(float64(event.Count))
+	}
+}
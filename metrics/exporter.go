@@ -2,20 +2,30 @@ package metrics
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
 	"net/http"
 	"sync"
 
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// Errors of StartMetricsServer and StopMetricsServer
+var (
+	ErrServerRunning    = errors.New("metrics server is already running")
+	ErrServerNotRunning = errors.New("metrics server is not running")
+)
+
 var (
 	// metricsServer holds the HTTP server instance
 	metricsServer *http.Server
 	serverLock    sync.Mutex
 
+	// treeServers holds the metrics servers of isolated manager trees (guarded by serverLock)
+	treeServers = make(map[*types.GlobalManager]*http.Server)
+
 	// defaultCollector is the default metrics collector
 	defaultCollector *Collector
 	collectorLock    sync.Mutex
@@ -28,12 +38,14 @@ var (
 // NOTE: This function starts its own HTTP server. For library usage, it's recommended
 // to use GetMetricsHandler() instead and register it with your application's HTTP server.
 // This avoids port conflicts and gives you control over the server lifecycle.
+//
+// Returns ErrServerRunning if the server is already running.
 func StartMetricsServer(addr string) error {
 	serverLock.Lock()
 	defer serverLock.Unlock()
 
 	if metricsServer != nil {
-		return fmt.Errorf("metrics server is already running")
+		return ErrServerRunning
 	}
 
 	// Initialize metrics if not already done
@@ -47,9 +59,15 @@ func StartMetricsServer(addr string) error {
 	}
 	collectorLock.Unlock()
 
-	// Create HTTP server
+	metricsServer = serve(addr, newServeMux(promhttp.Handler(), GetDebugHandler()))
+	return nil
+}
+
+// newServeMux returns the handler of a metrics server: metrics, the live tree, health and an index
+func newServeMux(metricsHandler, debugHandler http.Handler) *http.ServeMux {
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/metrics", metricsHandler)
+	mux.Handle(DebugPath, debugHandler)
 
 	// Add a health check endpoint
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -71,14 +89,20 @@ func StartMetricsServer(addr string) error {
     <h1>GoRoutinesManager Metrics Exporter</h1>
     <p>Prometheus metrics are available at <a href="/metrics">/metrics</a></p>
     <p>Health check is available at <a href="/health">/health</a></p>
+    <p>Live goroutine tree is available at <a href="/debug/goroutines">/debug/goroutines</a></p>
 </body>
 </html>
 		`))
 	})
 
-	metricsServer = &http.Server{
+	return mux
+}
+
+// serve starts an HTTP server for handler on addr in the background
+func serve(addr string, handler http.Handler) *http.Server {
+	server := &http.Server{
 		Addr:    addr,
-		Handler: mux,
+		Handler: handler,
 	}
 
 	// Start server in a goroutine
@@ -87,11 +111,57 @@ func StartMetricsServer(addr string) error {
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Printf("Metrics server error: %v", err)
 		}
-	}(metricsServer)
+	}(server)
+
+	return server
+}
 
+// StartTreeMetricsServer starts an HTTP server exposing the metrics and live tree of an isolated
+// manager tree (see types.NewIsolatedGlobalManager), like StartMetricsServer does for the
+// package-level one. /metrics serves the tree's GlobalManager.MetricsHandler, or a registry with a
+// TreeCollector for the tree if it has none; the tree's gauges are collected when scraped.
+//
+// Returns ErrServerRunning if the tree's server is already running.
+func StartTreeMetricsServer(globalManager *types.GlobalManager, addr string) error {
+	serverLock.Lock()
+	defer serverLock.Unlock()
+
+	if _, ok := treeServers[globalManager]; ok {
+		return ErrServerRunning
+	}
+
+	metricsHandler := globalManager.MetricsHandler
+	if metricsHandler == nil {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(NewTreeCollector(globalManager))
+		metricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+	treeServers[globalManager] = serve(addr, newServeMux(metricsHandler, GetDebugHandlerFor(globalManager)))
 	return nil
 }
 
+// StopTreeMetricsServer gracefully stops the metrics server of an isolated manager tree.
+// Returns ErrServerNotRunning if the tree's server is not running.
+func StopTreeMetricsServer(ctx context.Context, globalManager *types.GlobalManager) error {
+	serverLock.Lock()
+	defer serverLock.Unlock()
+
+	server, ok := treeServers[globalManager]
+	if !ok {
+		return ErrServerNotRunning
+	}
+	delete(treeServers, globalManager)
+	return server.Shutdown(ctx)
+}
+
+// IsTreeServerRunning returns whether the metrics server of an isolated manager tree is running
+func IsTreeServerRunning(globalManager *types.GlobalManager) bool {
+	serverLock.Lock()
+	defer serverLock.Unlock()
+	_, ok := treeServers[globalManager]
+	return ok
+}
+
 // UpdateMetricsUpdateInterval updates the metrics collection interval dynamically
 // This implements the observer pattern - when UpdateInterval changes in types,
 // call this function to notify the collector
@@ -105,13 +175,14 @@ func UpdateMetricsUpdateInterval() {
 	}
 }
 
-// StopMetricsServer gracefully stops the metrics HTTP server
+// StopMetricsServer gracefully stops the metrics HTTP server.
+// Returns ErrServerNotRunning if the server is not running.
 func StopMetricsServer(ctx context.Context) error {
 	serverLock.Lock()
 	defer serverLock.Unlock()
 
 	if metricsServer == nil {
-		return fmt.Errorf("metrics server is not running")
+		return ErrServerNotRunning
 	}
 
 	// Stop the collector
@@ -67,6 +67,45 @@ var (
 
 	// GoroutineAge tracks the age of currently running goroutines
 	GoroutineAge *prometheus.GaugeVec
+
+	// GoroutineRestartsTotal tracks supervised goroutine restarts
+	GoroutineRestartsTotal *prometheus.CounterVec
+
+	// GoroutineAdmissionRejectionsTotal tracks Go() calls refused by a routine or concurrency limit
+	GoroutineAdmissionRejectionsTotal *prometheus.CounterVec
+)
+
+// Worker Pool Metrics (with labels)
+var (
+	// PoolQueueDepth tracks the number of tasks waiting in each worker pool's queue
+	PoolQueueDepth *prometheus.GaugeVec
+
+	// PoolWorkers tracks the number of running workers per worker pool
+	PoolWorkers *prometheus.GaugeVec
+
+	// PoolWorkerUtilization tracks the fraction of busy workers per worker pool (0-1)
+	PoolWorkerUtilization *prometheus.GaugeVec
+
+	// PoolTaskLatency tracks the time from task submission to task completion
+	PoolTaskLatency *prometheus.HistogramVec
+)
+
+// Schedule Metrics (with labels)
+var (
+	// ScheduleNextRun tracks the next run time of each schedule (Unix seconds, 0 while none is pending)
+	ScheduleNextRun *prometheus.GaugeVec
+
+	// ScheduleLastRun tracks the start time of the last finished run of each schedule (Unix seconds)
+	ScheduleLastRun *prometheus.GaugeVec
+
+	// ScheduleLastRunFailed indicates whether the last finished run of each schedule failed (1) or succeeded (0)
+	ScheduleLastRunFailed *prometheus.GaugeVec
+
+	// ScheduleRunsTotal tracks schedule run times by result (success, error, overlap, missed)
+	ScheduleRunsTotal *prometheus.CounterVec
+
+	// ScheduleRunDuration tracks the duration of schedule runs
+	ScheduleRunDuration *prometheus.HistogramVec
 )
 
 // Metadata Metrics
@@ -111,7 +150,7 @@ var (
 	ShutdownGoroutinesRemaining *prometheus.GaugeVec
 )
 
-// InitMetrics initializes and registers all Prometheus metrics
+// InitMetrics initializes and registers all Prometheus metrics and subscribes EventRecorder to events
 // This function is safe to call multiple times (uses sync.Once)
 func InitMetrics() {
 	once.Do(func() {
@@ -119,6 +158,8 @@ func InitMetrics() {
 		initAppMetrics()
 		initLocalMetrics()
 		initGoroutineMetrics()
+		initPoolMetrics()
+		initScheduleMetrics()
 		initMetadataMetrics()
 		initSystemMetrics()
 		initOperationMetrics()
@@ -126,6 +167,9 @@ func InitMetrics() {
 		metricsLock.Lock()
 		metricsInitialized = true
 		metricsLock.Unlock()
+
+		// Metrics of routine and manager events are recorded by an event subscriber
+		types.Subscribe(EventRecorder{})
 	})
 }
 
@@ -258,6 +302,122 @@ func initGoroutineMetrics() {
 		},
 		[]string{"app_name", "local_name", "function_name", "routine_id"},
 	)
+
+	GoroutineRestartsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "goroutine",
+			Name:      "restarts_total",
+			Help:      "Total number of supervised goroutine restarts",
+		},
+		[]string{"app_name", "local_name", "function_name"},
+	)
+
+	GoroutineAdmissionRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "goroutine",
+			Name:      "admission_rejections_total",
+			Help:      "Total number of Go() calls refused by a routine or concurrency limit, by reason",
+		},
+		[]string{"app_name", "local_name", "function_name", "reason"},
+	)
+}
+
+func initPoolMetrics() {
+	PoolQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "pool",
+			Name:      "queue_depth",
+			Help:      "Number of tasks waiting in the worker pool queue",
+		},
+		[]string{"app_name", "local_name", "pool_name"},
+	)
+
+	PoolWorkers = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "pool",
+			Name:      "workers",
+			Help:      "Number of running workers in the worker pool",
+		},
+		[]string{"app_name", "local_name", "pool_name"},
+	)
+
+	PoolWorkerUtilization = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "pool",
+			Name:      "worker_utilization",
+			Help:      "Fraction of busy workers in the worker pool (0-1)",
+		},
+		[]string{"app_name", "local_name", "pool_name"},
+	)
+
+	PoolTaskLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "pool",
+			Name:      "task_latency_seconds",
+			Help:      "Time from task submission to task completion in seconds",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"app_name", "local_name", "pool_name"},
+	)
+}
+
+func initScheduleMetrics() {
+	ScheduleNextRun = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "schedule",
+			Name:      "next_run_timestamp_seconds",
+			Help:      "Next run time of the schedule (Unix seconds, 0 while no run is pending)",
+		},
+		[]string{"app_name", "local_name", "schedule_name"},
+	)
+
+	ScheduleLastRun = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "schedule",
+			Name:      "last_run_timestamp_seconds",
+			Help:      "Start time of the last finished run of the schedule (Unix seconds)",
+		},
+		[]string{"app_name", "local_name", "schedule_name"},
+	)
+
+	ScheduleLastRunFailed = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "schedule",
+			Name:      "last_run_failed",
+			Help:      "Whether the last finished run of the schedule failed (1) or succeeded (0)",
+		},
+		[]string{"app_name", "local_name", "schedule_name"},
+	)
+
+	ScheduleRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "schedule",
+			Name:      "runs_total",
+			Help:      "Schedule run times by result (success, error, overlap, missed)",
+		},
+		[]string{"app_name", "local_name", "schedule_name", "result"},
+	)
+
+	ScheduleRunDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "goroutine_manager",
+			Subsystem: "schedule",
+			Name:      "run_duration_seconds",
+			Help:      "Duration of schedule runs in seconds",
+			Buckets:   []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600},
+		},
+		[]string{"app_name", "local_name", "schedule_name"},
+	)
 }
 
 func initMetadataMetrics() {
@@ -422,3 +582,115 @@ func RemoveGoroutineAge(appName, localName, functionName, routineID string) {
 
 	GoroutineAge.DeleteLabelValues(appName, localName, functionName, routineID)
 }
+
+// RecordGoroutineRestart records a supervised restart of a goroutine
+func RecordGoroutineRestart(appName, localName, functionName string) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	GoroutineRestartsTotal.WithLabelValues(appName, localName, functionName).Inc()
+}
+
+// RecordAdmissionRejection records a Go() call refused by a limit
+// (reason "max_routines", "max_concurrent", "admission_timeout" or "manager_shutdown")
+func RecordAdmissionRejection(appName, localName, functionName, reason string) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	GoroutineAdmissionRejectionsTotal.WithLabelValues(appName, localName, functionName, reason).Inc()
+}
+
+// UpdatePoolQueueDepth sets the number of queued tasks of a worker pool
+func UpdatePoolQueueDepth(appName, localName, poolName string, depth int) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	PoolQueueDepth.WithLabelValues(appName, localName, poolName).Set(float64(depth))
+}
+
+// UpdatePoolWorkers sets the running and busy worker counts of a worker pool
+func UpdatePoolWorkers(appName, localName, poolName string, workers, busy int) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	PoolWorkers.WithLabelValues(appName, localName, poolName).Set(float64(workers))
+	utilization := 0.0
+	if workers > 0 {
+		utilization = float64(busy) / float64(workers)
+	}
+	PoolWorkerUtilization.WithLabelValues(appName, localName, poolName).Set(utilization)
+}
+
+// RecordPoolTaskLatency records the time from submission to completion of a worker pool task
+func RecordPoolTaskLatency(appName, localName, poolName string, latency time.Duration) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	PoolTaskLatency.WithLabelValues(appName, localName, poolName).Observe(latency.Seconds())
+}
+
+// RemovePoolMetrics removes the metrics of a worker pool that was shut down
+func RemovePoolMetrics(appName, localName, poolName string) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	PoolQueueDepth.DeleteLabelValues(appName, localName, poolName)
+	PoolWorkers.DeleteLabelValues(appName, localName, poolName)
+	PoolWorkerUtilization.DeleteLabelValues(appName, localName, poolName)
+}
+
+// UpdateScheduleNextRun sets the next run time of a schedule (the zero time while no run is pending)
+func UpdateScheduleNextRun(appName, localName, scheduleName string, nextRun time.Time) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	value := 0.0
+	if !nextRun.IsZero() {
+		value = float64(nextRun.UnixNano()) / float64(time.Second)
+	}
+	ScheduleNextRun.WithLabelValues(appName, localName, scheduleName).Set(value)
+}
+
+// RecordScheduleRun records a finished schedule run
+func RecordScheduleRun(appName, localName, scheduleName string, startedAt time.Time, duration time.Duration, err error) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	result, failed := "success", 0.0
+	if err != nil {
+		result, failed = "error", 1.0
+	}
+	ScheduleLastRun.WithLabelValues(appName, localName, scheduleName).Set(float64(startedAt.UnixNano()) / float64(time.Second))
+	ScheduleLastRunFailed.WithLabelValues(appName, localName, scheduleName).Set(failed)
+	ScheduleRunsTotal.WithLabelValues(appName, localName, scheduleName, result).Inc()
+	ScheduleRunDuration.WithLabelValues(appName, localName, scheduleName).Observe(duration.Seconds())
+}
+
+// RecordScheduleSkipped records schedule run times that did not start a run
+// (reason "overlap": the previous run was still going, "missed": the run time passed)
+func RecordScheduleSkipped(appName, localName, scheduleName, reason string, count int) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	ScheduleRunsTotal.WithLabelValues(appName, localName, scheduleName, reason).Add(float64(count))
+}
+
+// RemoveScheduleMetrics removes the metrics of a schedule that was stopped
+func RemoveScheduleMetrics(appName, localName, scheduleName string) {
+	if !IsMetricsEnabled() {
+		return
+	}
+
+	ScheduleNextRun.DeleteLabelValues(appName, localName, scheduleName)
+	ScheduleLastRun.DeleteLabelValues(appName, localName, scheduleName)
+	ScheduleLastRunFailed.DeleteLabelValues(appName, localName, scheduleName)
+}
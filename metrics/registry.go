@@ -45,6 +45,21 @@ func ResetMetrics() {
 	GoroutinesByFunction.Reset()
 	GoroutineDuration.Reset()
 	GoroutineAge.Reset()
+	GoroutineRestartsTotal.Reset()
+	GoroutineAdmissionRejectionsTotal.Reset()
+
+	// Reset worker pool metrics
+	PoolQueueDepth.Reset()
+	PoolWorkers.Reset()
+	PoolWorkerUtilization.Reset()
+	PoolTaskLatency.Reset()
+
+	// Reset schedule metrics
+	ScheduleNextRun.Reset()
+	ScheduleLastRun.Reset()
+	ScheduleLastRunFailed.Reset()
+	ScheduleRunsTotal.Reset()
+	ScheduleRunDuration.Reset()
 
 	// Reset metadata metrics
 	MaxRoutines.Set(0)
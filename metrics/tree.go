@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	treeAppManagersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("goroutine_manager", "global", "app_managers_total"),
+		"Total number of app managers", nil, nil,
+	)
+	treeLocalManagersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("goroutine_manager", "global", "local_managers_total"),
+		"Total number of local managers across all apps", nil, nil,
+	)
+	treeGoroutinesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("goroutine_manager", "global", "goroutines_total"),
+		"Total number of tracked goroutines", nil, nil,
+	)
+	treeShutdownTimeoutDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("goroutine_manager", "global", "shutdown_timeout_seconds"),
+		"Configured shutdown timeout in seconds", nil, nil,
+	)
+	treeMaxRoutinesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("goroutine_manager", "metadata", "max_routines"),
+		"Configured maximum routines limit (0 = unlimited)", nil, nil,
+	)
+	treeAppGoroutinesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("goroutine_manager", "app", "goroutines"),
+		"Number of goroutines per app", []string{"app_name"}, nil,
+	)
+	treeLocalGoroutinesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("goroutine_manager", "local", "goroutines"),
+		"Number of goroutines per local manager", []string{"app_name", "local_name"}, nil,
+	)
+	treeByFunctionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("goroutine_manager", "goroutine", "by_function"),
+		"Number of goroutines grouped by function", []string{"app_name", "local_name", "function_name"}, nil,
+	)
+)
+
+// TreeCollector is a prometheus.Collector reporting the state of one manager tree at scrape time.
+// Unlike Collector, which polls the package-level singleton into the default registry, it reads the
+// global manager it was created for, so each isolated tree can be exported through its own registry.
+// It reports the same metric names as the tree gauges of Collector.
+type TreeCollector struct {
+	globalManager *types.GlobalManager
+}
+
+// NewTreeCollector creates a collector for globalManager
+//
+// Example:
+//
+//	registry := prometheus.NewRegistry()
+//	registry.MustRegister(metrics.NewTreeCollector(globalManager))
+//	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+func NewTreeCollector(globalManager *types.GlobalManager) *TreeCollector {
+	return &TreeCollector{globalManager: globalManager}
+}
+
+// Describe implements prometheus.Collector
+func (TC *TreeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- treeAppManagersDesc
+	ch <- treeLocalManagersDesc
+	ch <- treeGoroutinesDesc
+	ch <- treeShutdownTimeoutDesc
+	ch <- treeMaxRoutinesDesc
+	ch <- treeAppGoroutinesDesc
+	ch <- treeLocalGoroutinesDesc
+	ch <- treeByFunctionDesc
+}
+
+// Collect implements prometheus.Collector
+func (TC *TreeCollector) Collect(ch chan<- prometheus.Metric) {
+	snapshot := TC.globalManager.TakeSnapshot(types.SnapshotFilter{})
+
+	localCount := 0
+	for _, app := range snapshot.Apps {
+		appGoroutines := 0
+		for _, local := range app.Locals {
+			localCount++
+			appGoroutines += local.RoutineCount
+			ch <- prometheus.MustNewConstMetric(treeLocalGoroutinesDesc, prometheus.GaugeValue, float64(local.RoutineCount), app.Name, local.Name)
+
+			functionCounts := make(map[string]int)
+			for _, routine := range local.Routines {
+				functionCounts[routine.FunctionName]++
+			}
+			for functionName, count := range functionCounts {
+				ch <- prometheus.MustNewConstMetric(treeByFunctionDesc, prometheus.GaugeValue, float64(count), app.Name, local.Name, functionName)
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(treeAppGoroutinesDesc, prometheus.GaugeValue, float64(appGoroutines), app.Name)
+	}
+
+	ch <- prometheus.MustNewConstMetric(treeAppManagersDesc, prometheus.GaugeValue, float64(len(snapshot.Apps)))
+	ch <- prometheus.MustNewConstMetric(treeLocalManagersDesc, prometheus.GaugeValue, float64(localCount))
+	ch <- prometheus.MustNewConstMetric(treeGoroutinesDesc, prometheus.GaugeValue, float64(snapshot.RoutineCount))
+	if metadata := TC.globalManager.GetMetadata(); metadata != nil {
+		ch <- prometheus.MustNewConstMetric(treeShutdownTimeoutDesc, prometheus.GaugeValue, metadata.GetShutdownTimeout().Seconds())
+		ch <- prometheus.MustNewConstMetric(treeMaxRoutinesDesc, prometheus.GaugeValue, float64(metadata.GetMaxRoutines()))
+	}
+}
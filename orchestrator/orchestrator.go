@@ -0,0 +1,134 @@
+// Package orchestrator provides explicit, isolated instances of the Global → App → Local → Routine
+// manager hierarchy.
+//
+// The package-level constructors (global.NewGlobalManager, app.NewAppManager, local.NewLocalManager)
+// all operate on the process-wide singleton types.Global, which Default wraps. New creates an
+// Orchestrator that owns its own global manager tree, metadata and Prometheus registry, so tests can
+// run in parallel and one process can host several independent orchestrators.
+package orchestrator
+
+import (
+	"net/http"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/app"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Orchestrator owns a manager tree. It embeds the tree's GlobalManager, so Shutdown, ShutdownWithReport,
+// UpdateMetadata, NewAppManager and the other global manager methods operate on this tree only.
+//
+// Each orchestrator has its own event subscribers (Subscribe), panic handler (SetPanicHandler) and
+// admission gate, so Go() calls waiting for a routine slot are only woken by their own tree, and
+// the SET_METRICS_URL metadata flag starts a metrics server serving its registry, stopped when the tree
+// shuts down. Process-wide state is still shared between orchestrators: process signals only reach the
+// default orchestrator unless Init is passed global.WithSignalPolicy, and the operation counters and
+// histograms of the metrics package go to the default Prometheus registry.
+type Orchestrator struct {
+	interfaces.GlobalGoroutineManagerInterface
+
+	globalManager *types.GlobalManager
+	registry      *prometheus.Registry
+}
+
+// New creates an orchestrator with its own global manager tree, metadata and Prometheus registry.
+// The tree's root context is not tied to process signals - shut it down explicitly, or pass
+// global.WithSignalPolicy to Init.
+//
+// Example:
+//
+//	orch := orchestrator.New()
+//	defer orch.Shutdown(true)
+//
+//	localMgr, err := orch.NewLocalManager("api-server", "handlers")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	localMgr.Go("worker", worker)
+func New() *Orchestrator {
+	globalManager := types.NewIsolatedGlobalManager()
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics.NewTreeCollector(globalManager))
+	globalManager.MetricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+
+	// Lifecycle counters of the tree go to the metrics package like those of the default tree
+	globalManager.Subscribe(metrics.EventRecorder{})
+
+	return &Orchestrator{
+		GlobalGoroutineManagerInterface: global.NewGlobalManagerFor(globalManager),
+		globalManager:                   globalManager,
+		registry:                        registry,
+	}
+}
+
+// Default returns an orchestrator for the package-level singleton, initializing it with opts if needed
+// (see global.Init). Its registry is the registry of the metrics package.
+func Default(opts ...types.ConfigOption) (*Orchestrator, error) {
+	manager := global.NewGlobalManager()
+	globalManager, err := manager.Init(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Orchestrator{
+		GlobalGoroutineManagerInterface: manager,
+		globalManager:                   globalManager,
+		registry:                        metrics.GetRegistry(),
+	}, nil
+}
+
+// GlobalManager returns the root of the orchestrator's manager tree
+func (O *Orchestrator) GlobalManager() *types.GlobalManager {
+	return O.globalManager
+}
+
+// NewLocalManager creates (or gets) the app manager appName and its local manager localName
+//
+// Example:
+//
+//	localMgr, err := orch.NewLocalManager("api-server", "handlers")
+func (O *Orchestrator) NewLocalManager(appName, localName string) (interfaces.LocalGoroutineManagerInterface, error) {
+	appManager := app.NewAppManagerFor(O.globalManager, appName)
+	if _, err := appManager.CreateApp(); err != nil {
+		return nil, err
+	}
+	return appManager.NewLocalManager(localName)
+}
+
+// Snapshot returns the live manager tree of the orchestrator (see types.TakeSnapshot)
+func (O *Orchestrator) Snapshot(filter types.SnapshotFilter) *types.ManagerSnapshot {
+	return O.globalManager.TakeSnapshot(filter)
+}
+
+// Registry returns the orchestrator's Prometheus registry. For orchestrators created by New it holds
+// a metrics.TreeCollector for the tree; register your own collectors with it as needed.
+func (O *Orchestrator) Registry() *prometheus.Registry {
+	return O.registry
+}
+
+// MetricsHandler returns an HTTP handler serving the orchestrator's registry
+//
+// Example:
+//
+//	mux.Handle("/metrics", orch.MetricsHandler())
+func (O *Orchestrator) MetricsHandler() http.Handler {
+	if O.globalManager.MetricsHandler != nil {
+		return O.globalManager.MetricsHandler
+	}
+	return promhttp.HandlerFor(O.registry, promhttp.HandlerOpts{})
+}
+
+// DebugHandler returns an HTTP handler serving the orchestrator's live manager tree as JSON
+// (see metrics.GetDebugHandler)
+//
+// Example:
+//
+//	mux.Handle("/debug/goroutines", orch.DebugHandler())
+func (O *Orchestrator) DebugHandler() http.Handler {
+	return metrics.GetDebugHandlerFor(O.globalManager)
+}
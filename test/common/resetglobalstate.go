@@ -9,11 +9,6 @@ import (
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
 )
 
-var (
-	once sync.Once
-	lock sync.RWMutex
-)
-
 // Tests/Common/resetglobalstate.go
 func ResetGlobalState() {
 	// Stop the metrics server properly
@@ -22,13 +17,40 @@ func ResetGlobalState() {
 		defer cancel()
 		metrics.StopMetricsServer(ctx)
 	}
+	// The collector reads types.Global - stop it before resetting
+	metrics.StopCollector()
 
 	// Wait longer for all goroutines to finish
 	time.Sleep(300 * time.Millisecond)
 
-	// Acquire write lock before resetting
-	lock.Lock()
-	once = sync.Once{}
-	types.Global = nil
-	lock.Unlock()
+	// Cancel the routines a test left behind and wait for them, so that none of them still reads
+	// types.Global when it is reset below
+	if previous, err := types.GetGlobalManager(); err == nil {
+		for _, appManager := range previous.GetAppManagers() {
+			for _, localManager := range appManager.GetLocalManagers() {
+				if _, cancel := localManager.GetLocalContext(); cancel != nil {
+					cancel()
+				}
+				waitTimeout(localManager.GetLocalWaitGroup(), time.Second)
+			}
+		}
+	}
+
+	types.ResetGlobalManager()
+}
+
+// waitTimeout waits for wg, giving up after timeout
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) {
+	if wg == nil {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
 }
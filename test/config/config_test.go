@@ -0,0 +1,305 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/config"
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/orchestrator"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+const yamlConfig = `
+shutdown_timeout: 30s
+max_routines: 1000
+apps:
+  ingestion:
+    max_routines: 200
+    shutdown_timeout: 60s
+    defaults:
+      timeout: 5m
+    locals:
+      workers:
+        max_routines: 50
+functions:
+  fetch:
+    timeout: 15s
+    max_concurrent: 10
+`
+
+// writeFile writes content to name in a temporary directory and returns its path
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	return path
+}
+
+// TestLoad_YAMLAndJSON tests that YAML and JSON files map onto the same config
+func TestLoad_YAMLAndJSON(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestLoad_YAMLAndJSON ===")
+
+	fromYAML, err := config.Load(writeFile(t, "orchestrator.yaml", yamlConfig))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	fromJSON, err := config.Load(writeFile(t, "orchestrator.json", `{
+		"shutdown_timeout": "30s",
+		"max_routines": 1000,
+		"apps": {"ingestion": {
+			"max_routines": 200,
+			"shutdown_timeout": "60s",
+			"defaults": {"timeout": "5m"},
+			"locals": {"workers": {"max_routines": 50}}
+		}},
+		"functions": {"fetch": {"timeout": "15s", "max_concurrent": 10}}
+	}`))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if changes := fromYAML.Diff(fromJSON); len(changes) != 0 {
+		t.Errorf("Expected the YAML and JSON configs to match, got %v", changes)
+	}
+	if fromYAML.ShutdownTimeout != 30*time.Second || fromYAML.MaxRoutines != 1000 {
+		t.Errorf("Expected shutdown timeout 30s and max routines 1000, got %+v", fromYAML)
+	}
+	if quota := fromYAML.Apps["ingestion"].Locals["workers"].MaxRoutines; quota != 50 {
+		t.Errorf("Expected local quota 50, got %d", quota)
+	}
+	if app := fromYAML.Apps["ingestion"]; app.ShutdownTimeout != time.Minute || app.Defaults.Timeout != 5*time.Minute {
+		t.Errorf("Expected app shutdown timeout 1m and default timeout 5m, got %+v", app)
+	}
+	if fetch := fromYAML.Functions["fetch"]; fetch.Timeout != 15*time.Second || fetch.MaxConcurrent != 10 {
+		t.Errorf("Expected fetch defaults 15s/10, got %+v", fetch)
+	}
+	// Missing settings keep their defaults
+	if fromYAML.Metrics || fromYAML.MetricsInterval != types.DefaultMetricsInterval {
+		t.Errorf("Expected metrics to stay disabled with the default interval, got %+v", fromYAML)
+	}
+
+	fmt.Println("✓ YAML and JSON files load the same config")
+}
+
+// TestLoad_Invalid tests that invalid files are rejected with ErrInvalidConfig
+func TestLoad_Invalid(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestLoad_Invalid ===")
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"unknown setting", "unknown.yaml", "max_goroutines: 10\n"},
+		{"bad duration", "duration.yaml", "shutdown_timeout: soon\n"},
+		{"negative quota", "quota.yaml", "apps:\n  ingestion:\n    max_routines: -1\n"},
+		{"negative default", "default.yaml", "apps:\n  ingestion:\n    defaults:\n      max_concurrent: -1\n"},
+		{"zero shutdown timeout", "timeout.json", `{"shutdown_timeout": "0s"}`},
+		{"duration as number", "number.json", `{"shutdown_timeout": 30}`},
+	}
+	for _, tt := range tests {
+		if _, err := config.Load(writeFile(t, tt.file, tt.content)); !errors.Is(err, goerrors.ErrInvalidConfig) {
+			t.Errorf("%s: expected ErrInvalidConfig, got %v", tt.name, err)
+		}
+	}
+	if _, err := config.Load(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a missing file error, got %v", err)
+	}
+
+	fmt.Println("✓ Invalid files are rejected")
+}
+
+// TestLoad_Env tests that GRO_* environment variables override the file
+func TestLoad_Env(t *testing.T) {
+	fmt.Println("\n=== TestLoad_Env ===")
+
+	t.Setenv(config.EnvMaxRoutines, "500")
+	t.Setenv(config.EnvAppMaxRoutines, "ingestion=100, http=20")
+	t.Setenv(config.EnvLocalMaxRoutines, "http/handlers=5")
+	t.Setenv(config.EnvFunctionTimeout, "index=1m")
+	t.Setenv(config.EnvLocalShutdownTimeout, "ingestion/workers=30s")
+
+	cfg, err := config.Load(writeFile(t, "orchestrator.yaml", yamlConfig))
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.MaxRoutines != 500 || cfg.ShutdownTimeout != 30*time.Second {
+		t.Errorf("Expected max routines 500 from the env and shutdown timeout 30s from the file, got %+v", cfg)
+	}
+	if cfg.Apps["ingestion"].MaxRoutines != 100 || cfg.Apps["ingestion"].Locals["workers"].MaxRoutines != 50 {
+		t.Errorf("Expected the env to override only the ingestion quota, got %+v", cfg.Apps["ingestion"])
+	}
+	if timeout := cfg.Apps["ingestion"].Locals["workers"].ShutdownTimeout; timeout != 30*time.Second {
+		t.Errorf("Expected the env to set the workers shutdown timeout, got %v", timeout)
+	}
+	if cfg.Apps["http"].MaxRoutines != 20 || cfg.Apps["http"].Locals["handlers"].MaxRoutines != 5 {
+		t.Errorf("Expected the env to add the http quotas, got %+v", cfg.Apps["http"])
+	}
+	if cfg.Functions["index"].Timeout != time.Minute || cfg.Functions["fetch"].Timeout != 15*time.Second {
+		t.Errorf("Expected function defaults from the file and the env, got %+v", cfg.Functions)
+	}
+
+	// Without a file
+	t.Setenv(config.EnvMetricsAddr, ":9090")
+	if cfg, err := config.Load(""); err != nil || !cfg.Metrics || cfg.MetricsURL != ":9090" {
+		t.Errorf("Expected metrics on :9090 from the env, got %+v (%v)", cfg, err)
+	}
+
+	t.Setenv(config.EnvLocalMaxRoutines, "handlers=5")
+	if _, err := config.Load(""); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig for a local without app, got %v", err)
+	}
+
+	fmt.Println("✓ Environment variables override the file")
+}
+
+// TestConfig_QuotasAndFunctionDefaults tests that configured quotas apply to managers created later
+// and function defaults apply to Go() calls without the matching options
+func TestConfig_QuotasAndFunctionDefaults(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestConfig_QuotasAndFunctionDefaults ===")
+
+	orch := orchestrator.New()
+	defer orch.Shutdown(false)
+	if _, err := orch.Init(
+		global.WithAppQuota("quota-app", 20),
+		global.WithLocalQuota("quota-app", "quota-local", 4),
+		global.WithFunctionDefaults("fetch", types.FunctionConfig{Timeout: 50 * time.Millisecond}),
+	); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	localMgr, err := orch.NewLocalManager("quota-app", "quota-local")
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	appManager, err := orch.GlobalManager().GetAppManager("quota-app")
+	if err != nil {
+		t.Fatalf("GetAppManager() failed: %v", err)
+	}
+	if appManager.GetMaxRoutines() != 20 || localMgr.GetMaxRoutines() != 4 {
+		t.Errorf("Expected quotas 20 and 4, got %d and %d", appManager.GetMaxRoutines(), localMgr.GetMaxRoutines())
+	}
+
+	deadlines := make(chan bool, 2)
+	worker := func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		deadlines <- ok
+		return nil
+	}
+	if err := localMgr.Go("fetch", worker); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if err := localMgr.Go("index", worker); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	withDeadline := 0
+	for i := 0; i < 2; i++ {
+		if <-deadlines {
+			withDeadline++
+		}
+	}
+	if withDeadline != 1 {
+		t.Errorf("Expected only the fetch routine to get the default timeout, got %d routines with a deadline", withDeadline)
+	}
+
+	fmt.Println("✓ Quotas and function defaults apply")
+}
+
+// TestWatch tests that a watched file applies its changes live and that invalid content is
+// rejected while the previous config stays in effect
+func TestWatch(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestWatch ===")
+
+	path := writeFile(t, "orchestrator.yaml", "apps:\n  watch-app:\n    max_routines: 10\n")
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	orch := orchestrator.New()
+	defer orch.Shutdown(false)
+	if _, err := orch.Init(global.WithConfig(cfg)); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	if _, err := orch.NewLocalManager("watch-app", "watch-local"); err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+
+	type reload struct {
+		changes []types.ConfigChange
+		err     error
+	}
+	reloads := make(chan reload, 4)
+	watcher, err := config.Watch(path, orch, config.WatchConfig{
+		PollInterval: 10 * time.Millisecond,
+		OnReload: func(changes []types.ConfigChange, err error) {
+			reloads <- reload{changes, err}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Watch() failed: %v", err)
+	}
+	defer watcher.Stop()
+
+	waitReload := func() reload {
+		t.Helper()
+		select {
+		case r := <-reloads:
+			return r
+		case <-time.After(5 * time.Second):
+			t.Fatal("Watcher did not reload the file")
+			return reload{}
+		}
+	}
+	appQuota := func() int {
+		appManager, err := orch.GlobalManager().GetAppManager("watch-app")
+		if err != nil {
+			t.Fatalf("GetAppManager() failed: %v", err)
+		}
+		return appManager.GetMaxRoutines()
+	}
+
+	if err := os.WriteFile(path, []byte("apps:\n  watch-app:\n    max_routines: 25\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	r := waitReload()
+	if r.err != nil {
+		t.Fatalf("Expected the reload to succeed, got %v", r.err)
+	}
+	if len(r.changes) != 1 || r.changes[0].Setting != "apps.watch-app.max_routines" {
+		t.Errorf("Expected apps.watch-app.max_routines to change, got %v", r.changes)
+	}
+	if quota := appQuota(); quota != 25 {
+		t.Errorf("Expected the app quota to be 25, got %d", quota)
+	}
+
+	// Invalid content is rejected and reported, the previous config stays in effect
+	if err := os.WriteFile(path, []byte("apps:\n  watch-app:\n    max_routines: -5\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	if r := waitReload(); !errors.Is(r.err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig, got %v", r.err)
+	}
+	if quota := appQuota(); quota != 25 {
+		t.Errorf("Expected the app quota to stay 25, got %d", quota)
+	}
+
+	// A forced reload applies the file even if it did not change
+	if _, err := watcher.Reload(); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected Reload() to report ErrInvalidConfig, got %v", err)
+	}
+
+	fmt.Println("✓ Watched file changes apply live")
+}
@@ -0,0 +1,207 @@
+package leak_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/leak"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/app"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/orchestrator"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// setupLocal creates an app and local manager
+func setupLocal(t *testing.T, appName, localName string) *local.LocalManagerStruct {
+	t.Helper()
+	if _, err := app.NewAppManager(appName).CreateApp(); err != nil {
+		t.Fatalf("CreateApp() failed: %v", err)
+	}
+	localMgr := local.NewLocalManager(appName, localName)
+	if _, err := localMgr.CreateLocal(localName); err != nil {
+		t.Fatalf("CreateLocal() failed: %v", err)
+	}
+	return localMgr.(*local.LocalManagerStruct)
+}
+
+// TestLeak_TrackedRoutines tests that running routines are reported as tracked, not as leaks
+func TestLeak_TrackedRoutines(t *testing.T) {
+	fmt.Println("\n=== TestLeak_TrackedRoutines ===")
+	common.ResetGlobalState()
+
+	localMgr := setupLocal(t, "leak-tracked", "workers")
+	var id string
+	started := make(chan struct{})
+	localMgr.Go("worker", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return nil
+	}, local.AddToWaitGroup("worker"), local.CaptureRoutineID(&id))
+	<-started
+
+	report, err := leak.Detect("leak-tracked")
+	if err != nil {
+		t.Fatalf("Detect() failed: %v", err)
+	}
+	if len(report.Tracked) != 1 || report.Tracked[0].RoutineID != id || report.Tracked[0].FunctionName != "worker" {
+		t.Errorf("Expected routine %s to be tracked, got %s", id, report)
+	}
+	if len(report.Leaks()) != 0 {
+		t.Errorf("Expected no leaks, got %s", report)
+	}
+
+	if err := localMgr.Shutdown(true); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+	leak.VerifyNoLeaks(t, leak.ForApp("leak-tracked"))
+
+	fmt.Println("✓ Tracked routines are not leaks")
+}
+
+// TestLeak_UntrackedAndLingering tests that worker-started and post-shutdown goroutines are reported
+func TestLeak_UntrackedAndLingering(t *testing.T) {
+	fmt.Println("\n=== TestLeak_UntrackedAndLingering ===")
+	common.ResetGlobalState()
+
+	previousTimeout := types.ShutdownTimeout
+	types.ShutdownTimeout = 100 * time.Millisecond
+	defer func() { types.ShutdownTimeout = previousTimeout }()
+
+	localMgr := setupLocal(t, "leak-app", "workers")
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	var spawnerID, stubbornID string
+	localMgr.Go("spawner", func(ctx context.Context) error {
+		// Started behind the orchestrator's back
+		go func() {
+			<-release
+		}()
+		close(started)
+		<-ctx.Done()
+		return nil
+	}, local.CaptureRoutineID(&spawnerID))
+	localMgr.Go("stubborn", func(ctx context.Context) error {
+		// Ignores cancellation
+		<-release
+		return nil
+	}, local.AddToWaitGroup("stubborn"), local.CaptureRoutineID(&stubbornID))
+	<-started
+
+	if err := localMgr.Shutdown(true); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+
+	report, err := leak.Detect("leak-app")
+	if err != nil {
+		t.Fatalf("Detect() failed: %v", err)
+	}
+	if len(report.Untracked) != 1 || report.Untracked[0].RoutineID != spawnerID || report.Untracked[0].FunctionName != "spawner" {
+		t.Errorf("Expected one untracked goroutine started by %s, got %s", spawnerID, report)
+	}
+	if len(report.Lingering) != 1 || report.Lingering[0].RoutineID != stubbornID {
+		t.Errorf("Expected stubborn routine %s to linger after shutdown, got %s", stubbornID, report)
+	}
+	if len(report.Tracked) != 0 {
+		t.Errorf("Expected no tracked routines after shutdown, got %s", report)
+	}
+
+	close(release)
+	leak.VerifyNoLeaks(t, leak.ForApp("leak-app"))
+
+	fmt.Println("✓ Untracked and lingering goroutines are reported")
+}
+
+// recordingT records VerifyNoLeaks failures
+type recordingT struct {
+	errors []string
+}
+
+func (RT *recordingT) Helper() {}
+
+func (RT *recordingT) Errorf(format string, args ...interface{}) {
+	RT.errors = append(RT.errors, fmt.Sprintf(format, args...))
+}
+
+// TestLeak_VerifyNoLeaksFails tests that VerifyNoLeaks fails while goroutines are still running
+func TestLeak_VerifyNoLeaksFails(t *testing.T) {
+	fmt.Println("\n=== TestLeak_VerifyNoLeaksFails ===")
+	common.ResetGlobalState()
+
+	localMgr := setupLocal(t, "leak-fail", "workers")
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	localMgr.Go("worker", func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+
+	recorder := &recordingT{}
+	leak.VerifyNoLeaks(recorder, leak.ForApp("leak-fail"), leak.WithTimeout(50*time.Millisecond))
+	if len(recorder.errors) == 0 {
+		t.Error("Expected VerifyNoLeaks to report the running routine")
+	}
+
+	fmt.Println("✓ VerifyNoLeaks reports running goroutines")
+}
+
+// TestLeak_IsolatedTrees tests that the routines of orchestrators are looked up in their own tree,
+// also when two trees use the same app name
+func TestLeak_IsolatedTrees(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestLeak_IsolatedTrees ===")
+
+	first := orchestrator.New()
+	second := orchestrator.New()
+	defer second.Shutdown(false)
+	localMgr, err := first.NewLocalManager("leak-tree", "workers")
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	if _, err := second.NewLocalManager("leak-tree", "workers"); err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+
+	var id string
+	started := make(chan struct{})
+	if err := localMgr.Go("worker", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return nil
+	}, local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	<-started
+
+	report, err := leak.DetectTree(first.GlobalManager(), "leak-tree")
+	if err != nil {
+		t.Fatalf("DetectTree() failed: %v", err)
+	}
+	if len(report.Tracked) != 1 || report.Tracked[0].RoutineID != id || report.Tracked[0].TreeID != first.GlobalManager().TreeID() {
+		t.Errorf("Expected routine %s to be tracked in its tree, got %s", id, report)
+	}
+	if len(report.Leaks()) != 0 {
+		t.Errorf("Expected no leaks, got %s", report)
+	}
+
+	report, err = leak.DetectTree(second.GlobalManager(), "leak-tree")
+	if err != nil {
+		t.Fatalf("DetectTree() failed: %v", err)
+	}
+	if len(report.Tracked) != 0 || len(report.Leaks()) != 0 {
+		t.Errorf("Expected no goroutines of the second tree, got %s", report)
+	}
+
+	if err := first.Shutdown(true); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+	leak.VerifyNoLeaks(t, leak.ForTree(first.GlobalManager()))
+
+	fmt.Println("✓ Routines are looked up in their own tree")
+}
@@ -0,0 +1,215 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/app"
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+)
+
+// setupAdmissionLocal creates an app and local manager for admission tests
+func setupAdmissionLocal(t *testing.T, appName, localName string) interfaces.LocalGoroutineManagerInterface {
+	t.Helper()
+	appMgr := app.NewAppManager(appName)
+	if _, err := appMgr.CreateApp(); err != nil {
+		t.Fatalf("CreateApp() failed: %v", err)
+	}
+	localMgr := local.NewLocalManager(appName, localName)
+	if _, err := localMgr.CreateLocal(localName); err != nil {
+		t.Fatalf("CreateLocal() failed: %v", err)
+	}
+	return localMgr
+}
+
+// blockingWorker returns a worker that runs until release is closed or its context is cancelled
+func blockingWorker(release <-chan struct{}) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	}
+}
+
+// TestAdmission_GlobalLimitRejects tests that Go() rejects once the global MaxRoutines limit is reached
+func TestAdmission_GlobalLimitRejects(t *testing.T) {
+	fmt.Println("\n=== TestAdmission_GlobalLimitRejects ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "admission-app", "admission-local")
+	if _, err := global.NewGlobalManager().UpdateMetadata(global.SET_MAX_ROUTINES, 2); err != nil {
+		t.Fatalf("UpdateMetadata() failed: %v", err)
+	}
+	defer global.NewGlobalManager().UpdateMetadata(global.SET_MAX_ROUTINES, 0)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	for i := 0; i < 2; i++ {
+		if err := localMgr.Go("limited-worker", blockingWorker(release)); err != nil {
+			t.Fatalf("Go() %d failed: %v", i, err)
+		}
+	}
+
+	err := localMgr.Go("limited-worker", blockingWorker(release))
+	if !errors.Is(err, goerrors.ErrMaxRoutinesReached) {
+		t.Fatalf("Expected ErrMaxRoutinesReached, got %v", err)
+	}
+	if count := localMgr.GetGoroutineCount(); count != 2 {
+		t.Errorf("Expected 2 tracked routines, got %d", count)
+	}
+
+	fmt.Println("✓ Global limit rejects new routines")
+}
+
+// TestAdmission_WaitForSlot tests that AdmissionWait blocks until a routine completes
+func TestAdmission_WaitForSlot(t *testing.T) {
+	fmt.Println("\n=== TestAdmission_WaitForSlot ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "admission-app", "admission-local")
+	if err := localMgr.SetMaxRoutines(1); err != nil {
+		t.Fatalf("SetMaxRoutines() failed: %v", err)
+	}
+
+	release := make(chan struct{})
+	if err := localMgr.Go("first", blockingWorker(release)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	// Free the slot after a short delay
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+	}()
+
+	start := time.Now()
+	done := make(chan struct{})
+	err := localMgr.Go("second", func(ctx context.Context) error {
+		close(done)
+		return nil
+	}, local.WithAdmissionPolicy(local.AdmissionWait))
+	if err != nil {
+		t.Fatalf("Go() with AdmissionWait failed: %v", err)
+	}
+	if waited := time.Since(start); waited < 50*time.Millisecond {
+		t.Errorf("Expected Go() to wait for a slot, returned after %v", waited)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Second routine never ran")
+	}
+
+	fmt.Println("✓ AdmissionWait waits for a free slot")
+}
+
+// TestAdmission_WaitTimeout tests that WithAdmissionTimeout gives up after the deadline
+func TestAdmission_WaitTimeout(t *testing.T) {
+	fmt.Println("\n=== TestAdmission_WaitTimeout ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "admission-app", "admission-local")
+	if err := localMgr.SetMaxRoutines(1); err != nil {
+		t.Fatalf("SetMaxRoutines() failed: %v", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	if err := localMgr.Go("first", blockingWorker(release)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	start := time.Now()
+	err := localMgr.Go("second", blockingWorker(release), local.WithAdmissionTimeout(100*time.Millisecond))
+	if !errors.Is(err, goerrors.ErrAdmissionTimeout) {
+		t.Fatalf("Expected ErrAdmissionTimeout, got %v", err)
+	}
+	if waited := time.Since(start); waited < 100*time.Millisecond {
+		t.Errorf("Expected Go() to wait for the deadline, returned after %v", waited)
+	}
+
+	fmt.Println("✓ WithAdmissionTimeout gives up after the deadline")
+}
+
+// TestAdmission_AppQuotaIsolation tests that an app quota does not affect other apps
+func TestAdmission_AppQuotaIsolation(t *testing.T) {
+	fmt.Println("\n=== TestAdmission_AppQuotaIsolation ===")
+	common.ResetGlobalState()
+
+	noisyLocal := setupAdmissionLocal(t, "noisy-app", "noisy-local")
+	quietLocal := setupAdmissionLocal(t, "quiet-app", "quiet-local")
+
+	noisyApp := app.NewAppManager("noisy-app")
+	if err := noisyApp.SetMaxRoutines(3); err != nil {
+		t.Fatalf("SetMaxRoutines() failed: %v", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+
+	for i := 0; i < 3; i++ {
+		if err := noisyLocal.Go("noisy", blockingWorker(release)); err != nil {
+			t.Fatalf("Go() %d failed: %v", i, err)
+		}
+	}
+	if err := noisyLocal.Go("noisy", blockingWorker(release)); !errors.Is(err, goerrors.ErrMaxRoutinesReached) {
+		t.Fatalf("Expected ErrMaxRoutinesReached for noisy app, got %v", err)
+	}
+
+	// The quiet app is unaffected by the noisy app's quota
+	for i := 0; i < 5; i++ {
+		if err := quietLocal.Go("quiet", blockingWorker(release)); err != nil {
+			t.Fatalf("Go() on quiet app failed: %v", err)
+		}
+	}
+
+	fmt.Println("✓ App quotas are isolated")
+}
+
+// TestAdmission_TreeIsolation tests that isolated trees admit routines through their own gates
+func TestAdmission_TreeIsolation(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestAdmission_TreeIsolation ===")
+
+	held, _ := setupOrchestratorLocal(t, "admission-app", "admission-local")
+	free, freeLocal := setupOrchestratorLocal(t, "admission-app", "admission-local")
+	if held.GlobalManager().Admission() == free.GlobalManager().Admission() {
+		t.Fatal("Expected every isolated tree to have its own admission gate")
+	}
+	if err := freeLocal.SetMaxRoutines(5); err != nil {
+		t.Fatalf("SetMaxRoutines() failed: %v", err)
+	}
+
+	// A gate held in one tree does not block admission in another
+	gate := held.GlobalManager().Admission()
+	gate.Lock()
+	defer gate.Unlock()
+
+	release := make(chan struct{})
+	defer close(release)
+	admitted := make(chan error, 1)
+	go func() {
+		admitted <- freeLocal.Go("limited-worker", blockingWorker(release))
+	}()
+	select {
+	case err := <-admitted:
+		if err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Go() waited for the admission gate of another tree")
+	}
+
+	fmt.Println("✓ Isolated trees have their own admission gates")
+}
@@ -0,0 +1,133 @@
+package manager_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+)
+
+// TestMaxConcurrent_Rejects tests that WithMaxConcurrent rejects once n routines of the function run
+func TestMaxConcurrent_Rejects(t *testing.T) {
+	fmt.Println("\n=== TestMaxConcurrent_Rejects ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "concurrency-app", "concurrency-local")
+	release := make(chan struct{})
+	defer close(release)
+
+	for i := 0; i < 2; i++ {
+		if err := localMgr.Go("reindex", blockingWorker(release), local.WithMaxConcurrent(2)); err != nil {
+			t.Fatalf("Go() %d failed: %v", i, err)
+		}
+	}
+
+	err := localMgr.Go("reindex", blockingWorker(release), local.WithMaxConcurrent(2))
+	if !errors.Is(err, goerrors.ErrMaxConcurrentReached) {
+		t.Fatalf("Expected ErrMaxConcurrentReached, got %v", err)
+	}
+
+	// Other function names are not limited
+	if err := localMgr.Go("compaction", blockingWorker(release), local.WithMaxConcurrent(2)); err != nil {
+		t.Errorf("Go() of another function failed: %v", err)
+	}
+	if count := localMgr.GetFunctionGoroutineCount("reindex"); count != 2 {
+		t.Errorf("Expected 2 reindex routines, got %d", count)
+	}
+
+	fmt.Println("✓ Per-function limit rejects new routines")
+}
+
+// TestMaxConcurrent_Waits tests that WithMaxConcurrent waits for a slot with the wait policy
+func TestMaxConcurrent_Waits(t *testing.T) {
+	fmt.Println("\n=== TestMaxConcurrent_Waits ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "concurrency-app", "concurrency-local")
+	first := make(chan struct{})
+	if err := localMgr.Go("compaction", blockingWorker(first), local.WithMaxConcurrent(1)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	// Timing out while the first routine runs
+	err := localMgr.Go("compaction", blockingWorker(first), local.WithMaxConcurrent(1), local.WithAdmissionTimeout(50*time.Millisecond))
+	if !errors.Is(err, goerrors.ErrAdmissionTimeout) {
+		t.Fatalf("Expected ErrAdmissionTimeout, got %v", err)
+	}
+
+	// Admitted once the first routine finishes
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(first)
+	}()
+	second := make(chan struct{})
+	defer close(second)
+	start := time.Now()
+	if err := localMgr.Go("compaction", blockingWorker(second), local.WithMaxConcurrent(1), local.WithAdmissionPolicy(local.AdmissionWait)); err != nil {
+		t.Fatalf("Go() with AdmissionWait failed: %v", err)
+	}
+	if waited := time.Since(start); waited < 40*time.Millisecond {
+		t.Errorf("Go() should wait for the first routine, waited %v", waited)
+	}
+	if count := localMgr.GetFunctionGoroutineCount("compaction"); count != 1 {
+		t.Errorf("Expected 1 compaction routine, got %d", count)
+	}
+
+	fmt.Println("✓ Per-function limit waits for a slot")
+}
+
+// TestSingleflight_Joins tests that WithSingleflight joins a running routine with the same key
+func TestSingleflight_Joins(t *testing.T) {
+	fmt.Println("\n=== TestSingleflight_Joins ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "concurrency-app", "concurrency-local")
+	release := make(chan struct{})
+
+	var firstID, joinedID, otherID string
+	if err := localMgr.Go("refresh-token", blockingWorker(release), local.WithSingleflight("user-1"), local.CaptureRoutineID(&firstID)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if err := localMgr.Go("refresh-token", blockingWorker(release), local.WithSingleflight("user-1"), local.CaptureRoutineID(&joinedID)); err != nil {
+		t.Fatalf("Joining Go() failed: %v", err)
+	}
+	if joinedID != firstID {
+		t.Errorf("Expected to join routine %s, got %s", firstID, joinedID)
+	}
+	if err := localMgr.Go("refresh-token", blockingWorker(release), local.WithSingleflight("user-2"), local.CaptureRoutineID(&otherID)); err != nil {
+		t.Fatalf("Go() with another key failed: %v", err)
+	}
+	if otherID == firstID {
+		t.Error("Another key should spawn its own routine")
+	}
+	if count := localMgr.GetFunctionGoroutineCount("refresh-token"); count != 2 {
+		t.Errorf("Expected 2 refresh-token routines, got %d", count)
+	}
+
+	// The joined routine's result is shared
+	close(release)
+	if _, err := localMgr.WaitForRoutineResult(joinedID, time.Second); err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+
+	// Once finished and removed from the local manager, the key spawns again
+	deadline := time.Now().Add(time.Second)
+	for localMgr.GetFunctionGoroutineCount("refresh-token") > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	var nextID string
+	rerun := make(chan struct{})
+	defer close(rerun)
+	if err := localMgr.Go("refresh-token", blockingWorker(rerun), local.WithSingleflight("user-1"), local.CaptureRoutineID(&nextID)); err != nil {
+		t.Fatalf("Go() after completion failed: %v", err)
+	}
+	if nextID == firstID {
+		t.Error("A finished routine should not be joined")
+	}
+
+	fmt.Println("✓ Singleflight joins running routines")
+}
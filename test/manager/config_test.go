@@ -0,0 +1,298 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/orchestrator"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// TestConfig_Validate tests that Validate rejects invalid settings with ErrInvalidConfig
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestConfig_Validate ===")
+
+	if err := types.DefaultConfig().Validate(); err != nil {
+		t.Fatalf("Expected the default config to be valid, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opts []types.ConfigOption
+	}{
+		{"zero shutdown timeout", []types.ConfigOption{global.WithShutdownTimeout(0)}},
+		{"negative max routines", []types.ConfigOption{global.WithMaxRoutines(-1)}},
+		{"negative metrics interval", []types.ConfigOption{global.WithMetrics(":9090", -time.Second)}},
+		{"shutdown signal reloads", []types.ConfigOption{global.WithSignalPolicy(types.SignalPolicy{
+			OnReload:      func(os.Signal) {},
+			ReloadSignals: []os.Signal{syscall.SIGTERM},
+		})}},
+		{"reload signals without hook", []types.ConfigOption{global.WithSignalPolicy(types.SignalPolicy{
+			ReloadSignals: []os.Signal{syscall.SIGHUP},
+		})}},
+	}
+	for _, tt := range tests {
+		if err := types.NewConfig(tt.opts...).Validate(); !errors.Is(err, goerrors.ErrInvalidConfig) {
+			t.Errorf("%s: expected ErrInvalidConfig, got %v", tt.name, err)
+		}
+	}
+
+	// Every problem is reported at once
+	err := types.NewConfig(global.WithShutdownTimeout(-1), global.WithMaxRoutines(-1)).Validate()
+	if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 2 {
+		t.Errorf("Expected 2 joined errors, got %v", err)
+	}
+
+	// A disabled policy installs no handlers, so its signals are not checked
+	disabled := types.NewConfig(global.WithSignalPolicy(types.SignalPolicy{Disabled: true, ReloadSignals: []os.Signal{syscall.SIGHUP}}))
+	if err := disabled.Validate(); err != nil {
+		t.Errorf("Expected a disabled policy to be valid, got %v", err)
+	}
+
+	fmt.Println("✓ Validate rejects invalid configs")
+}
+
+// TestReconfigure tests that Init options and Reconfigure apply and report only the changed settings
+func TestReconfigure(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestReconfigure ===")
+
+	orch, _ := setupOrchestratorLocal(t, "config-app", "config-local")
+	defer orch.Shutdown(false)
+
+	if _, err := orch.Init(global.WithMaxRoutines(5)); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	cfg, err := orch.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig() failed: %v", err)
+	}
+	if cfg.MaxRoutines != 5 || cfg.ShutdownTimeout != types.DefaultShutdownTimeout {
+		t.Errorf("Expected max routines 5 and the default shutdown timeout, got %+v", cfg)
+	}
+	if cfg.Signals != nil {
+		t.Errorf("Expected no signal policy on an isolated tree, got %+v", cfg.Signals)
+	}
+
+	// Nothing changed
+	changes, err := orch.Reconfigure(cfg)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v (%v)", changes, err)
+	}
+
+	cfg.MaxRoutines = 7
+	changes, err = orch.Reconfigure(cfg)
+	if err != nil {
+		t.Fatalf("Reconfigure() failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Setting != types.ConfigMaxRoutines || changes[0].Old != 5 || changes[0].New != 7 {
+		t.Errorf("Expected max_routines: 5 -> 7, got %v", changes)
+	}
+	if metadata, _ := orch.GetMetadata(); metadata.GetMaxRoutines() != 7 {
+		t.Errorf("Expected the metadata to hold max routines 7, got %d", metadata.GetMaxRoutines())
+	}
+
+	// An invalid config changes nothing
+	cfg.MaxRoutines = 9
+	cfg.MetricsInterval = 0
+	if _, err := orch.Reconfigure(cfg); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig, got %v", err)
+	}
+	if cfg, _ := orch.GetConfig(); cfg.MaxRoutines != 7 {
+		t.Errorf("Expected max routines to stay 7, got %d", cfg.MaxRoutines)
+	}
+	if _, err := orch.Init(global.WithMaxRoutines(-3)); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected Init() to reject an invalid option, got %v", err)
+	}
+
+	// The legacy flags are validated too
+	if _, err := orch.UpdateMetadata(global.SET_MAX_ROUTINES, -1); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected UpdateMetadata() to reject a negative limit, got %v", err)
+	}
+
+	fmt.Println("✓ Reconfigure applies and reports the changed settings")
+}
+
+// startSignalOrchestrator returns an orchestrator handling signals according to policy, with one
+// routine that exits when cancelled and, if stubborn, one that ignores cancellation until release
+func startSignalOrchestrator(t *testing.T, policy types.SignalPolicy, release chan struct{}, stubborn bool) *orchestrator.Orchestrator {
+	t.Helper()
+	orch, localMgr := setupOrchestratorLocal(t, "signal-app", "signal-local")
+	if err := localMgr.Go("cooperative", blockingWorker(release)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if stubborn {
+		if err := localMgr.Go("stubborn", func(ctx context.Context) error {
+			<-release
+			return nil
+		}); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	}
+	if _, err := orch.Init(global.WithSignalPolicy(policy)); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	t.Cleanup(orch.GlobalManager().StopSignals)
+	return orch
+}
+
+// sendSignal sends sig to the test process
+func sendSignal(t *testing.T, sig syscall.Signal) {
+	t.Helper()
+	if err := syscall.Kill(os.Getpid(), sig); err != nil {
+		t.Fatalf("Kill() failed: %v", err)
+	}
+}
+
+// TestSignalPolicy_GracefulShutdown tests that a shutdown signal runs a graceful shutdown and
+// reports a clean exit code
+func TestSignalPolicy_GracefulShutdown(t *testing.T) {
+	fmt.Println("\n=== TestSignalPolicy_GracefulShutdown ===")
+
+	release := make(chan struct{})
+	defer close(release)
+	exitCodes := make(chan int, 1)
+	orch := startSignalOrchestrator(t, types.SignalPolicy{
+		Signals: []os.Signal{syscall.SIGUSR1},
+		OnExit:  func(code int) { exitCodes <- code },
+	}, release, false)
+
+	sendSignal(t, syscall.SIGUSR1)
+	select {
+	case code := <-exitCodes:
+		if code != types.ExitCodeClean {
+			t.Errorf("Expected exit code %d, got %d", types.ExitCodeClean, code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Signal did not shut down the orchestrator")
+	}
+	if state := orch.GetState(); state != types.StateStopped {
+		t.Errorf("Expected the global manager to be stopped, got %s", state)
+	}
+
+	fmt.Println("✓ Shutdown signal runs a graceful shutdown")
+}
+
+// TestSignalPolicy_SecondSignalForces tests that a second signal forces the shutdown while the
+// graceful shutdown still waits for a stuck routine
+func TestSignalPolicy_SecondSignalForces(t *testing.T) {
+	fmt.Println("\n=== TestSignalPolicy_SecondSignalForces ===")
+
+	release := make(chan struct{})
+	defer close(release)
+	exitCodes := make(chan int, 1)
+	startSignalOrchestrator(t, types.SignalPolicy{
+		Signals: []os.Signal{syscall.SIGUSR1},
+		OnExit:  func(code int) { exitCodes <- code },
+	}, release, true)
+
+	sendSignal(t, syscall.SIGUSR1)
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case code := <-exitCodes:
+		t.Fatalf("Expected the graceful shutdown to wait for the stuck routine, exited with %d", code)
+	default:
+	}
+
+	sendSignal(t, syscall.SIGUSR1)
+	select {
+	case code := <-exitCodes:
+		if code != types.ExitCodeUnclean {
+			t.Errorf("Expected exit code %d, got %d", types.ExitCodeUnclean, code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Second signal did not force the shutdown")
+	}
+
+	fmt.Println("✓ Second signal forces the shutdown")
+}
+
+// TestSignalPolicy_SecondSignalCancelsGraceful tests that a second signal cancels the graceful
+// shutdown and waits for it before forcing, so the two shutdowns never overlap
+func TestSignalPolicy_SecondSignalCancelsGraceful(t *testing.T) {
+	fmt.Println("\n=== TestSignalPolicy_SecondSignalCancelsGraceful ===")
+
+	var gracefulRunning atomic.Bool
+	started := make(chan struct{})
+	overlapped := make(chan bool, 1)
+	exitCodes := make(chan int, 1)
+
+	globalManager := types.NewIsolatedGlobalManager()
+	globalManager.HandleSignals(types.SignalPolicy{
+		Signals: []os.Signal{syscall.SIGUSR1},
+		OnExit:  func(code int) { exitCodes <- code },
+	}, func(ctx context.Context, safe bool) (*types.ShutdownReport, error) {
+		if safe {
+			gracefulRunning.Store(true)
+			defer gracefulRunning.Store(false)
+			close(started)
+			<-ctx.Done()
+			// Still busy after the cancellation, like a shutdown collecting its reports
+			time.Sleep(100 * time.Millisecond)
+			return nil, ctx.Err()
+		}
+		overlapped <- gracefulRunning.Load()
+		return nil, nil
+	})
+	t.Cleanup(globalManager.StopSignals)
+
+	sendSignal(t, syscall.SIGUSR1)
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("First signal did not start the graceful shutdown")
+	}
+
+	sendSignal(t, syscall.SIGUSR1)
+	select {
+	case code := <-exitCodes:
+		if code != types.ExitCodeUnclean {
+			t.Errorf("Expected exit code %d, got %d", types.ExitCodeUnclean, code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Second signal did not force the shutdown")
+	}
+	if <-overlapped {
+		t.Error("Expected the forced shutdown to start after the graceful shutdown returned")
+	}
+
+	fmt.Println("✓ Second signal cancels the graceful shutdown before forcing")
+}
+
+// TestSignalPolicy_Reload tests that a reload signal calls OnReload without shutting down
+func TestSignalPolicy_Reload(t *testing.T) {
+	fmt.Println("\n=== TestSignalPolicy_Reload ===")
+
+	release := make(chan struct{})
+	defer close(release)
+	reloads := make(chan os.Signal, 1)
+	orch := startSignalOrchestrator(t, types.SignalPolicy{
+		Signals:       []os.Signal{syscall.SIGUSR1},
+		OnReload:      func(sig os.Signal) { reloads <- sig },
+		ReloadSignals: []os.Signal{syscall.SIGUSR2},
+	}, release, false)
+
+	sendSignal(t, syscall.SIGUSR2)
+	select {
+	case sig := <-reloads:
+		if sig != syscall.SIGUSR2 {
+			t.Errorf("Expected SIGUSR2, got %s", sig)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Reload signal did not call OnReload")
+	}
+	if state := orch.GetState(); state != types.StateRunning {
+		t.Errorf("Expected the global manager to keep running, got %s", state)
+	}
+
+	fmt.Println("✓ Reload signal calls OnReload")
+}
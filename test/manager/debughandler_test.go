@@ -0,0 +1,101 @@
+package manager_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// getSnapshot requests the debug handler with the given query and decodes the tree
+func getSnapshot(t *testing.T, query string) *types.ManagerSnapshot {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	metrics.GetDebugHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, metrics.DebugPath+query, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var snapshot types.ManagerSnapshot
+	if err := json.Unmarshal(recorder.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("Response is not a snapshot: %v", err)
+	}
+	return &snapshot
+}
+
+// TestDebugHandler_Tree tests that the debug handler serves the live tree with routine details and filters
+func TestDebugHandler_Tree(t *testing.T) {
+	fmt.Println("\n=== TestDebugHandler_Tree ===")
+	common.ResetGlobalState()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	apiLocal := setupAdmissionLocal(t, "api", "handlers")
+	var oldID string
+	apiLocal.Go("stuck", blockingWorker(release), local.AddToWaitGroup("stuck"), local.CaptureRoutineID(&oldID))
+	time.Sleep(50 * time.Millisecond)
+	apiLocal.Go("fresh", blockingWorker(release), local.WithTimeout(time.Hour))
+
+	jobsLocal := setupAdmissionLocal(t, "jobs", "workers")
+	jobsLocal.Go("job", blockingWorker(release))
+
+	snapshot := getSnapshot(t, "")
+	if snapshot.RoutineCount != 3 || len(snapshot.Apps) != 2 || snapshot.Apps[0].Name != "api" {
+		t.Fatalf("Unexpected tree: %+v", snapshot)
+	}
+	routines := snapshot.Apps[0].Locals[0].Routines
+	if len(routines) != 2 || routines[0].ID != oldID {
+		t.Fatalf("Expected 2 api routines, oldest first, got %+v", routines)
+	}
+	if routines[0].WaitGroup != "stuck" || routines[0].Deadline != nil || routines[0].Cancelled {
+		t.Errorf("Unexpected details for the stuck routine: %+v", routines[0])
+	}
+	if routines[1].Deadline == nil || time.Until(*routines[1].Deadline) < 59*time.Minute {
+		t.Errorf("Expected the timeout deadline of the fresh routine, got %v", routines[1].Deadline)
+	}
+
+	// Filters
+	if filtered := getSnapshot(t, "?app=jobs"); len(filtered.Apps) != 1 || filtered.RoutineCount != 1 {
+		t.Errorf("app filter: unexpected tree %+v", filtered)
+	}
+	if filtered := getSnapshot(t, "?function=fresh"); filtered.RoutineCount != 1 {
+		t.Errorf("function filter: expected 1 routine, got %d", filtered.RoutineCount)
+	}
+	if filtered := getSnapshot(t, "?min_age=40ms&app=api"); filtered.RoutineCount != 1 || filtered.Apps[0].Locals[0].Routines[0].ID != oldID {
+		t.Errorf("min_age filter: expected only the stuck routine, got %+v", filtered)
+	}
+
+	recorder := httptest.NewRecorder()
+	metrics.GetDebugHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, metrics.DebugPath+"?min_age=soon", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid min_age, got %d", recorder.Code)
+	}
+
+	// Cancelled routines that have not exited yet are flagged
+	ignoring := make(chan struct{})
+	var cancelledID string
+	apiLocal.Go("ignoring", func(ctx context.Context) error {
+		<-ignoring
+		return nil
+	}, local.CaptureRoutineID(&cancelledID))
+	routine, err := apiLocal.GetRoutine(cancelledID)
+	if err != nil {
+		t.Fatalf("GetRoutine() failed: %v", err)
+	}
+	routine.GetCancel()()
+	filtered := getSnapshot(t, "?function=ignoring")
+	close(ignoring)
+	if filtered.RoutineCount != 1 || !filtered.Apps[0].Locals[0].Routines[0].Cancelled {
+		t.Errorf("Expected a cancelled routine, got %+v", filtered)
+	}
+
+	fmt.Println("✓ Debug handler serves the live tree")
+}
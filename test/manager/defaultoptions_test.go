@@ -0,0 +1,117 @@
+package manager_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// TestDefaultOptions_Merge tests that the default options of the local manager and of the function
+// name apply below the options passed to Go()
+func TestDefaultOptions_Merge(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestDefaultOptions_Merge ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "options-app", "options-local")
+	defer orch.Shutdown(false)
+
+	if err := localMgr.SetDefaultOptions(local.WithTimeout(time.Minute), local.AddToWaitGroup("all")); err != nil {
+		t.Fatalf("SetDefaultOptions() failed: %v", err)
+	}
+	if err := localMgr.SetFunctionOptions("handler", local.WithTimeout(time.Second), local.WithMaxConcurrent(2)); err != nil {
+		t.Fatalf("SetFunctionOptions() failed: %v", err)
+	}
+
+	want := types.RoutineOptions{Timeout: types.ReportDuration(time.Minute), PanicRecovery: true, WaitGroup: "all"}
+	if options := localMgr.GetEffectiveOptions("plain"); !reflect.DeepEqual(options, want) {
+		t.Errorf("Expected %+v, got %+v", want, options)
+	}
+	want = types.RoutineOptions{Timeout: types.ReportDuration(time.Second), PanicRecovery: true, WaitGroup: "all", MaxConcurrent: 2}
+	if options := localMgr.GetEffectiveOptions("handler"); !reflect.DeepEqual(options, want) {
+		t.Errorf("Expected %+v, got %+v", want, options)
+	}
+	if options := localMgr.GetEffectiveOptions("handler", local.WithTimeout(time.Hour)); options.Timeout != types.ReportDuration(time.Hour) {
+		t.Errorf("Expected the call-site timeout of 1h, got %v", time.Duration(options.Timeout))
+	}
+
+	// Running routines record their options
+	release := make(chan struct{})
+	defer close(release)
+	var id string
+	if err := localMgr.Go("handler", blockingWorker(release), local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	routine, err := localMgr.GetRoutine(id)
+	if err != nil {
+		t.Fatalf("GetRoutine() failed: %v", err)
+	}
+	if options := routine.GetOptions(); options.Timeout != types.ReportDuration(time.Second) || options.WaitGroup != "all" {
+		t.Errorf("Expected the function options on the routine, got %+v", options)
+	}
+
+	// Removing the function options leaves the local manager's
+	if err := localMgr.SetFunctionOptions("handler"); err != nil {
+		t.Fatalf("SetFunctionOptions() failed: %v", err)
+	}
+	if options := localMgr.GetEffectiveOptions("handler"); options.Timeout != types.ReportDuration(time.Minute) || options.MaxConcurrent != 0 {
+		t.Errorf("Expected only the default options, got %+v", options)
+	}
+
+	if err := localMgr.SetDefaultOptions(local.WithTimeout(time.Minute), "timeout"); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig for an option of another type, got %v", err)
+	}
+	if options := localMgr.GetEffectiveOptions("plain"); options.WaitGroup != "all" {
+		t.Errorf("Expected a rejected call to keep the default options, got %+v", options)
+	}
+
+	fmt.Println("✓ Default options apply below call-site options")
+}
+
+// TestDefaultOptions_Audit tests that snapshots show the effective options of running routines,
+// including configured defaults, so routines without a timeout can be found
+func TestDefaultOptions_Audit(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestDefaultOptions_Audit ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "audit-app", "audit-local")
+	defer orch.Shutdown(false)
+	if _, err := orch.Init(global.WithFunctionDefaults("fetch", types.FunctionConfig{Timeout: time.Hour})); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	// "fetch" is timed by the configured defaults, "sync" by its function options
+	if err := localMgr.SetFunctionOptions("sync", local.WithTimeout(time.Minute)); err != nil {
+		t.Fatalf("SetFunctionOptions() failed: %v", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	for _, functionName := range []string{"fetch", "sync", "untimed"} {
+		if err := localMgr.Go(functionName, blockingWorker(release)); err != nil {
+			t.Fatalf("Go(%s) failed: %v", functionName, err)
+		}
+	}
+
+	untimed := make(map[string]bool)
+	snapshot := orch.GlobalManager().TakeSnapshot(types.SnapshotFilter{AppName: "audit-app"})
+	for _, app := range snapshot.Apps {
+		for _, localSnapshot := range app.Locals {
+			for _, routine := range localSnapshot.Routines {
+				if routine.Options.Timeout == 0 {
+					untimed[routine.FunctionName] = true
+				}
+			}
+		}
+	}
+	if len(untimed) != 1 || !untimed["untimed"] {
+		t.Errorf("Expected only the untimed routine without a timeout, got %v", untimed)
+	}
+
+	fmt.Println("✓ Snapshots show the effective options")
+}
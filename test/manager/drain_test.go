@@ -0,0 +1,272 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// drainRecorder records the progress reported during a drain
+type drainRecorder struct {
+	mu       sync.Mutex
+	progress []types.DrainProgress
+}
+
+func (DR *drainRecorder) record(progress types.DrainProgress) {
+	DR.mu.Lock()
+	defer DR.mu.Unlock()
+	DR.progress = append(DR.progress, progress)
+}
+
+// phases returns the first progress reported for each phase
+func (DR *drainRecorder) phases() map[string]types.DrainProgress {
+	DR.mu.Lock()
+	defer DR.mu.Unlock()
+	phases := make(map[string]types.DrainProgress)
+	for _, progress := range DR.progress {
+		if _, ok := phases[progress.Phase]; !ok {
+			phases[progress.Phase] = progress
+		}
+	}
+	return phases
+}
+
+// TestDrain_WaitsForRoutines tests that Drain rejects new routines but lets the running ones finish
+// with their contexts intact
+func TestDrain_WaitsForRoutines(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestDrain_WaitsForRoutines ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "drain-app", "drain-local")
+
+	var cancelled atomic.Int32
+	for i := 0; i < 3; i++ {
+		if err := localMgr.Go("upload", func(ctx context.Context) error {
+			time.Sleep(150 * time.Millisecond)
+			if ctx.Err() != nil {
+				cancelled.Add(1)
+			}
+			return nil
+		}); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	}
+
+	recorder := &drainRecorder{}
+	type result struct {
+		report *types.ShutdownReport
+		err    error
+	}
+	drained := make(chan result, 1)
+	go func() {
+		report, err := localMgr.Drain(types.DrainConfig{
+			WaitTimeout:      2 * time.Second,
+			ProgressInterval: 50 * time.Millisecond,
+			OnProgress:       recorder.record,
+		})
+		drained <- result{report, err}
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	if state := localMgr.GetState(); state != types.StateDraining {
+		t.Errorf("Expected the local manager to be draining, got %s", state)
+	}
+	var closed *goerrors.ManagerClosedError
+	if err := localMgr.Go("late", func(ctx context.Context) error { return nil }); !errors.As(err, &closed) || closed.State != "draining" {
+		t.Errorf("Expected Go() to be rejected while draining, got %v", err)
+	}
+
+	res := <-drained
+	if res.err != nil {
+		t.Fatalf("Drain() failed: %v", res.err)
+	}
+	if n := cancelled.Load(); n != 0 {
+		t.Errorf("Expected no routine context to be cancelled, got %d", n)
+	}
+	if graceful, forceCancelled, stillRunning := res.report.Totals(); graceful != 3 || forceCancelled != 0 || stillRunning != 0 {
+		t.Errorf("Expected 3 graceful routines, got %d graceful, %d force-cancelled, %d still running", graceful, forceCancelled, stillRunning)
+	}
+	if state := localMgr.GetState(); state != types.StateStopped {
+		t.Errorf("Expected the local manager to be stopped, got %s", state)
+	}
+
+	phases := recorder.phases()
+	wait, ok := phases[types.DrainPhaseWait]
+	if !ok || wait.Remaining != 3 || wait.Functions["upload"] != 3 || wait.LocalName != "drain-local" {
+		t.Errorf("Expected wait progress with 3 upload routines, got %+v", wait)
+	}
+	if _, ok := phases[types.DrainPhaseCancel]; ok {
+		t.Error("Expected no cancel phase when every routine finished")
+	}
+
+	fmt.Println("✓ Drain lets running routines finish")
+}
+
+// TestDrain_Escalates tests that Drain cancels the routines still running after the wait deadline
+// and stops tracking the ones that ignore the cancellation
+func TestDrain_Escalates(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestDrain_Escalates ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "drain-app", "drain-local")
+	release := make(chan struct{})
+	defer close(release)
+
+	if err := localMgr.Go("quick", func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if err := localMgr.Go("cancellable", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if err := localMgr.Go("stubborn", func(ctx context.Context) error {
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	recorder := &drainRecorder{}
+	report, err := localMgr.Drain(types.DrainConfig{
+		WaitTimeout:   100 * time.Millisecond,
+		CancelTimeout: 100 * time.Millisecond,
+		OnProgress:    recorder.record,
+	})
+	if err != nil {
+		t.Fatalf("Drain() failed: %v", err)
+	}
+
+	if graceful, forceCancelled, stillRunning := report.Totals(); graceful != 1 || forceCancelled != 1 || stillRunning != 1 {
+		t.Errorf("Expected 1 graceful, 1 force-cancelled and 1 still running routine, got %d, %d, %d", graceful, forceCancelled, stillRunning)
+	}
+	phaseNames := make(map[string]bool)
+	for _, phase := range report.Phases {
+		phaseNames[phase.Name] = true
+	}
+	for _, name := range []string{"drain_wait", "drain_cancel", "force_remove"} {
+		if !phaseNames[name] {
+			t.Errorf("Expected phase %s in the report, got %+v", name, report.Phases)
+		}
+	}
+
+	phases := recorder.phases()
+	if cancel := phases[types.DrainPhaseCancel]; cancel.Remaining != 2 || cancel.Functions["cancellable"] != 1 || cancel.Functions["stubborn"] != 1 {
+		t.Errorf("Expected cancel progress with the cancellable and stubborn routines, got %+v", cancel)
+	}
+	if force := phases[types.DrainPhaseForce]; force.Remaining != 1 || force.Functions["stubborn"] != 1 {
+		t.Errorf("Expected force progress with the stubborn routine, got %+v", force)
+	}
+	if count := localMgr.GetGoroutineCount(); count != 0 {
+		t.Errorf("Expected the stubborn routine to be removed, got %d routines", count)
+	}
+
+	fmt.Println("✓ Drain escalates to cancel and force removal")
+}
+
+// TestDrain_ScheduleRunFinishes tests that Drain stops a schedule without cancelling the run in progress
+func TestDrain_ScheduleRunFinishes(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestDrain_ScheduleRunFinishes ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "drain-app", "drain-local")
+
+	started := make(chan struct{}, 1)
+	var runs, cancelled atomic.Int32
+	schedule, err := localMgr.NewSchedule("sync", types.ScheduleConfig{Interval: 10 * time.Millisecond}, func(ctx context.Context) error {
+		runs.Add(1)
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		time.Sleep(100 * time.Millisecond)
+		if ctx.Err() != nil {
+			cancelled.Add(1)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewSchedule() failed: %v", err)
+	}
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("Schedule did not run")
+	}
+	report, err := localMgr.Drain(types.DrainConfig{WaitTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("Drain() failed: %v", err)
+	}
+
+	select {
+	case <-schedule.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Schedule did not stop")
+	}
+	if n := cancelled.Load(); n != 0 {
+		t.Errorf("Expected the run in progress not to be cancelled, got %d cancelled runs", n)
+	}
+	if !report.Clean() {
+		t.Errorf("Expected a clean report, got %s", report)
+	}
+	ranBefore := runs.Load()
+	time.Sleep(50 * time.Millisecond)
+	if ran := runs.Load(); ran != ranBefore {
+		t.Errorf("Expected no runs after the drain, got %d more", ran-ranBefore)
+	}
+
+	fmt.Println("✓ Drain lets schedule runs in progress finish")
+}
+
+// TestDrain_Global tests that draining an orchestrator drains every app and local manager
+func TestDrain_Global(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestDrain_Global ===")
+
+	orch, firstLocal := setupOrchestratorLocal(t, "drain-app", "drain-local")
+	secondLocal, err := orch.NewLocalManager("other-app", "other-local")
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	for _, localMgr := range []interfaces.LocalGoroutineManagerInterface{firstLocal, secondLocal} {
+		if err := localMgr.Go("job", func(ctx context.Context) error {
+			time.Sleep(50 * time.Millisecond)
+			return ctx.Err()
+		}); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	}
+
+	report, err := orch.Drain(types.DrainConfig{WaitTimeout: time.Second})
+	if err != nil {
+		t.Fatalf("Drain() failed: %v", err)
+	}
+	if len(report.Children) != 2 {
+		t.Errorf("Expected 2 app reports, got %d", len(report.Children))
+	}
+	if graceful, _, _ := report.Totals(); graceful != 2 || !report.Clean() {
+		t.Errorf("Expected 2 graceful routines, got %s", report)
+	}
+	if state := orch.GetState(); state != types.StateStopped {
+		t.Errorf("Expected the global manager to be stopped, got %s", state)
+	}
+	if state := secondLocal.GetState(); state != types.StateStopped {
+		t.Errorf("Expected the local manager to be stopped, got %s", state)
+	}
+
+	fmt.Println("✓ Global Drain drains every app manager")
+}
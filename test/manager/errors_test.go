@@ -0,0 +1,163 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/app"
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+)
+
+// TestErrors_AdmissionRejected tests that admission errors carry the limit and wrap the sentinels
+func TestErrors_AdmissionRejected(t *testing.T) {
+	fmt.Println("\n=== TestErrors_AdmissionRejected ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "errors-app", "errors-local")
+	release := make(chan struct{})
+	defer close(release)
+	if err := localMgr.Go("import", blockingWorker(release), local.WithMaxConcurrent(1)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	err := localMgr.Go("import", blockingWorker(release), local.WithMaxConcurrent(1))
+	var rejected *goerrors.AdmissionRejectedError
+	if !errors.As(err, &rejected) {
+		t.Fatalf("Expected AdmissionRejectedError, got %v", err)
+	}
+	if !errors.Is(err, goerrors.ErrMaxConcurrentReached) {
+		t.Errorf("Expected the error to wrap ErrMaxConcurrentReached")
+	}
+	if rejected.App != "errors-app" || rejected.Local != "errors-local" || rejected.Function != "import" ||
+		rejected.Reason != goerrors.ReasonMaxConcurrent || rejected.Scope != goerrors.ScopeFunction || rejected.Limit != 1 {
+		t.Errorf("Unexpected rejection details: %+v", rejected)
+	}
+
+	// A timed out wait keeps the limit details
+	err = localMgr.Go("import", blockingWorker(release), local.WithMaxConcurrent(1), local.WithAdmissionTimeout(20*time.Millisecond))
+	if !errors.As(err, &rejected) || !errors.Is(err, goerrors.ErrAdmissionTimeout) || rejected.Reason != goerrors.ReasonAdmissionTimeout {
+		t.Errorf("Expected an admission timeout rejection, got %v", err)
+	}
+
+	// The manager's context being cancelled while waiting is a ManagerClosedError
+	_, isolatedMgr := setupOrchestratorLocal(t, "errors-app", "errors-local")
+	isolatedMgr.SetMaxRoutines(1)
+	// Ignores cancellation, so its slot stays taken
+	if err := isolatedMgr.Go("stubborn", func(ctx context.Context) error {
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- isolatedMgr.Go("report", blockingWorker(release), local.WithAdmissionPolicy(local.AdmissionWait))
+	}()
+	time.Sleep(50 * time.Millisecond)
+	localManager, err := isolatedMgr.Get()
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	localManager.Cancel()
+	select {
+	case err = <-waitErr:
+	case <-time.After(time.Second):
+		t.Fatal("Go() kept waiting after the manager shut down")
+	}
+	var closed *goerrors.ManagerClosedError
+	if !errors.As(err, &closed) || !errors.Is(err, goerrors.ErrManagerClosed) || !errors.Is(err, goerrors.ErrMaxRoutinesReached) {
+		t.Errorf("Expected a ManagerClosedError wrapping the limit, got %v", err)
+	}
+
+	fmt.Println("✓ Admission errors are structured")
+}
+
+// TestErrors_ShutdownTimeout tests that ShutdownFunction timeouts are ShutdownTimeoutErrors
+func TestErrors_ShutdownTimeout(t *testing.T) {
+	fmt.Println("\n=== TestErrors_ShutdownTimeout ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "errors-app", "errors-local")
+	release := make(chan struct{})
+	defer close(release)
+	for i := 0; i < 2; i++ {
+		// Ignores cancellation until released
+		if err := localMgr.Go("stubborn", func(ctx context.Context) error {
+			<-release
+			return nil
+		}, local.AddToWaitGroup("stubborn")); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	}
+
+	err := localMgr.ShutdownFunction("stubborn", 50*time.Millisecond)
+	var timeoutErr *goerrors.ShutdownTimeoutError
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, goerrors.ErrShutdownTimeout) {
+		t.Fatalf("Expected ShutdownTimeoutError, got %v", err)
+	}
+	if timeoutErr.Function != "stubborn" || timeoutErr.Local != "errors-local" || timeoutErr.Remaining != 2 || timeoutErr.Timeout != 50*time.Millisecond {
+		t.Errorf("Unexpected timeout details: %+v", timeoutErr)
+	}
+
+	fmt.Println("✓ Shutdown timeouts are structured")
+}
+
+// TestErrors_RoutinePanic tests that a recovered panic is a RoutinePanicError with the routine's details
+func TestErrors_RoutinePanic(t *testing.T) {
+	fmt.Println("\n=== TestErrors_RoutinePanic ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "errors-app", "errors-local")
+	var id string
+	if err := localMgr.Go("fragile", func(ctx context.Context) error {
+		panic("boom")
+	}, local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	result, err := localMgr.WaitForRoutineResult(id, time.Second)
+	if err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+	var panicErr *goerrors.RoutinePanicError
+	if !errors.As(result.Err, &panicErr) {
+		t.Fatalf("Expected RoutinePanicError, got %v", result.Err)
+	}
+	if panicErr.Function != "fragile" || panicErr.RoutineID != id || panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+		t.Errorf("Unexpected panic details: %+v", panicErr)
+	}
+
+	fmt.Println("✓ Panics are structured")
+}
+
+// TestErrors_NotFound tests lookups of missing managers and routines, and creating an existing local manager
+func TestErrors_NotFound(t *testing.T) {
+	fmt.Println("\n=== TestErrors_NotFound ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "errors-app", "errors-local")
+
+	_, err := localMgr.GetRoutine("missing-id")
+	var notFound *goerrors.NotFoundError
+	if !errors.As(err, &notFound) || !errors.Is(err, goerrors.ErrRoutineNotFound) || notFound.Name != "missing-id" {
+		t.Errorf("Expected NotFoundError for the routine, got %v", err)
+	}
+
+	_, err = app.NewAppManager("missing-app").NewLocalManager("handlers")
+	if !errors.As(err, &notFound) || !errors.Is(err, goerrors.ErrAppManagerNotFound) || notFound.Name != "missing-app" {
+		t.Errorf("Expected NotFoundError for the app manager, got %v", err)
+	}
+
+	// Creating an existing local manager returns it without an error
+	existing, err := localMgr.CreateLocal("errors-local")
+	if err != nil || existing == nil {
+		t.Errorf("CreateLocal() of an existing local manager failed: %v", err)
+	}
+
+	fmt.Println("✓ Lookups return NotFoundErrors")
+}
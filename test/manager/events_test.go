@@ -0,0 +1,189 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// eventCollector is a subscriber that keeps every event it receives
+type eventCollector struct {
+	mu     sync.Mutex
+	events []types.Event
+}
+
+func (EC *eventCollector) OnEvent(event types.Event) {
+	EC.mu.Lock()
+	defer EC.mu.Unlock()
+	EC.events = append(EC.events, event)
+}
+
+// find returns the events of the given type, optionally filtered by routine ID
+func (EC *eventCollector) find(eventType types.EventType, routineID string) []types.Event {
+	EC.mu.Lock()
+	defer EC.mu.Unlock()
+	var found []types.Event
+	for _, event := range EC.events {
+		if event.Type == eventType && (routineID == "" || event.RoutineID == routineID) {
+			found = append(found, event)
+		}
+	}
+	return found
+}
+
+// TestEvents_RoutineLifecycle tests that routine outcomes are published as events
+func TestEvents_RoutineLifecycle(t *testing.T) {
+	fmt.Println("\n=== TestEvents_RoutineLifecycle ===")
+	common.ResetGlobalState()
+
+	globalMgr := global.NewGlobalManager()
+	if _, err := globalMgr.Init(); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	collector := &eventCollector{}
+	unsubscribe := globalMgr.Subscribe(collector)
+	defer unsubscribe()
+
+	localMgr := setupAdmissionLocal(t, "events-app", "events-local")
+
+	errWorker := errors.New("worker failed")
+	var okID, errID, panicID, timeoutID, cancelID string
+	localMgr.Go("ok", func(ctx context.Context) error { return nil }, local.CaptureRoutineID(&okID))
+	localMgr.Go("failing", func(ctx context.Context) error { return errWorker }, local.CaptureRoutineID(&errID))
+	localMgr.Go("panicking", func(ctx context.Context) error { panic("boom") }, local.CaptureRoutineID(&panicID))
+	localMgr.Go("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, local.WithTimeout(20*time.Millisecond), local.CaptureRoutineID(&timeoutID))
+	localMgr.Go("cancelled", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, local.CaptureRoutineID(&cancelID))
+	if err := localMgr.CancelRoutine(cancelID); err != nil {
+		t.Fatalf("CancelRoutine() failed: %v", err)
+	}
+
+	for _, id := range []string{okID, errID, panicID, timeoutID, cancelID} {
+		localMgr.WaitForRoutineResult(id, time.Second)
+	}
+
+	for _, id := range []string{okID, errID, panicID, timeoutID, cancelID} {
+		if len(collector.find(types.EventRoutineStarted, id)) != 1 {
+			t.Errorf("Expected one started event for %s", id)
+		}
+		completed := collector.find(types.EventRoutineCompleted, id)
+		if len(completed) != 1 {
+			t.Errorf("Expected one completed event for %s, got %d", id, len(completed))
+			continue
+		}
+		if completed[0].AppName != "events-app" || completed[0].LocalName != "events-local" {
+			t.Errorf("Completed event has wrong manager: %s/%s", completed[0].AppName, completed[0].LocalName)
+		}
+	}
+
+	if errored := collector.find(types.EventRoutineErrored, errID); len(errored) != 1 || !errors.Is(errored[0].Err, errWorker) {
+		t.Errorf("Expected an errored event carrying the worker error, got %v", errored)
+	}
+	if len(collector.find(types.EventRoutineErrored, okID)) != 0 {
+		t.Error("Successful routine should not publish an errored event")
+	}
+	if panicked := collector.find(types.EventRoutinePanicked, panicID); len(panicked) != 1 || panicked[0].FunctionName != "panicking" {
+		t.Errorf("Expected a panicked event, got %v", panicked)
+	}
+	if len(collector.find(types.EventRoutineTimedOut, timeoutID)) != 1 {
+		t.Error("Expected a timed out event")
+	}
+	if len(collector.find(types.EventRoutineCancelled, cancelID)) != 1 {
+		t.Error("Expected a cancelled event")
+	}
+
+	fmt.Println("✓ Routine lifecycle events are published")
+}
+
+// TestEvents_ManagerLifecycle tests that manager creation and shutdown are published as events
+func TestEvents_ManagerLifecycle(t *testing.T) {
+	fmt.Println("\n=== TestEvents_ManagerLifecycle ===")
+	common.ResetGlobalState()
+
+	globalMgr := global.NewGlobalManager()
+	if _, err := globalMgr.Init(); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	collector := &eventCollector{}
+	unsubscribe := globalMgr.Subscribe(collector)
+	defer unsubscribe()
+
+	previousTimeout := types.ShutdownTimeout
+	types.ShutdownTimeout = 100 * time.Millisecond
+	defer func() { types.ShutdownTimeout = previousTimeout }()
+
+	localMgr := setupAdmissionLocal(t, "events-app", "events-local")
+	release := make(chan struct{})
+	defer close(release)
+	localMgr.Go("stubborn", func(ctx context.Context) error {
+		<-release
+		return nil
+	}, local.AddToWaitGroup("stubborn"))
+
+	if _, err := localMgr.ShutdownWithReport(true); err != nil {
+		t.Fatalf("ShutdownWithReport() failed: %v", err)
+	}
+
+	if created := collector.find(types.EventAppCreated, ""); len(created) != 1 || created[0].AppName != "events-app" {
+		t.Errorf("Expected an app created event, got %v", created)
+	}
+	if created := collector.find(types.EventLocalCreated, ""); len(created) != 1 || created[0].LocalName != "events-local" {
+		t.Errorf("Expected a local created event, got %v", created)
+	}
+	if began := collector.find(types.EventShutdownBegan, ""); len(began) != 1 || began[0].Manager != types.EventManagerLocal {
+		t.Errorf("Expected a local shutdown began event, got %v", began)
+	}
+	if forced := collector.find(types.EventForceCancel, ""); len(forced) != 1 || forced[0].Count != 1 {
+		t.Errorf("Expected a force cancel event for 1 routine, got %v", forced)
+	}
+	if finished := collector.find(types.EventShutdownFinished, ""); len(finished) != 1 || !finished[0].Safe || finished[0].Duration <= 0 {
+		t.Errorf("Expected a safe shutdown finished event with a duration, got %v", finished)
+	}
+
+	// Unsubscribed collectors receive nothing
+	unsubscribe()
+	count := len(collector.find(types.EventRoutineStarted, ""))
+	localMgr2 := setupAdmissionLocal(t, "events-app", "events-local-2")
+	localMgr2.Go("after", func(ctx context.Context) error { return nil })
+	if len(collector.find(types.EventRoutineStarted, "")) != count {
+		t.Error("Unsubscribed collector should not receive events")
+	}
+
+	fmt.Println("✓ Manager lifecycle events are published")
+}
+
+// TestEvents_PanickingSubscriber tests that a panicking subscriber does not break routines
+func TestEvents_PanickingSubscriber(t *testing.T) {
+	fmt.Println("\n=== TestEvents_PanickingSubscriber ===")
+	common.ResetGlobalState()
+
+	unsubscribe := types.Subscribe(types.SubscriberFunc(func(event types.Event) {
+		panic("bad subscriber")
+	}))
+	defer unsubscribe()
+
+	localMgr := setupAdmissionLocal(t, "events-app", "events-local")
+	var id string
+	if err := localMgr.Go("worker", func(ctx context.Context) error { return nil }, local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	result, err := localMgr.WaitForRoutineResult(id, time.Second)
+	if err != nil || result.Err != nil {
+		t.Errorf("Routine should complete normally, got %v / %v", result, err)
+	}
+
+	fmt.Println("✓ Subscriber panics are contained")
+}
@@ -0,0 +1,197 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+)
+
+// TestGroup_FirstErrorCancels tests that the first member error cancels the others and is returned by Wait
+func TestGroup_FirstErrorCancels(t *testing.T) {
+	fmt.Println("\n=== TestGroup_FirstErrorCancels ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "group-app", "group-local")
+	group := localMgr.NewGroup(context.Background(), "fetchers")
+
+	errFetch := errors.New("fetch failed")
+	var cancelled atomic.Int32
+	for i := 0; i < 3; i++ {
+		if err := group.Go("fetch", func(ctx context.Context) error {
+			<-ctx.Done()
+			cancelled.Add(1)
+			return ctx.Err()
+		}); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	}
+
+	// Members are normal tracked routines
+	routines, err := localMgr.GetRoutinesByFunctionName("fetch")
+	if err != nil {
+		t.Fatalf("GetRoutinesByFunctionName() failed: %v", err)
+	}
+	if len(routines) != 3 {
+		t.Errorf("Expected 3 tracked members, got %d", len(routines))
+	}
+
+	if err := group.Go("fail", func(ctx context.Context) error {
+		return errFetch
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	if err := group.Wait(); !errors.Is(err, errFetch) {
+		t.Errorf("Expected Wait() to return the first error, got %v", err)
+	}
+	if cancelled.Load() != 3 {
+		t.Errorf("Expected 3 members cancelled, got %d", cancelled.Load())
+	}
+	if group.Context().Err() == nil {
+		t.Error("Group context should be cancelled after Wait()")
+	}
+
+	fmt.Println("✓ First error cancels the group")
+}
+
+// TestGroup_PanicIsError tests that a panicking member fails the group
+func TestGroup_PanicIsError(t *testing.T) {
+	fmt.Println("\n=== TestGroup_PanicIsError ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "group-app", "group-local")
+	group := localMgr.NewGroup(context.Background(), "panickers")
+
+	if err := group.Go("panic", func(ctx context.Context) error {
+		panic("boom")
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	if err := group.Wait(); !errors.Is(err, goerrors.ErrRoutinePanicked) {
+		t.Errorf("Expected ErrRoutinePanicked, got %v", err)
+	}
+
+	fmt.Println("✓ Panicking member fails the group")
+}
+
+// TestGroup_SetLimit tests that SetLimit bounds the number of active members
+func TestGroup_SetLimit(t *testing.T) {
+	fmt.Println("\n=== TestGroup_SetLimit ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "group-app", "group-local")
+	group := localMgr.NewGroup(context.Background(), "limited")
+	group.SetLimit(2)
+
+	var active, maxActive atomic.Int32
+	for i := 0; i < 6; i++ {
+		if err := group.Go("limited-worker", func(ctx context.Context) error {
+			n := active.Add(1)
+			for {
+				current := maxActive.Load()
+				if n <= current || maxActive.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			active.Add(-1)
+			return nil
+		}); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	}
+
+	if err := group.Wait(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if maxActive.Load() > 2 {
+		t.Errorf("Expected at most 2 active members, got %d", maxActive.Load())
+	}
+
+	fmt.Println("✓ Limit bounds active members")
+}
+
+// TestGroup_ShutdownCancelsMembers tests that ShutdownFunction cancels group members
+func TestGroup_ShutdownCancelsMembers(t *testing.T) {
+	fmt.Println("\n=== TestGroup_ShutdownCancelsMembers ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "group-app", "group-local")
+	group := localMgr.NewGroup(context.Background(), "pollers")
+
+	for i := 0; i < 2; i++ {
+		if err := group.Go("poll", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, local.AddToWaitGroup("poll")); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	}
+
+	if err := localMgr.ShutdownFunction("poll", time.Second); err != nil {
+		t.Fatalf("ShutdownFunction() failed: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- group.Wait() }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Group members were not cancelled by ShutdownFunction")
+	}
+
+	fmt.Println("✓ ShutdownFunction cancels group members")
+}
+
+// TestGroup_RejectsRestartAndSingleflight tests that members which would run more than once or join
+// a running routine are rejected, so Wait cannot hang or return early
+func TestGroup_RejectsRestartAndSingleflight(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestGroup_RejectsRestartAndSingleflight ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "group-options-app", "group-options-local")
+	defer orch.Shutdown(false)
+	group := localMgr.NewGroup(context.Background(), "members")
+	worker := func(ctx context.Context) error { return nil }
+
+	policy := local.RestartPolicy{Mode: local.RestartAlways, InitialBackoff: time.Millisecond}
+	if err := group.Go("supervised", worker, local.WithRestart(policy)); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig for WithRestart, got %v", err)
+	}
+	if err := localMgr.SetFunctionOptions("defaulted", local.WithRestart(policy)); err != nil {
+		t.Fatalf("SetFunctionOptions() failed: %v", err)
+	}
+	if err := group.Go("defaulted", worker); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig for a defaulted restart policy, got %v", err)
+	}
+	if err := group.Go("joined", worker, local.WithSingleflight("key")); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig for WithSingleflight, got %v", err)
+	}
+
+	if err := group.Go("once", worker); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- group.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected no group error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Wait() did not return")
+	}
+
+	fmt.Println("✓ Group members run exactly once")
+}
@@ -0,0 +1,239 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/app"
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// recordingInterceptor returns an interceptor appending name to calls before and after the rest of the chain
+func recordingInterceptor(mu *sync.Mutex, calls *[]string, name string) types.Interceptor {
+	return func(next types.WorkerFunc) types.WorkerFunc {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			*calls = append(*calls, name)
+			mu.Unlock()
+			err := next(ctx)
+			mu.Lock()
+			*calls = append(*calls, "/"+name)
+			mu.Unlock()
+			return err
+		}
+	}
+}
+
+// TestInterceptor_Order tests that interceptors of the global, app and local manager and of the
+// Go() call wrap the worker in that order and see the routine's metadata
+func TestInterceptor_Order(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestInterceptor_Order ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "intercept-app", "intercept-local")
+	defer orch.Shutdown(false)
+	appMgr := app.NewAppManagerFor(orch.GlobalManager(), "intercept-app")
+
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) types.Interceptor { return recordingInterceptor(&mu, &calls, name) }
+	if _, err := localMgr.Use(record("local")); err != nil {
+		t.Fatalf("Use() failed: %v", err)
+	}
+	if _, err := appMgr.Use(record("app")); err != nil {
+		t.Fatalf("Use() failed: %v", err)
+	}
+	if _, err := orch.Use(record("global-1"), record("global-2")); err != nil {
+		t.Fatalf("Use() failed: %v", err)
+	}
+
+	var info types.RoutineInfo
+	var id string
+	worker := func(ctx context.Context) error {
+		info, _ = types.RoutineInfoFromContext(ctx)
+		mu.Lock()
+		calls = append(calls, "worker")
+		mu.Unlock()
+		return nil
+	}
+	if err := localMgr.Go("intercepted", worker, local.WithInterceptors(record("call")), local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if _, err := localMgr.WaitForRoutineResult(id, 2*time.Second); err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+
+	want := "[global-1 global-2 app local call worker /call /local /app /global-2 /global-1]"
+	mu.Lock()
+	got := fmt.Sprint(calls)
+	mu.Unlock()
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	wantInfo := types.RoutineInfo{AppName: "intercept-app", LocalName: "intercept-local", FunctionName: "intercepted", RoutineID: id}
+	if info != wantInfo {
+		t.Errorf("Expected %+v, got %+v", wantInfo, info)
+	}
+
+	if _, err := localMgr.Use(nil); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig for a nil interceptor, got %v", err)
+	}
+
+	fmt.Println("✓ Interceptors wrap the worker from the global manager inwards")
+}
+
+// TestInterceptor_Remove tests that the function returned by Use removes only its own interceptors
+func TestInterceptor_Remove(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestInterceptor_Remove ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "remove-app", "remove-local")
+	defer orch.Shutdown(false)
+
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) types.Interceptor { return recordingInterceptor(&mu, &calls, name) }
+	removeFirst, err := localMgr.Use(record("first"))
+	if err != nil {
+		t.Fatalf("Use() failed: %v", err)
+	}
+	if _, err := localMgr.Use(record("second")); err != nil {
+		t.Fatalf("Use() failed: %v", err)
+	}
+	removeFirst()
+	// Removing twice is a no-op
+	removeFirst()
+
+	var id string
+	if err := localMgr.Go("intercepted", func(ctx context.Context) error { return nil }, local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if _, err := localMgr.WaitForRoutineResult(id, 2*time.Second); err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+
+	want := "[second /second]"
+	mu.Lock()
+	got := fmt.Sprint(calls)
+	mu.Unlock()
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	fmt.Println("✓ Use returns a function removing its interceptors")
+}
+
+// TestInterceptor_ContextAndErrors tests that interceptors can pass values to the worker and replace
+// its error
+func TestInterceptor_ContextAndErrors(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestInterceptor_ContextAndErrors ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "values-app", "values-local")
+	defer orch.Shutdown(false)
+
+	type requestIDKey struct{}
+	errWrapped := errors.New("reported")
+	_, err := localMgr.Use(func(next types.WorkerFunc) types.WorkerFunc {
+		return func(ctx context.Context) error {
+			if err := next(context.WithValue(ctx, requestIDKey{}, "req-42")); err != nil {
+				return fmt.Errorf("%w: %v", errWrapped, err)
+			}
+			return nil
+		}
+	})
+	if err != nil {
+		t.Fatalf("Use() failed: %v", err)
+	}
+
+	var requestID interface{}
+	var id string
+	worker := func(ctx context.Context) error {
+		requestID = ctx.Value(requestIDKey{})
+		return errors.New("failed")
+	}
+	if err := localMgr.Go("values", worker, local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	result, err := localMgr.WaitForRoutineResult(id, 2*time.Second)
+	if err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+	if requestID != "req-42" {
+		t.Errorf("Expected the worker to see req-42, got %v", requestID)
+	}
+	if !errors.Is(result.Err, errWrapped) {
+		t.Errorf("Expected the interceptor's error as the result, got %v", result.Err)
+	}
+
+	fmt.Println("✓ Interceptors pass context values and replace errors")
+}
+
+// TestInterceptor_PanicRecovery tests that the built-in panic recovery wraps the registered
+// interceptors, and that supervised restarts run the chain again
+func TestInterceptor_PanicRecovery(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestInterceptor_PanicRecovery ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "recover-app", "recover-local")
+	defer orch.Shutdown(false)
+
+	_, err := localMgr.Use(func(next types.WorkerFunc) types.WorkerFunc {
+		return func(ctx context.Context) error {
+			if info, _ := types.RoutineInfoFromContext(ctx); info.FunctionName == "broken" {
+				panic("interceptor failed")
+			}
+			return next(ctx)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Use() failed: %v", err)
+	}
+
+	var id string
+	if err := localMgr.Go("broken", func(ctx context.Context) error { return nil }, local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	result, err := localMgr.WaitForRoutineResult(id, 2*time.Second)
+	if err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+	if !result.Panicked || !errors.Is(result.Err, goerrors.ErrRoutinePanicked) {
+		t.Errorf("Expected the interceptor's panic to be recovered, got %+v", result)
+	}
+
+	// Each supervised run goes through the chain with its restart count
+	var mu sync.Mutex
+	var restarts []int64
+	countRuns := func(next types.WorkerFunc) types.WorkerFunc {
+		return func(ctx context.Context) error {
+			info, _ := types.RoutineInfoFromContext(ctx)
+			mu.Lock()
+			restarts = append(restarts, info.Restarts)
+			mu.Unlock()
+			return next(ctx)
+		}
+	}
+	worker := func(ctx context.Context) error { return errors.New("retry") }
+	policy := local.RestartPolicy{Mode: local.RestartOnFailure, InitialBackoff: time.Millisecond, MaxRestarts: 2}
+	if err := localMgr.Go("supervised", worker, local.WithRestart(policy), local.WithInterceptors(countRuns), local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if _, err := localMgr.WaitForRoutineResult(id, 2*time.Second); err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+	mu.Lock()
+	got := fmt.Sprint(restarts)
+	mu.Unlock()
+	if got != "[0 1 2]" {
+		t.Errorf("Expected runs with 0, 1 and 2 restarts, got %s", got)
+	}
+
+	fmt.Println("✓ Built-in panic recovery wraps the interceptors")
+}
@@ -0,0 +1,65 @@
+package manager_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"testing"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// TestLabels_WorkerContext tests that workers run under pprof labels, including user-defined ones
+func TestLabels_WorkerContext(t *testing.T) {
+	fmt.Println("\n=== TestLabels_WorkerContext ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "labels-app", "labels-local")
+
+	labels := make(chan map[string]string, 1)
+	release := make(chan struct{})
+	var id string
+	if err := localMgr.Go("consumer", func(ctx context.Context) error {
+		found := make(map[string]string)
+		pprof.ForLabels(ctx, func(key, value string) bool {
+			found[key] = value
+			return true
+		})
+		labels <- found
+		<-release
+		return nil
+	}, local.WithLabels(map[string]string{"topic": "orders", types.LabelApp: "spoofed"}), local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	found := <-labels
+	expected := map[string]string{
+		"topic":              "orders",
+		types.LabelApp:       "labels-app",
+		types.LabelLocal:     "labels-local",
+		types.LabelFunction:  "consumer",
+		types.LabelRoutineID: id,
+	}
+	for key, value := range expected {
+		if found[key] != value {
+			t.Errorf("Expected label %s=%q, got %q", key, value, found[key])
+		}
+	}
+
+	// The labels show up in goroutine profiles
+	var profile bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&profile, 1); err != nil {
+		t.Fatalf("WriteTo() failed: %v", err)
+	}
+	close(release)
+	if !strings.Contains(profile.String(), fmt.Sprintf("%q:%q", types.LabelRoutineID, id)) ||
+		!strings.Contains(profile.String(), `"topic":"orders"`) {
+		t.Error("Expected the routine's labels in the goroutine profile")
+	}
+
+	fmt.Println("✓ Workers run under pprof labels")
+}
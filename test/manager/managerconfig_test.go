@@ -0,0 +1,237 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/app"
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/orchestrator"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// TestManagerConfig_Inheritance tests that unset app and local settings inherit from the parent
+func TestManagerConfig_Inheritance(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestManagerConfig_Inheritance ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "inherit-app", "inherit-local")
+	defer orch.Shutdown(false)
+	appMgr := app.NewAppManagerFor(orch.GlobalManager(), "inherit-app")
+
+	if timeout := localMgr.GetEffectiveConfig().ShutdownTimeout; timeout != types.DefaultShutdownTimeout {
+		t.Errorf("Expected the global shutdown timeout, got %v", timeout)
+	}
+
+	if err := appMgr.SetShutdownTimeout(time.Minute); err != nil {
+		t.Fatalf("SetShutdownTimeout() failed: %v", err)
+	}
+	if timeout := localMgr.GetEffectiveConfig().ShutdownTimeout; timeout != time.Minute {
+		t.Errorf("Expected the local manager to inherit the app timeout, got %v", timeout)
+	}
+	if err := localMgr.SetShutdownTimeout(5 * time.Second); err != nil {
+		t.Fatalf("SetShutdownTimeout() failed: %v", err)
+	}
+	if timeout := localMgr.GetEffectiveConfig().ShutdownTimeout; timeout != 5*time.Second {
+		t.Errorf("Expected the local override, got %v", timeout)
+	}
+	if timeout := appMgr.GetEffectiveConfig().ShutdownTimeout; timeout != time.Minute {
+		t.Errorf("Expected the app timeout to stay 1m, got %v", timeout)
+	}
+
+	// Defaults are inherited field by field
+	if err := appMgr.SetRoutineDefaults(types.FunctionConfig{Timeout: time.Minute, MaxConcurrent: 3}); err != nil {
+		t.Fatalf("SetRoutineDefaults() failed: %v", err)
+	}
+	if err := localMgr.SetRoutineDefaults(types.FunctionConfig{MaxConcurrent: 1}); err != nil {
+		t.Fatalf("SetRoutineDefaults() failed: %v", err)
+	}
+	want := types.FunctionConfig{Timeout: time.Minute, MaxConcurrent: 1}
+	if defaults := localMgr.GetEffectiveConfig().Defaults; defaults != want {
+		t.Errorf("Expected defaults %+v, got %+v", want, defaults)
+	}
+
+	// 0 restores the inherited value
+	if err := appMgr.SetShutdownTimeout(0); err != nil {
+		t.Fatalf("SetShutdownTimeout() failed: %v", err)
+	}
+	if timeout := appMgr.GetEffectiveConfig().ShutdownTimeout; timeout != types.DefaultShutdownTimeout {
+		t.Errorf("Expected the global shutdown timeout again, got %v", timeout)
+	}
+
+	if err := localMgr.SetShutdownTimeout(-time.Second); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig for a negative timeout, got %v", err)
+	}
+	if err := appMgr.SetRoutineDefaults(types.FunctionConfig{MaxConcurrent: -1}); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig for negative defaults, got %v", err)
+	}
+
+	fmt.Println("✓ App and local settings inherit from the parent")
+}
+
+// TestManagerConfig_ShutdownTimeout tests that app and local shutdowns use the effective shutdown
+// timeout instead of the global one
+func TestManagerConfig_ShutdownTimeout(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestManagerConfig_ShutdownTimeout ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "timeout-app", "timeout-local")
+	defer orch.Shutdown(false)
+	appMgr := app.NewAppManagerFor(orch.GlobalManager(), "timeout-app")
+	release := make(chan struct{})
+	defer close(release)
+
+	stubborn := func(ctx context.Context) error {
+		<-release
+		return nil
+	}
+	if err := localMgr.Go("stubborn", stubborn); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if err := appMgr.SetShutdownTimeout(100 * time.Millisecond); err != nil {
+		t.Fatalf("SetShutdownTimeout() failed: %v", err)
+	}
+
+	// The global timeout is 10s - the app's 100ms applies
+	start := time.Now()
+	report, err := appMgr.ShutdownWithReport(true)
+	if err != nil {
+		t.Fatalf("ShutdownWithReport() failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the app shutdown timeout to apply, took %v", elapsed)
+	}
+	if report.Clean() {
+		t.Error("Expected the stubborn routine to be force-cancelled")
+	}
+
+	// A local override applies within a global shutdown
+	localMgr, err = orch.NewLocalManager("timeout-app", "timeout-local")
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	if err := localMgr.SetShutdownTimeout(100 * time.Millisecond); err != nil {
+		t.Fatalf("SetShutdownTimeout() failed: %v", err)
+	}
+	if err := appMgr.SetShutdownTimeout(0); err != nil {
+		t.Fatalf("SetShutdownTimeout() failed: %v", err)
+	}
+	if err := localMgr.Go("stubborn", stubborn); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	start = time.Now()
+	if _, err := orch.ShutdownWithReport(true); err != nil {
+		t.Fatalf("ShutdownWithReport() failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the local shutdown timeout to apply, took %v", elapsed)
+	}
+
+	fmt.Println("✓ Shutdown uses the effective shutdown timeout")
+}
+
+// TestManagerConfig_RoutineDefaults tests that Go() applies the app and local routine defaults below
+// the function defaults and the call-site options
+func TestManagerConfig_RoutineDefaults(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestManagerConfig_RoutineDefaults ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "defaults-app", "defaults-local")
+	defer orch.Shutdown(false)
+	if _, err := orch.Init(global.WithFunctionDefaults("fetch", types.FunctionConfig{Timeout: time.Hour})); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	appMgr := app.NewAppManagerFor(orch.GlobalManager(), "defaults-app")
+	if err := appMgr.SetRoutineDefaults(types.FunctionConfig{Timeout: time.Minute}); err != nil {
+		t.Fatalf("SetRoutineDefaults() failed: %v", err)
+	}
+
+	// timeoutOf spawns a routine and returns the time left until its deadline (0 = none)
+	timeoutOf := func(functionName string, opts ...interfaces.GoroutineOption) time.Duration {
+		t.Helper()
+		timeouts := make(chan time.Duration, 1)
+		worker := func(ctx context.Context) error {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				timeouts <- 0
+				return nil
+			}
+			timeouts <- time.Until(deadline)
+			return nil
+		}
+		if err := localMgr.Go(functionName, worker, opts...); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+		return <-timeouts
+	}
+
+	if timeout := timeoutOf("plain"); timeout <= 50*time.Second || timeout > time.Minute {
+		t.Errorf("Expected the app default of 1m, got %v", timeout)
+	}
+	if timeout := timeoutOf("fetch"); timeout <= 50*time.Minute {
+		t.Errorf("Expected the function default of 1h, got %v", timeout)
+	}
+	if timeout := timeoutOf("plain", local.WithTimeout(time.Second)); timeout > time.Second {
+		t.Errorf("Expected the call-site timeout of 1s, got %v", timeout)
+	}
+
+	fmt.Println("✓ Routine defaults apply below function defaults and call-site options")
+}
+
+// TestManagerConfig_FromConfig tests that app and local settings of the global configuration apply
+// to managers created later and to existing managers on Reconfigure
+func TestManagerConfig_FromConfig(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestManagerConfig_FromConfig ===")
+
+	orch := orchestrator.New()
+	defer orch.Shutdown(false)
+	if _, err := orch.Init(
+		global.WithAppConfig("configured-app", types.ManagerConfig{ShutdownTimeout: time.Minute}),
+		global.WithLocalConfig("configured-app", "configured-local", types.ManagerConfig{
+			Defaults: types.FunctionConfig{MaxConcurrent: 2},
+		}),
+	); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	localMgr, err := orch.NewLocalManager("configured-app", "configured-local")
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	want := types.ManagerConfig{ShutdownTimeout: time.Minute, Defaults: types.FunctionConfig{MaxConcurrent: 2}}
+	if effective := localMgr.GetEffectiveConfig(); effective != want {
+		t.Errorf("Expected %+v, got %+v", want, effective)
+	}
+
+	cfg, err := orch.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig() failed: %v", err)
+	}
+	changes, err := orch.Reconfigure(cfg.With(global.WithLocalConfig("configured-app", "configured-local", types.ManagerConfig{
+		ShutdownTimeout: 5 * time.Second,
+		Defaults:        types.FunctionConfig{MaxConcurrent: 2},
+	})))
+	if err != nil {
+		t.Fatalf("Reconfigure() failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Setting != "apps.configured-app.locals.configured-local.shutdown_timeout" {
+		t.Errorf("Expected the local shutdown timeout to change, got %v", changes)
+	}
+	if timeout := localMgr.GetEffectiveConfig().ShutdownTimeout; timeout != 5*time.Second {
+		t.Errorf("Expected the existing local manager to get 5s, got %v", timeout)
+	}
+
+	cfg.Apps = map[string]types.AppConfig{"configured-app": {ManagerConfig: types.ManagerConfig{ShutdownTimeout: -time.Second}}}
+	if _, err := orch.Reconfigure(cfg); !errors.Is(err, goerrors.ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig for a negative app timeout, got %v", err)
+	}
+
+	fmt.Println("✓ Configured app and local settings apply")
+}
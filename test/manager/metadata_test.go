@@ -1,10 +1,14 @@
 package manager_test
 
 import (
+	"context"
+	"errors"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
 	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
 )
@@ -555,3 +559,51 @@ func TestGlobalManager_UpdateMetadata_BeforeInit(t *testing.T) {
 // 		t.Error("Metrics server should be stopped after disabling")
 // 	}
 // }
+
+// TestGlobalManager_MetricsServerAfterShutdown tests that the metrics server started by
+// SET_METRICS_URL can be stopped once the global context is cancelled
+func TestGlobalManager_MetricsServerAfterShutdown(t *testing.T) {
+	common.ResetGlobalState()
+
+	gm := global.NewGlobalManager()
+	gm.Init()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() failed: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	if _, err := gm.UpdateMetadata(global.SET_METRICS_URL, addr); err != nil {
+		t.Fatalf("UpdateMetadata() failed: %v", err)
+	}
+	if !metrics.IsServerRunning() {
+		t.Fatal("Expected the metrics server to run")
+	}
+	if err := metrics.StartMetricsServer(addr); !errors.Is(err, metrics.ErrServerRunning) {
+		t.Errorf("Expected ErrServerRunning, got %v", err)
+	}
+	// Starting the running server again is not an error
+	if _, err := gm.UpdateMetadata(global.SET_METRICS_URL, addr); err != nil {
+		t.Errorf("UpdateMetadata() with the running server's URL failed: %v", err)
+	}
+
+	if err := gm.Shutdown(false); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+	if _, err := gm.UpdateMetadata(global.SET_METRICS_URL, []interface{}{false, addr}); err != nil {
+		t.Fatalf("UpdateMetadata() failed: %v", err)
+	}
+	if metrics.IsServerRunning() {
+		t.Error("Expected the metrics server to be stopped")
+	}
+	if err := metrics.StopMetricsServer(context.Background()); !errors.Is(err, metrics.ErrServerNotRunning) {
+		t.Errorf("Expected ErrServerNotRunning, got %v", err)
+	}
+	listener, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("Expected the metrics server's address to be free, got %v", err)
+	}
+	listener.Close()
+}
@@ -0,0 +1,318 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/metrics"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/orchestrator"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// setupOrchestratorLocal creates an orchestrator with one local manager. The orchestrator is shut
+// down when the test ends, so no routine of the test outlives it.
+func setupOrchestratorLocal(t *testing.T, appName, localName string) (*orchestrator.Orchestrator, interfaces.LocalGoroutineManagerInterface) {
+	t.Helper()
+	orch := orchestrator.New()
+	t.Cleanup(func() {
+		orch.Shutdown(false)
+	})
+	localMgr, err := orch.NewLocalManager(appName, localName)
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	return orch, localMgr
+}
+
+// TestOrchestrator_Isolated tests that orchestrators with the same app and local names do not share state
+func TestOrchestrator_Isolated(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestOrchestrator_Isolated ===")
+
+	first, firstLocal := setupOrchestratorLocal(t, "tenant", "workers")
+	second, secondLocal := setupOrchestratorLocal(t, "tenant", "workers")
+	release := make(chan struct{})
+	defer close(release)
+
+	for i := 0; i < 3; i++ {
+		if err := firstLocal.Go("job", blockingWorker(release)); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	}
+	if err := secondLocal.Go("job", blockingWorker(release)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	if count := first.GetGoroutineCount(); count != 3 {
+		t.Errorf("Expected 3 routines in the first orchestrator, got %d", count)
+	}
+	if count := second.GetGoroutineCount(); count != 1 {
+		t.Errorf("Expected 1 routine in the second orchestrator, got %d", count)
+	}
+	if first.GlobalManager() == second.GlobalManager() || first.GlobalManager() == types.Global {
+		t.Error("Orchestrators should own their global managers")
+	}
+
+	// Metadata is per orchestrator
+	if _, err := first.UpdateMetadata(global.SET_MAX_ROUTINES, 3); err != nil {
+		t.Fatalf("UpdateMetadata() failed: %v", err)
+	}
+	if err := firstLocal.Go("job", blockingWorker(release)); !errors.Is(err, goerrors.ErrMaxRoutinesReached) {
+		t.Errorf("Expected ErrMaxRoutinesReached, got %v", err)
+	}
+	if err := secondLocal.Go("job", blockingWorker(release)); err != nil {
+		t.Errorf("The second orchestrator should not be limited: %v", err)
+	}
+
+	fmt.Println("✓ Orchestrators are isolated")
+}
+
+// TestOrchestrator_Shutdown tests that shutting down one orchestrator leaves the others running
+func TestOrchestrator_Shutdown(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestOrchestrator_Shutdown ===")
+
+	first, firstLocal := setupOrchestratorLocal(t, "tenant", "workers")
+	second, secondLocal := setupOrchestratorLocal(t, "tenant", "workers")
+
+	var firstCtx context.Context
+	started := make(chan struct{})
+	if err := firstLocal.Go("job", func(ctx context.Context) error {
+		firstCtx = ctx
+		close(started)
+		<-ctx.Done()
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	<-started
+	release := make(chan struct{})
+	defer close(release)
+	if err := secondLocal.Go("job", blockingWorker(release)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	if err := first.Shutdown(false); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+	select {
+	case <-firstCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Routine of the shut down orchestrator was not cancelled")
+	}
+	if ctx, _ := first.GlobalManager().GetGlobalContext(); ctx.Err() == nil {
+		t.Error("Unsafe shutdown should cancel the orchestrator's root context")
+	}
+
+	if count := second.GetGoroutineCount(); count != 1 {
+		t.Errorf("Expected the second orchestrator's routine to keep running, got %d routines", count)
+	}
+	if ctx, _ := second.GlobalManager().GetGlobalContext(); ctx.Err() != nil {
+		t.Error("The second orchestrator's root context should not be cancelled")
+	}
+
+	fmt.Println("✓ Orchestrators shut down independently")
+}
+
+// TestOrchestrator_Metrics tests that each orchestrator exports its own tree through its registry
+func TestOrchestrator_Metrics(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestOrchestrator_Metrics ===")
+
+	first, firstLocal := setupOrchestratorLocal(t, "metrics-app", "workers")
+	second, _ := setupOrchestratorLocal(t, "metrics-app", "workers")
+	release := make(chan struct{})
+	defer close(release)
+	for i := 0; i < 2; i++ {
+		if err := firstLocal.Go("job", blockingWorker(release)); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	}
+
+	scrape := func(orch *orchestrator.Orchestrator) string {
+		recorder := httptest.NewRecorder()
+		orch.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		body, _ := io.ReadAll(recorder.Body)
+		return string(body)
+	}
+
+	firstMetrics := scrape(first)
+	for _, want := range []string{
+		"goroutine_manager_global_goroutines_total 2",
+		`goroutine_manager_goroutine_by_function{app_name="metrics-app",function_name="job",local_name="workers"} 2`,
+	} {
+		if !strings.Contains(firstMetrics, want) {
+			t.Errorf("Expected %q in:\n%s", want, firstMetrics)
+		}
+	}
+	if secondMetrics := scrape(second); !strings.Contains(secondMetrics, "goroutine_manager_global_goroutines_total 0") {
+		t.Errorf("Expected no routines in the second orchestrator:\n%s", secondMetrics)
+	}
+
+	if snapshot := first.Snapshot(types.SnapshotFilter{}); snapshot.RoutineCount != 2 {
+		t.Errorf("Expected 2 routines in the snapshot, got %d", snapshot.RoutineCount)
+	}
+
+	fmt.Println("✓ Orchestrators export their own metrics")
+}
+
+// TestOrchestrator_EventsAndPanicHandler tests that subscribers and the panic handler of an
+// orchestrator only see the routines of its own tree
+func TestOrchestrator_EventsAndPanicHandler(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestOrchestrator_EventsAndPanicHandler ===")
+
+	first, firstLocal := setupOrchestratorLocal(t, "events-app", "workers")
+	second, secondLocal := setupOrchestratorLocal(t, "events-app", "workers")
+
+	var mu sync.Mutex
+	var firstEvents, secondEvents []types.Event
+	firstCompleted := make(chan struct{}, 1)
+	secondCompleted := make(chan struct{}, 1)
+	subscriber := func(events *[]types.Event, completed chan struct{}) types.Subscriber {
+		return types.SubscriberFunc(func(event types.Event) {
+			mu.Lock()
+			*events = append(*events, event)
+			mu.Unlock()
+			if event.Type == types.EventRoutineCompleted {
+				completed <- struct{}{}
+			}
+		})
+	}
+	defer first.Subscribe(subscriber(&firstEvents, firstCompleted))()
+	defer second.Subscribe(subscriber(&secondEvents, secondCompleted))()
+	waitCompleted := func(completed chan struct{}) {
+		t.Helper()
+		select {
+		case <-completed:
+		case <-time.After(time.Second):
+			t.Fatal("Routine did not complete")
+		}
+	}
+
+	firstPanics := make(chan *types.PanicInfo, 1)
+	secondPanics := make(chan *types.PanicInfo, 1)
+	first.SetPanicHandler(func(info *types.PanicInfo) { firstPanics <- info })
+	second.SetPanicHandler(func(info *types.PanicInfo) { secondPanics <- info })
+
+	if err := secondLocal.Go("crash", func(ctx context.Context) error {
+		panic("boom")
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	select {
+	case info := <-secondPanics:
+		if info.FunctionName != "crash" {
+			t.Errorf("Expected the panic of crash, got %q", info.FunctionName)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("The second orchestrator's panic handler was not called")
+	}
+	waitCompleted(secondCompleted)
+	select {
+	case <-firstPanics:
+		t.Error("The first orchestrator's panic handler should not see panics of the second")
+	default:
+	}
+
+	mu.Lock()
+	if len(firstEvents) != 0 {
+		t.Errorf("Expected no events for the first orchestrator, got %v", firstEvents)
+	}
+	var panicked bool
+	for _, event := range secondEvents {
+		panicked = panicked || event.Type == types.EventRoutinePanicked
+	}
+	mu.Unlock()
+	if !panicked {
+		t.Error("Expected EventRoutinePanicked for the second orchestrator")
+	}
+
+	if err := firstLocal.Go("job", func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	waitCompleted(firstCompleted)
+	mu.Lock()
+	if len(firstEvents) == 0 {
+		t.Error("Expected events for the first orchestrator's routine")
+	}
+	mu.Unlock()
+
+	fmt.Println("✓ Orchestrators have their own subscribers and panic handler")
+}
+
+// TestOrchestrator_MetricsServer tests that SET_METRICS_URL serves the orchestrator's registry and
+// that the server stops with the orchestrator
+func TestOrchestrator_MetricsServer(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestOrchestrator_MetricsServer ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "server-app", "workers")
+	release := make(chan struct{})
+	defer close(release)
+	if err := localMgr.Go("job", blockingWorker(release)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() failed: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	if _, err := orch.UpdateMetadata(global.SET_METRICS_URL, addr); err != nil {
+		t.Fatalf("UpdateMetadata() failed: %v", err)
+	}
+	if !metrics.IsTreeServerRunning(orch.GlobalManager()) {
+		t.Fatal("Expected the orchestrator's metrics server to run")
+	}
+	if metrics.IsServerRunning() {
+		t.Error("The process-wide metrics server should not be started")
+	}
+
+	var body string
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		response, err := http.Get("http://" + addr + "/metrics")
+		if err == nil {
+			data, _ := io.ReadAll(response.Body)
+			response.Body.Close()
+			body = string(data)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Metrics server did not answer: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if want := `goroutine_manager_goroutine_by_function{app_name="server-app",function_name="job",local_name="workers"} 1`; !strings.Contains(body, want) {
+		t.Errorf("Expected %q in:\n%s", want, body)
+	}
+
+	if err := orch.Shutdown(false); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+	if metrics.IsTreeServerRunning(orch.GlobalManager()) {
+		t.Error("Expected the metrics server to stop with the orchestrator")
+	}
+	listener, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("Expected the metrics server's address to be free, got %v", err)
+	}
+	listener.Close()
+
+	fmt.Println("✓ Orchestrator metrics server serves its tree")
+}
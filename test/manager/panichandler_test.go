@@ -0,0 +1,101 @@
+package manager_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// TestPanicHandler_PerCall tests that WithPanicHandler receives the panic value and stack
+func TestPanicHandler_PerCall(t *testing.T) {
+	fmt.Println("\n=== TestPanicHandler_PerCall ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "panic-app", "panic-local")
+
+	globalCalled := make(chan struct{}, 1)
+	types.SetPanicHandler(func(info *types.PanicInfo) { globalCalled <- struct{}{} })
+	defer types.SetPanicHandler(nil)
+
+	handled := make(chan *types.PanicInfo, 1)
+	var id string
+	if err := localMgr.Go("exploding", func(ctx context.Context) error {
+		panic("kaboom")
+	}, local.WithPanicHandler(func(info *types.PanicInfo) { handled <- info }), local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	result, err := localMgr.WaitForRoutineResult(id, time.Second)
+	if err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+
+	select {
+	case info := <-handled:
+		if info.Value != "kaboom" || info.RoutineID != id || info.FunctionName != "exploding" {
+			t.Errorf("Unexpected panic info: %+v", info)
+		}
+		if !strings.Contains(string(info.Stack), "panichandler_test.go") {
+			t.Errorf("Expected the stack to include the panicking function, got %s", info.Stack)
+		}
+	default:
+		t.Fatal("Per-call panic handler was not called before the routine completed")
+	}
+	select {
+	case <-globalCalled:
+		t.Error("Global panic handler should not be called when a per-call handler is set")
+	default:
+	}
+
+	if result.Panic == nil || result.Panic.Value != "kaboom" {
+		t.Errorf("Expected panic info on the result, got %+v", result.Panic)
+	}
+
+	fmt.Println("✓ Per-call panic handler receives value and stack")
+}
+
+// TestPanicHandler_Global tests that the global panic handler is used by default and may panic itself
+func TestPanicHandler_Global(t *testing.T) {
+	fmt.Println("\n=== TestPanicHandler_Global ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "panic-app", "panic-local")
+
+	handled := make(chan *types.PanicInfo, 1)
+	types.SetPanicHandler(func(info *types.PanicInfo) {
+		handled <- info
+		panic("handler failed too")
+	})
+	defer types.SetPanicHandler(nil)
+
+	var id string
+	if err := localMgr.Go("exploding", func(ctx context.Context) error {
+		panic(fmt.Errorf("wrapped"))
+	}, local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	result, err := localMgr.WaitForRoutineResult(id, time.Second)
+	if err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+	if !result.Panicked || result.Panic == nil {
+		t.Fatalf("Expected a panicked result with panic info, got %+v", result)
+	}
+	select {
+	case info := <-handled:
+		if info.AppName != "panic-app" || info.LocalName != "panic-local" {
+			t.Errorf("Unexpected panic info: %+v", info)
+		}
+	default:
+		t.Fatal("Global panic handler was not called")
+	}
+
+	fmt.Println("✓ Global panic handler is used by default")
+}
@@ -0,0 +1,161 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+)
+
+// TestRoutineResult_WorkerError tests that the error returned by a worker is kept as its result
+func TestRoutineResult_WorkerError(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestRoutineResult_WorkerError ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "result-app", "result-local")
+
+	errJob := errors.New("job failed")
+	var id string
+	err := localMgr.Go("failing-job", func(ctx context.Context) error {
+		return errJob
+	}, local.CaptureRoutineID(&id))
+	if err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if id == "" {
+		t.Fatal("CaptureRoutineID did not receive the routine ID")
+	}
+
+	result, err := localMgr.WaitForRoutineResult(id, time.Second)
+	if err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+	if !errors.Is(result.Err, errJob) {
+		t.Errorf("Expected worker error, got %v", result.Err)
+	}
+	if result.Panicked {
+		t.Error("Result should not be marked as panicked")
+	}
+	if result.FunctionName != "failing-job" {
+		t.Errorf("Expected function name failing-job, got %s", result.FunctionName)
+	}
+
+	// Result stays available after the routine leaves the tracking map
+	time.Sleep(50 * time.Millisecond)
+	if _, err := localMgr.GetRoutine(id); err == nil {
+		t.Error("Routine should have been removed from the tracking map")
+	}
+	result, err = localMgr.GetRoutineResult(id)
+	if err != nil {
+		t.Fatalf("GetRoutineResult() after removal failed: %v", err)
+	}
+	if !errors.Is(result.Err, errJob) {
+		t.Errorf("Expected worker error after removal, got %v", result.Err)
+	}
+
+	fmt.Println("✓ Worker error is captured")
+}
+
+// TestRoutineResult_Panic tests that a recovered panic becomes the routine's error with a stack
+func TestRoutineResult_Panic(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestRoutineResult_Panic ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "result-app", "result-local")
+
+	var id string
+	err := localMgr.Go("panicking-job", func(ctx context.Context) error {
+		panic("boom")
+	}, local.CaptureRoutineID(&id))
+	if err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	result, err := localMgr.WaitForRoutineResult(id, time.Second)
+	if err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+	if !result.Panicked {
+		t.Error("Result should be marked as panicked")
+	}
+	if !errors.Is(result.Err, goerrors.ErrRoutinePanicked) {
+		t.Errorf("Expected ErrRoutinePanicked, got %v", result.Err)
+	}
+	if !strings.Contains(result.Err.Error(), "boom") || !strings.Contains(result.Err.Error(), "goroutine") {
+		t.Errorf("Expected panic value and stack in error, got %v", result.Err)
+	}
+
+	fmt.Println("✓ Panic is captured as an error")
+}
+
+// TestRoutineResult_NotFinished tests the running and timeout cases
+func TestRoutineResult_NotFinished(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestRoutineResult_NotFinished ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "result-app", "result-local")
+
+	release := make(chan struct{})
+	defer close(release)
+	var id string
+	if err := localMgr.Go("slow-job", blockingWorker(release), local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	if _, err := localMgr.GetRoutineResult(id); !errors.Is(err, goerrors.ErrRoutineNotFinished) {
+		t.Errorf("Expected ErrRoutineNotFinished, got %v", err)
+	}
+	if _, err := localMgr.WaitForRoutineResult(id, 50*time.Millisecond); !errors.Is(err, goerrors.ErrRoutineNotFinished) {
+		t.Errorf("Expected ErrRoutineNotFinished after timeout, got %v", err)
+	}
+	if _, err := localMgr.GetRoutineResult("unknown-id"); !errors.Is(err, goerrors.ErrRoutineNotFound) {
+		t.Errorf("Expected ErrRoutineNotFound, got %v", err)
+	}
+
+	fmt.Println("✓ Unfinished routines report ErrRoutineNotFinished")
+}
+
+// TestRoutineResult_BoundedStore tests that old results are evicted once the store is full
+func TestRoutineResult_BoundedStore(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestRoutineResult_BoundedStore ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "result-app", "result-local")
+	lm, err := localMgr.Get()
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	lm.SetResultsCapacity(2)
+
+	ids := make([]string, 3)
+	for i := range ids {
+		if err := localMgr.Go("quick-job", func(ctx context.Context) error {
+			return nil
+		}, local.CaptureRoutineID(&ids[i])); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+		if _, err := localMgr.WaitForRoutineResult(ids[i], time.Second); err != nil {
+			t.Fatalf("WaitForRoutineResult() failed: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if _, err := localMgr.GetRoutineResult(ids[0]); !errors.Is(err, goerrors.ErrRoutineNotFound) {
+		t.Errorf("Expected oldest result to be evicted, got %v", err)
+	}
+	for _, id := range ids[1:] {
+		if _, err := localMgr.GetRoutineResult(id); err != nil {
+			t.Errorf("Expected result for %s, got %v", id, err)
+		}
+	}
+	if n := lm.Results.Len(); n != 2 {
+		t.Errorf("Expected 2 stored results, got %d", n)
+	}
+
+	fmt.Println("✓ Result store is bounded")
+}
@@ -0,0 +1,420 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// concurrencyTracker records the highest number of concurrent runs of a schedule
+type concurrencyTracker struct {
+	current atomic.Int32
+	max     atomic.Int32
+	runs    atomic.Int32
+}
+
+func (CT *concurrencyTracker) worker(hold time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		n := CT.current.Add(1)
+		defer CT.current.Add(-1)
+		for {
+			m := CT.max.Load()
+			if n <= m || CT.max.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		CT.runs.Add(1)
+		select {
+		case <-time.After(hold):
+		case <-ctx.Done():
+		}
+		return nil
+	}
+}
+
+// stopSchedule stops a schedule and waits for its loop and runs to exit
+func stopSchedule(t *testing.T, localMgr interfaces.LocalGoroutineManagerInterface, schedule interfaces.Schedule) {
+	t.Helper()
+	schedule.Stop()
+	select {
+	case <-schedule.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("Schedule %s did not stop", schedule.GetName())
+	}
+	if !localMgr.WaitForFunctionWithTimeout(schedule.GetName(), time.Second) {
+		t.Fatalf("Runs of schedule %s did not exit", schedule.GetName())
+	}
+}
+
+// TestSchedule_Interval tests that an interval schedule runs as tracked routines and exposes its state
+func TestSchedule_Interval(t *testing.T) {
+	fmt.Println("\n=== TestSchedule_Interval ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "schedule-app", "schedule-local")
+
+	var runs atomic.Int32
+	schedule, err := localMgr.NewSchedule("tick", types.ScheduleConfig{Interval: 20 * time.Millisecond}, func(ctx context.Context) error {
+		if runs.Add(1)%2 == 0 {
+			return errors.New("even run")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewSchedule() failed: %v", err)
+	}
+	defer stopSchedule(t, localMgr, schedule)
+
+	if next := schedule.NextRun(); next.IsZero() {
+		t.Error("Expected a next run time")
+	}
+	if got, err := localMgr.GetSchedule("tick"); err != nil || got != schedule {
+		t.Errorf("GetSchedule() = %v, %v", got, err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runs.Load() < 4 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	status := schedule.Status()
+	if status.Kind != types.ScheduleKindInterval || status.Spec != "20ms" {
+		t.Errorf("Unexpected kind/spec %q/%q", status.Kind, status.Spec)
+	}
+	if status.Runs < 4 {
+		t.Errorf("Expected at least 4 runs, got %d", status.Runs)
+	}
+	if status.Failures < 2 {
+		t.Errorf("Expected at least 2 failed runs, got %d", status.Failures)
+	}
+	if schedule.LastRun().IsZero() || status.LastRun == nil {
+		t.Error("Expected a last run time")
+	}
+	if status.Runs%2 == 0 && schedule.LastError() == nil {
+		t.Error("Expected the last (even) run's error")
+	}
+
+	snapshot := types.TakeSnapshot(types.SnapshotFilter{AppName: "schedule-app"})
+	if len(snapshot.Apps) != 1 || len(snapshot.Apps[0].Locals) != 1 || len(snapshot.Apps[0].Locals[0].Schedules) != 1 {
+		t.Fatalf("Expected the schedule in the snapshot, got %+v", snapshot)
+	}
+	if snapshot.Apps[0].Locals[0].Schedules[0].Name != "tick" {
+		t.Errorf("Unexpected schedule in snapshot: %+v", snapshot.Apps[0].Locals[0].Schedules[0])
+	}
+
+	fmt.Println("✓ Interval schedule runs and reports its state")
+}
+
+// TestSchedule_OverlapPolicies tests skipping and allowing overlapping runs
+func TestSchedule_OverlapPolicies(t *testing.T) {
+	fmt.Println("\n=== TestSchedule_OverlapPolicies ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "schedule-app", "schedule-local")
+
+	skip := &concurrencyTracker{}
+	skipSchedule, err := localMgr.NewSchedule("skip", types.ScheduleConfig{
+		Interval: 10 * time.Millisecond,
+		Overlap:  types.OverlapSkip,
+	}, skip.worker(55*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewSchedule(skip) failed: %v", err)
+	}
+	allow := &concurrencyTracker{}
+	allowSchedule, err := localMgr.NewSchedule("allow", types.ScheduleConfig{
+		Interval: 10 * time.Millisecond,
+		Overlap:  types.OverlapAllow,
+	}, allow.worker(55*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewSchedule(allow) failed: %v", err)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	stopSchedule(t, localMgr, skipSchedule)
+	stopSchedule(t, localMgr, allowSchedule)
+
+	if skip.max.Load() != 1 {
+		t.Errorf("OverlapSkip ran %d runs concurrently", skip.max.Load())
+	}
+	if skipSchedule.Status().Skipped == 0 {
+		t.Error("OverlapSkip should have skipped runs")
+	}
+	if allow.max.Load() < 2 {
+		t.Errorf("OverlapAllow should run concurrently, max %d", allow.max.Load())
+	}
+
+	fmt.Println("✓ Overlap policies respected")
+}
+
+// TestSchedule_QueueAndMissedRuns tests that a queued run follows a long run and passed run times are missed
+func TestSchedule_QueueAndMissedRuns(t *testing.T) {
+	fmt.Println("\n=== TestSchedule_QueueAndMissedRuns ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "schedule-app", "schedule-local")
+
+	tracker := &concurrencyTracker{}
+	schedule, err := localMgr.NewSchedule("queue", types.ScheduleConfig{
+		Interval:  10 * time.Millisecond,
+		Overlap:   types.OverlapQueue,
+		MissedRun: types.MissedRunSkip,
+	}, tracker.worker(45*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewSchedule() failed: %v", err)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	stopSchedule(t, localMgr, schedule)
+
+	status := schedule.Status()
+	if tracker.max.Load() != 1 {
+		t.Errorf("OverlapQueue ran %d runs concurrently", tracker.max.Load())
+	}
+	if status.Skipped != 0 {
+		t.Errorf("OverlapQueue should not skip runs, skipped %d", status.Skipped)
+	}
+	if status.Missed == 0 {
+		t.Error("Run times passed during queued runs should be missed")
+	}
+	if tracker.runs.Load() < 2 {
+		t.Errorf("Expected queued runs, got %d", tracker.runs.Load())
+	}
+
+	fmt.Println("✓ Queued runs and missed run times handled")
+}
+
+// TestSchedule_FixedDelay tests that fixed delay runs never overlap
+func TestSchedule_FixedDelay(t *testing.T) {
+	fmt.Println("\n=== TestSchedule_FixedDelay ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "schedule-app", "schedule-local")
+
+	tracker := &concurrencyTracker{}
+	schedule, err := localMgr.NewSchedule("delay", types.ScheduleConfig{
+		Delay:          10 * time.Millisecond,
+		Overlap:        types.OverlapAllow,
+		RunImmediately: true,
+	}, tracker.worker(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewSchedule() failed: %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	stopSchedule(t, localMgr, schedule)
+
+	if tracker.max.Load() != 1 {
+		t.Errorf("Fixed delay runs overlapped (%d concurrent)", tracker.max.Load())
+	}
+	if runs := tracker.runs.Load(); runs < 3 || runs > 8 {
+		t.Errorf("Expected 3-8 runs 30ms apart in 200ms, got %d", runs)
+	}
+
+	fmt.Println("✓ Fixed delay runs one at a time")
+}
+
+// TestSchedule_ShutdownFunctionStops tests that ShutdownFunction stops the schedule along with its runs
+func TestSchedule_ShutdownFunctionStops(t *testing.T) {
+	fmt.Println("\n=== TestSchedule_ShutdownFunctionStops ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "schedule-app", "schedule-local")
+
+	var runs atomic.Int32
+	cancelled := make(chan struct{}, 1)
+	schedule, err := localMgr.NewSchedule("job", types.ScheduleConfig{Interval: 10 * time.Millisecond}, func(ctx context.Context) error {
+		runs.Add(1)
+		<-ctx.Done()
+		select {
+		case cancelled <- struct{}{}:
+		default:
+		}
+		return ctx.Err()
+	}, local.WithTimeout(time.Minute))
+	if err != nil {
+		t.Fatalf("NewSchedule() failed: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := localMgr.ShutdownFunction("job", 2*time.Second); err != nil {
+		t.Fatalf("ShutdownFunction() failed: %v", err)
+	}
+	select {
+	case <-schedule.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Schedule did not stop on ShutdownFunction()")
+	}
+	select {
+	case <-cancelled:
+	default:
+		t.Error("Run in progress should be cancelled")
+	}
+
+	if _, err := localMgr.GetSchedule("job"); !errors.Is(err, goerrors.ErrScheduleNotFound) {
+		t.Errorf("Stopped schedule should be removed, got %v", err)
+	}
+	after := runs.Load()
+	time.Sleep(50 * time.Millisecond)
+	if runs.Load() != after {
+		t.Error("Schedule kept running after ShutdownFunction()")
+	}
+	if count := localMgr.GetFunctionGoroutineCount("job"); count != 0 {
+		t.Errorf("Expected no routines left, got %d", count)
+	}
+
+	fmt.Println("✓ ShutdownFunction stops the schedule")
+}
+
+// TestSchedule_ShutdownStops tests that the local manager's shutdown stops its schedules
+func TestSchedule_ShutdownStops(t *testing.T) {
+	fmt.Println("\n=== TestSchedule_ShutdownStops ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "schedule-app", "schedule-local")
+
+	schedule, err := localMgr.NewSchedule("job", types.ScheduleConfig{Cron: "@hourly"}, func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewSchedule() failed: %v", err)
+	}
+	if next := schedule.NextRun(); next.Minute() != 0 || !next.After(time.Now()) {
+		t.Errorf("Expected the next full hour, got %v", next)
+	}
+
+	report, err := localMgr.ShutdownWithReport(true)
+	if err != nil {
+		t.Fatalf("ShutdownWithReport() failed: %v", err)
+	}
+	if len(report.Phases) == 0 || report.Phases[0].Name != "stop_schedules" {
+		t.Errorf("Expected stop_schedules as the first phase, got %v", report.Phases)
+	}
+	select {
+	case <-schedule.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Schedule did not stop on Shutdown()")
+	}
+	if !schedule.NextRun().IsZero() {
+		t.Error("Stopped schedule should have no next run")
+	}
+
+	fmt.Println("✓ Shutdown stops schedules")
+}
+
+// TestSchedule_InvalidConfig tests that invalid schedules are rejected
+func TestSchedule_InvalidConfig(t *testing.T) {
+	fmt.Println("\n=== TestSchedule_InvalidConfig ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "schedule-app", "schedule-local")
+	noop := func(ctx context.Context) error { return nil }
+
+	for name, config := range map[string]types.ScheduleConfig{
+		"empty":       {},
+		"two-kinds":   {Interval: time.Second, Cron: "* * * * *"},
+		"bad-cron":    {Cron: "61 * * * *"},
+		"short-cron":  {Cron: "* * *"},
+		"never-cron":  {Cron: "0 0 30 2 *"},
+		"bad-step":    {Cron: "*/0 * * * *"},
+		"bad-weekday": {Cron: "0 0 * * FUNDAY"},
+	} {
+		if _, err := localMgr.NewSchedule(name, config, noop); !errors.Is(err, goerrors.ErrInvalidSchedule) {
+			t.Errorf("%s: expected ErrInvalidSchedule, got %v", name, err)
+		}
+	}
+	if count := localMgr.GetGoroutineCount(); count != 0 {
+		t.Errorf("Invalid schedules should not start routines, got %d", count)
+	}
+
+	fmt.Println("✓ Invalid schedules rejected")
+}
+
+// TestParseCron tests cron expression matching
+func TestParseCron(t *testing.T) {
+	fmt.Println("\n=== TestParseCron ===")
+
+	// Saturday
+	from := time.Date(2024, time.June, 1, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.June, 1, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.June, 1, 10, 15, 0, 0, time.UTC)},
+		{"30 9 * * MON-FRI", time.Date(2024, time.June, 3, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC)},
+		{"5/20 10 * * *", time.Date(2024, time.June, 1, 10, 25, 0, 0, time.UTC)},
+		{"0 9 1,15 * *", time.Date(2024, time.June, 15, 9, 0, 0, 0, time.UTC)},
+		// Day of month or day of week when both are restricted
+		{"0 12 15 * MON", time.Date(2024, time.June, 3, 12, 0, 0, 0, time.UTC)},
+		{"0 0 29 FEB *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		cron, err := local.ParseCron(tt.expr, time.UTC)
+		if err != nil {
+			t.Errorf("ParseCron(%q) failed: %v", tt.expr, err)
+			continue
+		}
+		if got := cron.Next(from); !got.Equal(tt.want) {
+			t.Errorf("ParseCron(%q).Next() = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	fmt.Println("✓ Cron expressions match")
+}
+
+// TestSchedule_IgnoresRestart tests that a restart policy defaulted for the schedule's name does not
+// make runs restart, so every failed run is recorded once
+func TestSchedule_IgnoresRestart(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestSchedule_IgnoresRestart ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "schedule-restart-app", "schedule-restart-local")
+	defer orch.Shutdown(false)
+
+	policy := local.RestartPolicy{Mode: local.RestartOnFailure, InitialBackoff: time.Millisecond}
+	if err := localMgr.SetFunctionOptions("retried", local.WithRestart(policy), local.WithSingleflight("key")); err != nil {
+		t.Fatalf("SetFunctionOptions() failed: %v", err)
+	}
+
+	var calls atomic.Int32
+	schedule, err := localMgr.NewSchedule("retried", types.ScheduleConfig{Interval: 20 * time.Millisecond}, func(ctx context.Context) error {
+		calls.Add(1)
+		return errors.New("failed")
+	})
+	if err != nil {
+		t.Fatalf("NewSchedule() failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for schedule.Status().Runs < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	stopSchedule(t, localMgr, schedule)
+
+	status := schedule.Status()
+	if status.Runs < 3 {
+		t.Fatalf("Expected at least 3 runs, got %d", status.Runs)
+	}
+	if int64(calls.Load()) != status.Runs || status.Failures != status.Runs {
+		t.Errorf("Expected one worker call per failed run, got %d calls for %d runs (%d failures)", calls.Load(), status.Runs, status.Failures)
+	}
+
+	fmt.Println("✓ Schedule runs are not restarted")
+}
@@ -0,0 +1,172 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+)
+
+// TestShutdownContext_Deadline tests that a global ShutdownContext stops waiting at the caller's
+// deadline instead of the global ShutdownTimeout
+func TestShutdownContext_Deadline(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestShutdownContext_Deadline ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "ctx-app", "ctx-local")
+	release := make(chan struct{})
+	defer close(release)
+
+	if err := localMgr.Go("cooperative", blockingWorker(release)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	// Ignores cancellation
+	if err := localMgr.Go("stubborn", func(ctx context.Context) error {
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	report, err := orch.ShutdownContext(ctx)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Expected ShutdownContext to return at the deadline, took %v", elapsed)
+	}
+	if graceful, forceCancelled, stillRunning := report.Totals(); graceful != 1 || forceCancelled+stillRunning != 1 {
+		t.Errorf("Expected the stubborn routine to be force-cancelled, got %d graceful, %d force-cancelled, %d still running", graceful, forceCancelled, stillRunning)
+	}
+
+	fmt.Println("✓ ShutdownContext honors the caller's deadline")
+}
+
+// TestShutdownContext_Cancelled tests that ShutdownContext stops waiting when a context without
+// deadline is cancelled, and succeeds when every routine exits in time
+func TestShutdownContext_Cancelled(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestShutdownContext_Cancelled ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "ctx-app", "ctx-local")
+	release := make(chan struct{})
+	defer close(release)
+
+	if err := localMgr.Go("stubborn", func(ctx context.Context) error {
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	if _, err := localMgr.ShutdownContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// A clean shutdown reports no error
+	other, err := orch.NewLocalManager("ctx-app", "other-local")
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	if err := other.Go("cooperative", blockingWorker(release)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	report, err := other.ShutdownContext(ctx)
+	if err != nil || !report.Clean() {
+		t.Errorf("Expected a clean shutdown, got %v: %s", err, report)
+	}
+
+	fmt.Println("✓ ShutdownContext honors cancellation")
+}
+
+// TestShutdownFunctionContext tests that ShutdownFunctionContext returns a ShutdownTimeoutError
+// wrapping the context error when the function outlives the context
+func TestShutdownFunctionContext(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestShutdownFunctionContext ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "ctx-app", "ctx-local")
+	release := make(chan struct{})
+	defer close(release)
+
+	if err := localMgr.Go("stubborn", func(ctx context.Context) error {
+		<-release
+		return nil
+	}, local.AddToWaitGroup("stubborn")); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := localMgr.ShutdownFunctionContext(ctx, "stubborn")
+	var timeoutErr *goerrors.ShutdownTimeoutError
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, goerrors.ErrShutdownTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a ShutdownTimeoutError wrapping context.DeadlineExceeded, got %v", err)
+	}
+	if timeoutErr.Function != "stubborn" || timeoutErr.Remaining != 1 {
+		t.Errorf("Unexpected timeout details: %+v", timeoutErr)
+	}
+
+	fmt.Println("✓ ShutdownFunctionContext honors the caller's deadline")
+}
+
+// TestWaitContext tests WaitForRoutineContext and WaitForFunctionContext
+func TestWaitContext(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestWaitContext ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "ctx-app", "ctx-local")
+	release := make(chan struct{})
+
+	var quickID, blockedID string
+	if err := localMgr.Go("quick", func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}, local.CaptureRoutineID(&quickID), local.AddToWaitGroup("quick")); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if err := localMgr.Go("blocked", blockingWorker(release), local.CaptureRoutineID(&blockedID), local.AddToWaitGroup("blocked")); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := localMgr.WaitForRoutineContext(ctx, quickID); err != nil {
+		t.Errorf("WaitForRoutineContext() failed: %v", err)
+	}
+
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer shortCancel()
+	if err := localMgr.WaitForRoutineContext(shortCtx, blockedID); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if err := localMgr.WaitForFunctionContext(shortCtx, "blocked"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if err := localMgr.WaitForRoutineContext(ctx, "missing-id"); !errors.Is(err, goerrors.ErrRoutineNotFound) {
+		t.Errorf("Expected ErrRoutineNotFound, got %v", err)
+	}
+	if err := localMgr.WaitForFunctionContext(ctx, "missing"); !errors.Is(err, goerrors.ErrFunctionWgNotFound) {
+		t.Errorf("Expected ErrFunctionWgNotFound, got %v", err)
+	}
+
+	close(release)
+	if err := localMgr.WaitForFunctionContext(ctx, "blocked"); err != nil {
+		t.Errorf("WaitForFunctionContext() failed: %v", err)
+	}
+
+	fmt.Println("✓ Wait variants honor the caller's context")
+}
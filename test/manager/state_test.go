@@ -0,0 +1,168 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/app"
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// TestState_LocalStoppedRejectsGo tests that Go() is rejected after the local manager shut down
+// until CreateLocal reopens it
+func TestState_LocalStoppedRejectsGo(t *testing.T) {
+	fmt.Println("\n=== TestState_LocalStoppedRejectsGo ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "state-app", "state-local")
+	if state := localMgr.GetState(); state != types.StateRunning {
+		t.Fatalf("Expected a new local manager to be running, got %s", state)
+	}
+
+	if err := localMgr.Shutdown(false); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+	if state := localMgr.GetState(); state != types.StateStopped {
+		t.Errorf("Expected the local manager to be stopped, got %s", state)
+	}
+
+	err := localMgr.Go("late", func(ctx context.Context) error { return nil })
+	var closed *goerrors.ManagerClosedError
+	if !errors.As(err, &closed) || !errors.Is(err, goerrors.ErrManagerClosed) {
+		t.Fatalf("Expected ManagerClosedError, got %v", err)
+	}
+	if closed.App != "state-app" || closed.Local != "state-local" || closed.State != "stopped" {
+		t.Errorf("Unexpected closed details: %+v", closed)
+	}
+	if count := localMgr.GetGoroutineCount(); count != 0 {
+		t.Errorf("Expected no routines after the rejection, got %d", count)
+	}
+
+	// Reopen
+	if _, err := localMgr.CreateLocal("state-local"); err != nil {
+		t.Fatalf("CreateLocal() failed: %v", err)
+	}
+	if state := localMgr.GetState(); state != types.StateRunning {
+		t.Errorf("Expected the reopened local manager to be running, got %s", state)
+	}
+	var id string
+	if err := localMgr.Go("late", func(ctx context.Context) error { return ctx.Err() }, local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() after reopening failed: %v", err)
+	}
+	if result, err := localMgr.WaitForRoutineResult(id, time.Second); err != nil || result.Err != nil {
+		t.Errorf("Expected the routine to run with a live context, got %v, %v", result, err)
+	}
+
+	fmt.Println("✓ Stopped local managers reject Go() until reopened")
+}
+
+// TestState_AppDrainingRejectsWork tests that an app manager rejects new local managers and routines
+// while it shuts down, and accepts them again once CreateApp reopens it
+func TestState_AppDrainingRejectsWork(t *testing.T) {
+	fmt.Println("\n=== TestState_AppDrainingRejectsWork ===")
+	common.ResetGlobalState()
+
+	localMgr := setupAdmissionLocal(t, "state-app", "state-local")
+	appMgr := app.NewAppManager("state-app")
+	release := make(chan struct{})
+	// Ignores cancellation, so the graceful phase lasts until the timeout
+	if err := localMgr.Go("stubborn", func(ctx context.Context) error {
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		appMgr.ShutdownWithTimeout(true, 500*time.Millisecond)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	if state := appMgr.GetState(); state != types.StateDraining {
+		t.Errorf("Expected the app manager to be draining, got %s", state)
+	}
+	if state := localMgr.GetState(); state != types.StateDraining {
+		t.Errorf("Expected the local manager to be draining, got %s", state)
+	}
+	var closed *goerrors.ManagerClosedError
+	if err := localMgr.Go("late", blockingWorker(release)); !errors.As(err, &closed) || closed.State != "draining" {
+		t.Errorf("Expected Go() to be rejected while draining, got %v", err)
+	}
+	if _, err := appMgr.NewLocalManager("late-local"); !errors.As(err, &closed) || closed.App != "state-app" || closed.Local != "" {
+		t.Errorf("Expected NewLocalManager() to be rejected while draining, got %v", err)
+	}
+	if _, err := appMgr.CreateApp(); !errors.Is(err, goerrors.ErrManagerClosed) {
+		t.Errorf("Expected CreateApp() to be rejected while draining, got %v", err)
+	}
+
+	close(release)
+	<-shutdownDone
+	if state := appMgr.GetState(); state != types.StateStopped {
+		t.Errorf("Expected the app manager to be stopped, got %s", state)
+	}
+
+	// Re-create the app manager, then its local manager
+	if _, err := appMgr.CreateApp(); err != nil {
+		t.Fatalf("CreateApp() failed: %v", err)
+	}
+	if err := localMgr.Go("late", func(ctx context.Context) error { return nil }); !errors.Is(err, goerrors.ErrManagerClosed) {
+		t.Errorf("Expected the stopped local manager to reject Go() until it is reopened, got %v", err)
+	}
+	reopened, err := appMgr.NewLocalManager("state-local")
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	if err := reopened.Go("late", func(ctx context.Context) error { return nil }); err != nil {
+		t.Errorf("Go() after re-creating failed: %v", err)
+	}
+
+	fmt.Println("✓ Draining app managers reject new work")
+}
+
+// TestState_GlobalStopped tests that a stopped global manager rejects new app managers until Init reopens it
+func TestState_GlobalStopped(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestState_GlobalStopped ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "state-app", "state-local")
+	if err := orch.Shutdown(false); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+	if state := orch.GetState(); state != types.StateStopped {
+		t.Errorf("Expected the global manager to be stopped, got %s", state)
+	}
+
+	var closed *goerrors.ManagerClosedError
+	if _, err := orch.NewAppManager("other-app"); !errors.As(err, &closed) || closed.App != "" || closed.State != "stopped" {
+		t.Errorf("Expected NewAppManager() to be rejected, got %v", err)
+	}
+	if err := localMgr.Go("late", func(ctx context.Context) error { return nil }); !errors.Is(err, goerrors.ErrManagerClosed) {
+		t.Errorf("Expected Go() to be rejected, got %v", err)
+	}
+
+	// Reopen the tree from the top
+	if _, err := orch.Init(); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	reopened, err := orch.NewLocalManager("state-app", "state-local")
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	var id string
+	if err := reopened.Go("late", func(ctx context.Context) error { return ctx.Err() }, local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() after reopening failed: %v", err)
+	}
+	if result, err := reopened.WaitForRoutineResult(id, time.Second); err != nil || result.Err != nil {
+		t.Errorf("Expected the routine to run with a live context, got %v, %v", result, err)
+	}
+
+	fmt.Println("✓ Stopped global managers are reopened by Init")
+}
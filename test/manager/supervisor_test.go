@@ -0,0 +1,207 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+)
+
+// TestSupervisor_RestartOnFailure tests that a failing worker is restarted until it succeeds
+func TestSupervisor_RestartOnFailure(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestSupervisor_RestartOnFailure ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "supervisor-app", "supervisor-local")
+
+	var runs atomic.Int32
+	var id string
+	err := localMgr.Go("flaky", func(ctx context.Context) error {
+		if runs.Add(1) < 3 {
+			return errors.New("transient failure")
+		}
+		return nil
+	}, local.CaptureRoutineID(&id), local.WithRestart(local.RestartPolicy{
+		Mode:           local.RestartOnFailure,
+		InitialBackoff: 10 * time.Millisecond,
+	}))
+	if err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	result, err := localMgr.WaitForRoutineResult(id, 2*time.Second)
+	if err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+	if result.Err != nil {
+		t.Errorf("Expected final run to succeed, got %v", result.Err)
+	}
+	if runs.Load() != 3 {
+		t.Errorf("Expected 3 runs, got %d", runs.Load())
+	}
+
+	fmt.Println("✓ Failing worker restarted until success")
+}
+
+// TestSupervisor_BudgetExhausted tests that the escalation hook runs when the restart budget is used up
+func TestSupervisor_BudgetExhausted(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestSupervisor_BudgetExhausted ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "supervisor-app", "supervisor-local")
+
+	var runs atomic.Int32
+	escalated := make(chan int, 1)
+	var id string
+	errPanic := "always panics"
+	err := localMgr.Go("crasher", func(ctx context.Context) error {
+		runs.Add(1)
+		panic(errPanic)
+	}, local.CaptureRoutineID(&id), local.WithRestart(local.RestartPolicy{
+		Mode:           local.RestartOnFailure,
+		InitialBackoff: 5 * time.Millisecond,
+		Jitter:         0.5,
+		MaxRestarts:    3,
+		Window:         time.Minute,
+		OnBudgetExhausted: func(functionName, routineID string, restarts int, lastErr error) {
+			escalated <- restarts
+		},
+	}))
+	if err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	select {
+	case restarts := <-escalated:
+		if restarts != 3 {
+			t.Errorf("Expected escalation after 3 restarts, got %d", restarts)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Escalation hook was not called")
+	}
+
+	result, err := localMgr.WaitForRoutineResult(id, time.Second)
+	if err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+	if !result.Panicked {
+		t.Error("Expected final result to be a panic")
+	}
+	if runs.Load() != 4 {
+		t.Errorf("Expected 4 runs (1 + 3 restarts), got %d", runs.Load())
+	}
+
+	fmt.Println("✓ Restart budget escalates")
+}
+
+// TestSupervisor_CancelStopsRestarts tests that a cancelled routine keeps its identity and is not restarted
+func TestSupervisor_CancelStopsRestarts(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestSupervisor_CancelStopsRestarts ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "supervisor-app", "supervisor-local")
+
+	contexts := make(chan context.Context, 10)
+	var id string
+	err := localMgr.Go("poller", func(ctx context.Context) error {
+		contexts <- ctx
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(20 * time.Millisecond):
+			return nil
+		}
+	}, local.CaptureRoutineID(&id), local.WithRestart(local.RestartPolicy{
+		Mode:           local.RestartAlways,
+		InitialBackoff: 5 * time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}))
+	if err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	first := <-contexts
+	second := <-contexts
+	if first == second {
+		t.Error("Each run should get a fresh context")
+	}
+	if first.Err() == nil {
+		t.Error("Previous run's context should be cancelled")
+	}
+
+	routine, err := localMgr.GetRoutine(id)
+	if err != nil {
+		t.Fatalf("Routine should keep its ID across restarts: %v", err)
+	}
+	if routine.GetRestarts() < 1 {
+		t.Errorf("Expected at least 1 restart, got %d", routine.GetRestarts())
+	}
+
+	if err := localMgr.CancelRoutine(id); err != nil {
+		t.Fatalf("CancelRoutine() failed: %v", err)
+	}
+	result, err := localMgr.WaitForRoutineResult(id, time.Second)
+	if err != nil {
+		t.Fatalf("Cancelled supervised routine should stop: %v", err)
+	}
+	if !errors.Is(result.Err, context.Canceled) {
+		t.Errorf("Expected context.Canceled from the last run, got %v", result.Err)
+	}
+
+	fmt.Println("✓ Cancel stops the supervisor")
+}
+
+// TestSupervisor_StableRunResetsBackoff tests that the backoff starts over after a run that stayed
+// up for longer than MaxBackoff
+func TestSupervisor_StableRunResetsBackoff(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestSupervisor_StableRunResetsBackoff ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "supervisor-app", "supervisor-local")
+
+	const stableRun = 250 * time.Millisecond
+	var runs atomic.Int32
+	var stableEnd atomic.Int64
+	restarted := make(chan time.Time, 1)
+	var id string
+	err := localMgr.Go("consumer", func(ctx context.Context) error {
+		switch runs.Add(1) {
+		case 1, 2, 3:
+			// Backoffs 20ms, 80ms and 200ms (capped)
+			return errors.New("transient failure")
+		case 4:
+			time.Sleep(stableRun)
+			stableEnd.Store(time.Now().UnixNano())
+			return errors.New("failure after a stable run")
+		default:
+			restarted <- time.Now()
+			return nil
+		}
+	}, local.CaptureRoutineID(&id), local.WithRestart(local.RestartPolicy{
+		Mode:           local.RestartOnFailure,
+		InitialBackoff: 20 * time.Millisecond,
+		MaxBackoff:     200 * time.Millisecond,
+		Multiplier:     4,
+	}))
+	if err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	select {
+	case at := <-restarted:
+		if gap := at.Sub(time.Unix(0, stableEnd.Load())); gap > 120*time.Millisecond {
+			t.Errorf("Expected the initial backoff after a stable run, restarted after %v", gap)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Routine was not restarted")
+	}
+	if _, err := localMgr.WaitForRoutineResult(id, time.Second); err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+
+	fmt.Println("✓ Stable run resets the backoff")
+}
@@ -0,0 +1,309 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// TestWorkerPool_FixedSize tests that a fixed pool runs every task on tracked workers
+func TestWorkerPool_FixedSize(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestWorkerPool_FixedSize ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "pool-app", "pool-local")
+	pool, err := localMgr.NewWorkerPool("jobs", types.WorkerPoolConfig{MinWorkers: 3, QueueSize: 10})
+	if err != nil {
+		t.Fatalf("NewWorkerPool() failed: %v", err)
+	}
+
+	workers, err := localMgr.GetRoutinesByFunctionName("jobs")
+	if err != nil {
+		t.Fatalf("GetRoutinesByFunctionName() failed: %v", err)
+	}
+	if len(workers) != 3 {
+		t.Errorf("Expected 3 tracked workers, got %d", len(workers))
+	}
+
+	var ran atomic.Int32
+	for i := 0; i < 50; i++ {
+		if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			ran.Add(1)
+			return nil
+		}); err != nil {
+			t.Fatalf("Submit() failed: %v", err)
+		}
+	}
+
+	if err := pool.Drain(2 * time.Second); err != nil {
+		t.Fatalf("Drain() failed: %v", err)
+	}
+	if ran.Load() != 50 {
+		t.Errorf("Expected 50 tasks to run, got %d", ran.Load())
+	}
+	if _, err := localMgr.GetWorkerPool("jobs"); !errors.Is(err, goerrors.ErrWorkerPoolNotFound) {
+		t.Errorf("Drained pool should be removed, got %v", err)
+	}
+	if err := pool.TrySubmit(func(ctx context.Context) error { return nil }); !errors.Is(err, goerrors.ErrWorkerPoolClosed) {
+		t.Errorf("Expected ErrWorkerPoolClosed after Drain(), got %v", err)
+	}
+
+	fmt.Println("✓ Fixed pool runs all tasks")
+}
+
+// TestWorkerPool_Backpressure tests TrySubmit and Submit when the queue is full
+func TestWorkerPool_Backpressure(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestWorkerPool_Backpressure ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "pool-app", "pool-local")
+	pool, err := localMgr.NewWorkerPool("slow", types.WorkerPoolConfig{MinWorkers: 1, QueueSize: 1})
+	if err != nil {
+		t.Fatalf("NewWorkerPool() failed: %v", err)
+	}
+	defer pool.Stop()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	<-started
+
+	// Worker is busy - the single queue slot takes one more task
+	if err := pool.TrySubmit(func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("TrySubmit() into empty queue failed: %v", err)
+	}
+	if err := pool.TrySubmit(func(ctx context.Context) error { return nil }); !errors.Is(err, goerrors.ErrWorkerPoolFull) {
+		t.Errorf("Expected ErrWorkerPoolFull, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := pool.Submit(ctx, func(ctx context.Context) error { return nil }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected Submit() to block until the deadline, got %v", err)
+	}
+
+	close(release)
+	fmt.Println("✓ Full queue applies backpressure")
+}
+
+// TestWorkerPool_SubmitWait tests that SubmitWait returns the task's error, including panics
+func TestWorkerPool_SubmitWait(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestWorkerPool_SubmitWait ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "pool-app", "pool-local")
+	pool, err := localMgr.NewWorkerPool("waiters", types.WorkerPoolConfig{MinWorkers: 1})
+	if err != nil {
+		t.Fatalf("NewWorkerPool() failed: %v", err)
+	}
+	defer pool.Stop()
+
+	errTask := errors.New("task failed")
+	if err := pool.SubmitWait(context.Background(), func(ctx context.Context) error {
+		return errTask
+	}); !errors.Is(err, errTask) {
+		t.Errorf("Expected task error, got %v", err)
+	}
+
+	if err := pool.SubmitWait(context.Background(), func(ctx context.Context) error {
+		panic("boom")
+	}); !errors.Is(err, goerrors.ErrRoutinePanicked) {
+		t.Errorf("Expected ErrRoutinePanicked, got %v", err)
+	}
+
+	// The worker survives a panicking task
+	if pool.GetWorkerCount() != 1 {
+		t.Errorf("Expected 1 worker after a panicking task, got %d", pool.GetWorkerCount())
+	}
+
+	fmt.Println("✓ SubmitWait returns task errors")
+}
+
+// TestWorkerPool_AutoScale tests that an auto-scaling pool grows under load and shrinks when idle
+func TestWorkerPool_AutoScale(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestWorkerPool_AutoScale ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "pool-app", "pool-local")
+	pool, err := localMgr.NewWorkerPool("elastic", types.WorkerPoolConfig{
+		MinWorkers:  1,
+		MaxWorkers:  4,
+		QueueSize:   16,
+		IdleTimeout: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewWorkerPool() failed: %v", err)
+	}
+	defer pool.Stop()
+
+	release := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			<-release
+			return nil
+		}); err != nil {
+			t.Fatalf("Submit() failed: %v", err)
+		}
+	}
+	if pool.GetWorkerCount() != 4 {
+		t.Errorf("Expected pool to scale to 4 workers, got %d", pool.GetWorkerCount())
+	}
+
+	close(release)
+	deadline := time.Now().Add(2 * time.Second)
+	for pool.GetWorkerCount() > 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if pool.GetWorkerCount() != 1 {
+		t.Errorf("Expected pool to shrink to 1 worker, got %d", pool.GetWorkerCount())
+	}
+
+	fmt.Println("✓ Pool scales up and down")
+}
+
+// TestWorkerPool_ShutdownDrains tests that a safe local shutdown runs the queued tasks
+func TestWorkerPool_ShutdownDrains(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestWorkerPool_ShutdownDrains ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "pool-app", "pool-local")
+	pool, err := localMgr.NewWorkerPool("drained", types.WorkerPoolConfig{MinWorkers: 2, QueueSize: 20})
+	if err != nil {
+		t.Fatalf("NewWorkerPool() failed: %v", err)
+	}
+
+	var ran atomic.Int32
+	for i := 0; i < 20; i++ {
+		if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			ran.Add(1)
+			return nil
+		}); err != nil {
+			t.Fatalf("Submit() failed: %v", err)
+		}
+	}
+
+	if err := localMgr.Shutdown(true); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+	if ran.Load() != 20 {
+		t.Errorf("Expected all 20 queued tasks to run, got %d", ran.Load())
+	}
+	if localMgr.GetGoroutineCount() != 0 {
+		t.Errorf("Expected no workers after shutdown, got %d", localMgr.GetGoroutineCount())
+	}
+
+	fmt.Println("✓ Safe shutdown drains the pool")
+}
+
+// TestWorkerPool_ConcurrentCreate tests that concurrent NewWorkerPool calls with the same name
+// return one pool and start its workers once
+func TestWorkerPool_ConcurrentCreate(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestWorkerPool_ConcurrentCreate ===")
+
+	orch, localMgr := setupOrchestratorLocal(t, "pool-create-app", "pool-create-local")
+	defer orch.Shutdown(false)
+
+	// Hold the first worker's start so the other callers run while the first pool is being created
+	var held sync.Once
+	unsubscribe := orch.Subscribe(types.SubscriberFunc(func(event types.Event) {
+		if event.Type == types.EventRoutineStarted && event.AppName == "pool-create-app" {
+			held.Do(func() { time.Sleep(50 * time.Millisecond) })
+		}
+	}))
+	defer unsubscribe()
+
+	const callers = 16
+	pools := make([]interfaces.WorkerPool, callers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			pool, err := localMgr.NewWorkerPool("shared", types.WorkerPoolConfig{MinWorkers: 2})
+			if err != nil {
+				t.Errorf("NewWorkerPool() failed: %v", err)
+				return
+			}
+			pools[i] = pool
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for i := 1; i < callers; i++ {
+		if pools[i] != pools[0] {
+			t.Fatalf("Expected every caller to get the same pool")
+		}
+	}
+	workers, err := localMgr.GetRoutinesByFunctionName("shared")
+	if err != nil {
+		t.Fatalf("GetRoutinesByFunctionName() failed: %v", err)
+	}
+	if len(workers) != 2 {
+		t.Errorf("Expected 2 tracked workers, got %d", len(workers))
+	}
+
+	fmt.Println("✓ Concurrent creation starts one pool")
+}
+
+// TestWorkerPool_ShutdownDeadline tests that pool draining and the graceful phase of a safe local
+// shutdown share its timeout instead of each waiting for all of it
+func TestWorkerPool_ShutdownDeadline(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestWorkerPool_ShutdownDeadline ===")
+
+	_, localMgr := setupOrchestratorLocal(t, "pool-deadline-app", "pool-deadline-local")
+	pool, err := localMgr.NewWorkerPool("stubborn", types.WorkerPoolConfig{MinWorkers: 1, QueueSize: 1})
+	if err != nil {
+		t.Fatalf("NewWorkerPool() failed: %v", err)
+	}
+
+	// Both ignore cancellation until the test ends
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Submit() failed: %v", err)
+	}
+	<-started
+	if err := localMgr.Go("stubborn-routine", func(ctx context.Context) error {
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+
+	const timeout = 200 * time.Millisecond
+	start := time.Now()
+	if _, err := localMgr.ShutdownWithTimeout(true, timeout); err != nil {
+		t.Fatalf("ShutdownWithTimeout() failed: %v", err)
+	}
+	// The timeout plus the wait for force-cancelled routines, with some slack - not once per phase
+	if elapsed := time.Since(start); elapsed > 2*timeout+100*time.Millisecond {
+		t.Errorf("Expected the shutdown to take about %v, took %v", timeout, elapsed)
+	}
+
+	fmt.Println("✓ Shutdown phases share the timeout")
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/leak"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/app"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
 	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
@@ -69,8 +70,8 @@ func TestLocalManager_SafeShutdown_WithHangingGoroutines(t *testing.T) {
 	}
 	fmt.Printf("✓ Safe shutdown completed in %v\n", elapsed)
 
-	// Wait a bit for cancellations to propagate
-	time.Sleep(100 * time.Millisecond)
+	// Every routine must have exited - waits for cancellations to propagate
+	leak.VerifyNoLeaks(t, leak.ForApp("test-app"))
 
 	// Verify normal goroutines completed
 	if normalCompleted.Load() != 3 {
@@ -142,8 +143,8 @@ func TestLocalManager_SafeShutdown_AllHanging(t *testing.T) {
 	}
 	fmt.Printf("✓ Safe shutdown completed in %v\n", elapsed)
 
-	// Wait for cancellations
-	time.Sleep(100 * time.Millisecond)
+	// Every routine must have exited - waits for cancellations to propagate
+	leak.VerifyNoLeaks(t, leak.ForApp("test-app"))
 
 	// All should be cancelled
 	if cancelled.Load() != 5 {
@@ -0,0 +1,171 @@
+package shutdown_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/app"
+	goerrors "github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+)
+
+// stopRecorder records the order in which workers observe cancellation
+type stopRecorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (SR *stopRecorder) record(name string) {
+	SR.mu.Lock()
+	defer SR.mu.Unlock()
+	SR.order = append(SR.order, name)
+}
+
+func (SR *stopRecorder) get() []string {
+	SR.mu.Lock()
+	defer SR.mu.Unlock()
+	return append([]string(nil), SR.order...)
+}
+
+// spawnRecorded starts a worker on localMgr that records name when it is cancelled
+func spawnRecorded(t *testing.T, localMgr interfaces.LocalGoroutineManagerInterface, recorder *stopRecorder, name string) {
+	t.Helper()
+	if err := localMgr.Go("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		recorder.record(name)
+		return nil
+	}, local.AddToWaitGroup("worker")); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+}
+
+// TestShutdownOrder_LocalDependencies tests that local managers shut down after their dependencies
+func TestShutdownOrder_LocalDependencies(t *testing.T) {
+	fmt.Println("\n=== TestShutdownOrder_LocalDependencies ===")
+	common.ResetGlobalState()
+
+	appMgr := app.NewAppManager("order-app")
+	if _, err := appMgr.CreateApp(); err != nil {
+		t.Fatalf("CreateApp() failed: %v", err)
+	}
+
+	recorder := &stopRecorder{}
+	locals := make(map[string]interfaces.LocalGoroutineManagerInterface)
+	for _, name := range []string{"writers", "consumers", "ingress"} {
+		localMgr := local.NewLocalManager("order-app", name)
+		if _, err := localMgr.CreateLocal(name); err != nil {
+			t.Fatalf("CreateLocal(%s) failed: %v", name, err)
+		}
+		spawnRecorded(t, localMgr, recorder, name)
+		locals[name] = localMgr
+	}
+
+	if err := locals["consumers"].ShutdownAfter("ingress"); err != nil {
+		t.Fatalf("ShutdownAfter() failed: %v", err)
+	}
+	if err := locals["writers"].ShutdownAfter("consumers"); err != nil {
+		t.Fatalf("ShutdownAfter() failed: %v", err)
+	}
+
+	report, err := appMgr.ShutdownWithReport(true)
+	if err != nil {
+		t.Fatalf("ShutdownWithReport() failed: %v", err)
+	}
+
+	expected := []string{"ingress", "consumers", "writers"}
+	if got := recorder.get(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected shutdown order %v, got %v", expected, got)
+	}
+	if len(report.Phases) != 3 || report.Phases[2].Name != "local_managers_phase_3" {
+		t.Errorf("Expected 3 local manager phases, got %v", report.Phases)
+	}
+
+	fmt.Println("✓ Local managers shut down in dependency order")
+}
+
+// TestShutdownOrder_Cycle tests that a dependency cycle is rejected when declared
+func TestShutdownOrder_Cycle(t *testing.T) {
+	fmt.Println("\n=== TestShutdownOrder_Cycle ===")
+	common.ResetGlobalState()
+
+	appMgr := app.NewAppManager("cycle-app")
+	if _, err := appMgr.CreateApp(); err != nil {
+		t.Fatalf("CreateApp() failed: %v", err)
+	}
+	locals := make(map[string]interfaces.LocalGoroutineManagerInterface)
+	for _, name := range []string{"a", "b", "c"} {
+		localMgr := local.NewLocalManager("cycle-app", name)
+		if _, err := localMgr.CreateLocal(name); err != nil {
+			t.Fatalf("CreateLocal(%s) failed: %v", name, err)
+		}
+		locals[name] = localMgr
+	}
+
+	if err := locals["b"].ShutdownAfter("a"); err != nil {
+		t.Fatalf("ShutdownAfter() failed: %v", err)
+	}
+	if err := locals["c"].ShutdownAfter("b"); err != nil {
+		t.Fatalf("ShutdownAfter() failed: %v", err)
+	}
+	if err := locals["a"].ShutdownAfter("c"); !errors.Is(err, goerrors.ErrShutdownDependencyCycle) {
+		t.Errorf("Expected ErrShutdownDependencyCycle, got %v", err)
+	}
+	if err := locals["a"].ShutdownAfter("a"); !errors.Is(err, goerrors.ErrShutdownDependencyCycle) {
+		t.Errorf("Expected ErrShutdownDependencyCycle for a self dependency, got %v", err)
+	}
+	if after := locals["a"].GetShutdownOrder().After; len(after) != 0 {
+		t.Errorf("Rejected declaration should not be stored, got %v", after)
+	}
+
+	fmt.Println("✓ Dependency cycles are rejected")
+}
+
+// TestShutdownOrder_AppPriorities tests that the global shutdown stops app managers by priority
+func TestShutdownOrder_AppPriorities(t *testing.T) {
+	fmt.Println("\n=== TestShutdownOrder_AppPriorities ===")
+	common.ResetGlobalState()
+
+	globalMgr := global.NewGlobalManager()
+	if _, err := globalMgr.Init(); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	recorder := &stopRecorder{}
+	priorities := map[string]int{"storage": 2, "ingress": 0, "processing": 1}
+	for name, priority := range priorities {
+		appMgr := app.NewAppManager(name)
+		if _, err := appMgr.CreateApp(); err != nil {
+			t.Fatalf("CreateApp(%s) failed: %v", name, err)
+		}
+		if err := appMgr.SetShutdownPriority(priority); err != nil {
+			t.Fatalf("SetShutdownPriority() failed: %v", err)
+		}
+		localMgr := local.NewLocalManager(name, "main")
+		if _, err := localMgr.CreateLocal("main"); err != nil {
+			t.Fatalf("CreateLocal() failed: %v", err)
+		}
+		spawnRecorded(t, localMgr, recorder, name)
+	}
+
+	report, err := globalMgr.ShutdownWithReport(true)
+	if err != nil {
+		t.Fatalf("ShutdownWithReport() failed: %v", err)
+	}
+
+	expected := []string{"ingress", "processing", "storage"}
+	if got := recorder.get(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected shutdown order %v, got %v", expected, got)
+	}
+	if len(report.Phases) != 3 {
+		t.Errorf("Expected 3 app manager phases, got %v", report.Phases)
+	}
+
+	fmt.Println("✓ App managers shut down by priority")
+}
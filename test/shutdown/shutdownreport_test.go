@@ -0,0 +1,192 @@
+package shutdown_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/app"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/global"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/orchestrator"
+	common "github.com/JupiterMetaLabs/goroutine-orchestrator/test/common"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+)
+
+// findReport returns the first node of the report tree with the given level and name
+func findReport(report *types.ShutdownReport, level, name string) *types.ShutdownReport {
+	if report.Level == level && report.Name == name {
+		return report
+	}
+	for _, child := range report.Children {
+		if found := findReport(child, level, name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// TestShutdownReport_GracefulAndStuck tests that the report separates graceful and stuck routines
+func TestShutdownReport_GracefulAndStuck(t *testing.T) {
+	fmt.Println("\n=== TestShutdownReport_GracefulAndStuck ===")
+	common.ResetGlobalState()
+
+	globalMgr := global.NewGlobalManager()
+	if _, err := globalMgr.Init(); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+	previousTimeout := types.ShutdownTimeout
+	types.ShutdownTimeout = 200 * time.Millisecond
+	defer func() { types.ShutdownTimeout = previousTimeout }()
+
+	appMgr := app.NewAppManager("report-app")
+	if _, err := appMgr.CreateApp(); err != nil {
+		t.Fatalf("CreateApp() failed: %v", err)
+	}
+	localMgr := local.NewLocalManager("report-app", "report-local")
+	if _, err := localMgr.CreateLocal("report-local"); err != nil {
+		t.Fatalf("CreateLocal() failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		localMgr.Go("polite-worker", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, local.AddToWaitGroup("polite-worker"))
+	}
+
+	// Ignores cancellation for longer than the shutdown timeout
+	// Released later - app shutdown still waits for the local wait group after the report is built
+	release := make(chan struct{})
+	time.AfterFunc(time.Second, func() { close(release) })
+	var stuckID string
+	localMgr.Go("stuck-worker", func(ctx context.Context) error {
+		<-release
+		return nil
+	}, local.AddToWaitGroup("stuck-worker"), local.CaptureRoutineID(&stuckID))
+
+	report, err := globalMgr.ShutdownWithReport(true)
+	if err != nil {
+		t.Fatalf("ShutdownWithReport() failed: %v", err)
+	}
+
+	graceful, forceCancelled, stillRunning := report.Totals()
+	if graceful != 2 {
+		t.Errorf("Expected 2 graceful routines, got %d", graceful)
+	}
+	if forceCancelled+stillRunning != 1 {
+		t.Errorf("Expected 1 force-cancelled routine, got %d force-cancelled and %d still running", forceCancelled, stillRunning)
+	}
+	if report.Clean() {
+		t.Error("Report with a stuck routine should not be clean")
+	}
+
+	stuck := findReport(report, types.ShutdownLevelFunction, "stuck-worker")
+	if stuck == nil {
+		t.Fatal("Expected a function report for stuck-worker")
+	}
+	if len(stuck.StillRunning) != 1 || stuck.StillRunning[0] != stuckID {
+		t.Errorf("Expected stuck routine %s still running, got %v", stuckID, stuck.StillRunning)
+	}
+
+	localReport := findReport(report, types.ShutdownLevelLocal, "report-local")
+	if localReport == nil {
+		t.Fatal("Expected a local report for report-local")
+	}
+	if len(localReport.Errors) == 0 {
+		t.Error("Expected the ShutdownFunction timeout to be recorded")
+	}
+	phases := make(map[string]bool)
+	for _, phase := range localReport.Phases {
+		phases[phase.Name] = true
+	}
+	for _, name := range []string{"drain_pools", "graceful", "force_cancel"} {
+		if !phases[name] {
+			t.Errorf("Expected phase %s in local report, got %v", name, localReport.Phases)
+		}
+	}
+
+	fmt.Println("✓ Report separates graceful and stuck routines")
+}
+
+// TestShutdownReport_JSON tests that the report round-trips through JSON
+func TestShutdownReport_JSON(t *testing.T) {
+	fmt.Println("\n=== TestShutdownReport_JSON ===")
+	common.ResetGlobalState()
+
+	appMgr := app.NewAppManager("json-app")
+	if _, err := appMgr.CreateApp(); err != nil {
+		t.Fatalf("CreateApp() failed: %v", err)
+	}
+	localMgr := local.NewLocalManager("json-app", "json-local")
+	if _, err := localMgr.CreateLocal("json-local"); err != nil {
+		t.Fatalf("CreateLocal() failed: %v", err)
+	}
+	localMgr.Go("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, local.AddToWaitGroup("worker"))
+
+	report, err := appMgr.ShutdownWithReport(true)
+	if err != nil {
+		t.Fatalf("ShutdownWithReport() failed: %v", err)
+	}
+	if !report.Clean() {
+		t.Errorf("Expected a clean shutdown, got %s", report)
+	}
+
+	data, err := report.JSON()
+	if err != nil {
+		t.Fatalf("JSON() failed: %v", err)
+	}
+	var decoded types.ShutdownReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Report JSON does not decode: %v", err)
+	}
+	if decoded.Level != types.ShutdownLevelApp || decoded.Name != "json-app" {
+		t.Errorf("Unexpected decoded report root: %s/%s", decoded.Level, decoded.Name)
+	}
+	if decoded.Duration != report.Duration {
+		t.Errorf("Expected duration %v to round-trip, got %v", report.Duration, decoded.Duration)
+	}
+	if g, _, _ := decoded.Totals(); g != 1 {
+		t.Errorf("Expected 1 graceful routine after decoding, got %d", g)
+	}
+
+	fmt.Println("✓ Report round-trips through JSON")
+}
+
+// TestShutdownReport_ForceCancelled tests that cooperative routines cancelled by an unsafe shutdown
+// are reported as force-cancelled, not as still running
+func TestShutdownReport_ForceCancelled(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestShutdownReport_ForceCancelled ===")
+
+	orch := orchestrator.New()
+	defer orch.Shutdown(false)
+	localMgr, err := orch.NewLocalManager("force-app", "force-local")
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := localMgr.Go("cooperative-worker", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}); err != nil {
+			t.Fatalf("Go() failed: %v", err)
+		}
+	}
+
+	report, err := localMgr.ShutdownWithReport(false)
+	if err != nil {
+		t.Fatalf("ShutdownWithReport() failed: %v", err)
+	}
+	graceful, forceCancelled, stillRunning := report.Totals()
+	if graceful != 0 || forceCancelled != 5 || stillRunning != 0 {
+		t.Errorf("Expected 5 force-cancelled routines, got graceful %d force %d still %d", graceful, forceCancelled, stillRunning)
+	}
+
+	fmt.Println("✓ Cancelled cooperative routines are force-cancelled")
+}
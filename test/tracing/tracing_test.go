@@ -0,0 +1,248 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/local"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/orchestrator"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// setupTracing creates an orchestrator with a local manager, traced into an in-memory exporter
+func setupTracing(t *testing.T, appName, localName string, link bool) (*orchestrator.Orchestrator, interfaces.LocalGoroutineManagerInterface, *sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	orch := orchestrator.New()
+	localMgr, err := orch.NewLocalManager(appName, localName)
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+	uninstall, err := tracing.Install(orch, tracing.Config{TracerProvider: provider, Link: link})
+	if err != nil {
+		t.Fatalf("Install() failed: %v", err)
+	}
+	t.Cleanup(uninstall)
+	return orch, localMgr, provider, exporter
+}
+
+// runRoutine spawns worker and waits for its result
+func runRoutine(t *testing.T, localMgr interfaces.LocalGoroutineManagerInterface, functionName string, worker func(ctx context.Context) error, opts ...interfaces.GoroutineOption) {
+	t.Helper()
+	var id string
+	opts = append(opts, local.CaptureRoutineID(&id))
+	if err := localMgr.Go(functionName, worker, opts...); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if _, err := localMgr.WaitForRoutineResult(id, 2*time.Second); err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+}
+
+// findSpan returns the exported span named name
+func findSpan(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range exporter.GetSpans() {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("No span named %s", name)
+	return tracetest.SpanStub{}
+}
+
+// hasEvent reports whether span recorded an event named name
+func hasEvent(span tracetest.SpanStub, name string) bool {
+	for _, event := range span.Events {
+		if event.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeOf returns the value of the attribute key of span
+func attributeOf(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+// TestTracing_RoutineSpans tests that each worker run is a span named after its function, a child of
+// the span passed at spawn time, recording errors, panics, timeouts and cancellations
+func TestTracing_RoutineSpans(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestTracing_RoutineSpans ===")
+
+	orch, localMgr, provider, exporter := setupTracing(t, "trace-app", "trace-local", false)
+	defer orch.Shutdown(false)
+
+	parentCtx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	var workerSpan trace.SpanContext
+	worker := func(ctx context.Context) error {
+		workerSpan = trace.SpanContextFromContext(ctx)
+		return errors.New("send failed")
+	}
+	runRoutine(t, localMgr, "send-email", worker, local.WithContextValues(parentCtx))
+	parent.End()
+
+	span := findSpan(t, exporter, "send-email")
+	if span.Parent.SpanID() != parent.SpanContext().SpanID() || span.SpanContext.TraceID() != parent.SpanContext().TraceID() {
+		t.Errorf("Expected a child of the request span, got parent %v", span.Parent)
+	}
+	if span.SpanContext.SpanID() != workerSpan.SpanID() {
+		t.Errorf("Expected the worker's context to carry its span")
+	}
+	if span.Status.Code != codes.Error || !hasEvent(span, "exception") {
+		t.Errorf("Expected the error to be recorded, got status %v and events %v", span.Status, span.Events)
+	}
+	if got := attributeOf(span, tracing.AttrApp).AsString(); got != "trace-app" {
+		t.Errorf("Expected app attribute trace-app, got %q", got)
+	}
+
+	runRoutine(t, localMgr, "panicky", func(ctx context.Context) error { panic("boom") })
+	if span := findSpan(t, exporter, "panicky"); span.Status.Code != codes.Error || !hasEvent(span, tracing.EventPanic) {
+		t.Errorf("Expected the panic to be recorded, got status %v and events %v", span.Status, span.Events)
+	}
+
+	waitForCancel := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	runRoutine(t, localMgr, "slow", waitForCancel, local.WithTimeout(10*time.Millisecond))
+	if span := findSpan(t, exporter, "slow"); !hasEvent(span, tracing.EventTimeout) {
+		t.Errorf("Expected a timeout event, got %v", span.Events)
+	}
+
+	var id string
+	if err := localMgr.Go("cancelled", waitForCancel, local.CaptureRoutineID(&id)); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if err := localMgr.CancelRoutine(id); err != nil {
+		t.Fatalf("CancelRoutine() failed: %v", err)
+	}
+	if _, err := localMgr.WaitForRoutineResult(id, 2*time.Second); err != nil {
+		t.Fatalf("WaitForRoutineResult() failed: %v", err)
+	}
+	if span := findSpan(t, exporter, "cancelled"); !hasEvent(span, tracing.EventCancelled) {
+		t.Errorf("Expected a cancelled event, got %v", span.Events)
+	}
+
+	fmt.Println("✓ Worker runs are recorded as spans with their outcome")
+}
+
+// TestTracing_Link tests that with Link, routine spans start new traces linked to the spawning span
+func TestTracing_Link(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestTracing_Link ===")
+
+	orch, localMgr, provider, exporter := setupTracing(t, "link-app", "link-local", true)
+	defer orch.Shutdown(false)
+
+	parentCtx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	parent.End()
+	runRoutine(t, localMgr, "background", func(ctx context.Context) error { return nil }, local.WithContextValues(parentCtx))
+
+	span := findSpan(t, exporter, "background")
+	if span.Parent.IsValid() || span.SpanContext.TraceID() == parent.SpanContext().TraceID() {
+		t.Errorf("Expected a new trace, got parent %v", span.Parent)
+	}
+	if len(span.Links) != 1 || span.Links[0].SpanContext.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("Expected a link to the request span, got %v", span.Links)
+	}
+	if span.Status.Code == codes.Error {
+		t.Errorf("Expected no error status, got %v", span.Status)
+	}
+
+	fmt.Println("✓ Linked routine spans start new traces")
+}
+
+// TestTracing_Uninstall tests that uninstall removes the interceptor, so install cycles do not grow
+// the chain and later runs are not traced
+func TestTracing_Uninstall(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestTracing_Uninstall ===")
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	orch := orchestrator.New()
+	defer orch.Shutdown(false)
+	localMgr, err := orch.NewLocalManager("uninstall-app", "uninstall-local")
+	if err != nil {
+		t.Fatalf("NewLocalManager() failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		uninstall, err := tracing.Install(orch, tracing.Config{TracerProvider: provider})
+		if err != nil {
+			t.Fatalf("Install() %d failed: %v", i, err)
+		}
+		if interceptors := orch.GlobalManager().GetInterceptors(); len(interceptors) != 1 {
+			t.Fatalf("Expected 1 interceptor while installed, got %d", len(interceptors))
+		}
+		uninstall()
+	}
+	if interceptors := orch.GlobalManager().GetInterceptors(); len(interceptors) != 0 {
+		t.Errorf("Expected no interceptors after uninstall, got %d", len(interceptors))
+	}
+
+	runRoutine(t, localMgr, "untraced", func(ctx context.Context) error { return nil })
+	if spans := exporter.GetSpans(); len(spans) != 0 {
+		t.Errorf("Expected no spans after uninstall, got %d", len(spans))
+	}
+
+	fmt.Println("✓ Uninstall removes the tracing interceptor")
+}
+
+// TestTracing_ShutdownSpans tests that global, app and local shutdowns are nested spans with a
+// child span per shutdown phase.
+func TestTracing_ShutdownSpans(t *testing.T) {
+	t.Parallel()
+	fmt.Println("\n=== TestTracing_ShutdownSpans ===")
+
+	orch, localMgr, _, exporter := setupTracing(t, "shutdown-app", "shutdown-local", false)
+	if err := localMgr.Go("worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}); err != nil {
+		t.Fatalf("Go() failed: %v", err)
+	}
+	if err := orch.Shutdown(true); err != nil {
+		t.Fatalf("Shutdown() failed: %v", err)
+	}
+
+	global := findSpan(t, exporter, "shutdown.global")
+	app := findSpan(t, exporter, "shutdown.app")
+	localSpan := findSpan(t, exporter, "shutdown.local")
+	if app.Parent.SpanID() != global.SpanContext.SpanID() || localSpan.Parent.SpanID() != app.SpanContext.SpanID() {
+		t.Errorf("Expected local within app within global shutdown spans")
+	}
+	if got := attributeOf(localSpan, tracing.AttrLocal).AsString(); got != "shutdown-local" {
+		t.Errorf("Expected local attribute shutdown-local, got %q", got)
+	}
+	if got := attributeOf(localSpan, tracing.AttrGraceful).AsInt64(); got != 1 {
+		t.Errorf("Expected 1 graceful routine, got %d", got)
+	}
+	graceful := findSpan(t, exporter, "graceful")
+	if graceful.Parent.SpanID() != localSpan.SpanContext.SpanID() {
+		t.Errorf("Expected the graceful phase within the local shutdown span")
+	}
+	if graceful.StartTime.Before(localSpan.StartTime) || graceful.EndTime.After(localSpan.EndTime) {
+		t.Errorf("Expected the graceful phase within the local shutdown's time range")
+	}
+
+	fmt.Println("✓ Shutdowns are recorded as nested spans with their phases")
+}
@@ -0,0 +1,144 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ShutdownTracer is the event subscriber recording manager shutdowns and drains as spans named
+// "shutdown.global", "shutdown.app" and "shutdown.local". An app shutdown within a global shutdown
+// is a child of its span, a local shutdown a child of its app's. Each shutdown phase of the report
+// (e.g. graceful wait, force cancel) is a child span of the shutdown.
+type ShutdownTracer struct {
+	tracer trace.Tracer
+
+	mu     sync.Mutex
+	active map[shutdownKey][]trace.Span // Spans of the shutdowns in progress (a stack per manager)
+}
+
+// shutdownKey identifies the manager a shutdown event belongs to
+type shutdownKey struct {
+	manager   string
+	appName   string
+	localName string
+}
+
+// NewShutdownTracer creates a ShutdownTracer - subscribe it with Subscribe (see Install)
+func NewShutdownTracer(config Config) *ShutdownTracer {
+	return &ShutdownTracer{
+		tracer: config.tracer(),
+		active: make(map[shutdownKey][]trace.Span),
+	}
+}
+
+// OnEvent starts a span when a shutdown begins and ends it when the shutdown finishes
+func (ST *ShutdownTracer) OnEvent(event types.Event) {
+	switch event.Type {
+	case types.EventShutdownBegan:
+		ST.begin(event)
+	case types.EventShutdownFinished:
+		ST.finish(event)
+	case types.EventForceCancel:
+		if span := ST.current(keyOf(event)); span != nil {
+			span.AddEvent(EventForceCancel, trace.WithTimestamp(event.Time), trace.WithAttributes(AttrCount.Int(event.Count)))
+		}
+	}
+}
+
+// keyOf returns the manager of a shutdown event
+func keyOf(event types.Event) shutdownKey {
+	return shutdownKey{manager: event.Manager, appName: event.AppName, localName: event.LocalName}
+}
+
+// parentOf returns the key of the shutdown a shutdown of key runs within, if any
+func parentOf(key shutdownKey) (shutdownKey, bool) {
+	switch key.manager {
+	case types.EventManagerLocal:
+		return shutdownKey{manager: types.EventManagerApp, appName: key.appName}, true
+	case types.EventManagerApp:
+		return shutdownKey{manager: types.EventManagerGlobal}, true
+	}
+	return shutdownKey{}, false
+}
+
+// current returns the span of the latest shutdown of key in progress, or nil
+func (ST *ShutdownTracer) current(key shutdownKey) trace.Span {
+	ST.mu.Lock()
+	defer ST.mu.Unlock()
+	if spans := ST.active[key]; len(spans) > 0 {
+		return spans[len(spans)-1]
+	}
+	return nil
+}
+
+// begin starts the span of a shutdown, as a child of the enclosing shutdown in progress
+func (ST *ShutdownTracer) begin(event types.Event) {
+	key := keyOf(event)
+	ctx := context.Background()
+	for parentKey, ok := parentOf(key); ok; parentKey, ok = parentOf(parentKey) {
+		if parent := ST.current(parentKey); parent != nil {
+			ctx = trace.ContextWithSpan(ctx, parent)
+			break
+		}
+	}
+	_, span := ST.tracer.Start(ctx, "shutdown."+event.Manager,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithTimestamp(event.Time),
+		trace.WithAttributes(
+			AttrManager.String(event.Manager),
+			AttrApp.String(event.AppName),
+			AttrLocal.String(event.LocalName),
+			AttrSafe.Bool(event.Safe),
+		),
+	)
+
+	ST.mu.Lock()
+	defer ST.mu.Unlock()
+	ST.active[key] = append(ST.active[key], span)
+}
+
+// finish records the phases and outcome of a shutdown from its report and ends its span
+func (ST *ShutdownTracer) finish(event types.Event) {
+	key := keyOf(event)
+	ST.mu.Lock()
+	spans := ST.active[key]
+	if len(spans) == 0 {
+		// The shutdown failed before it began (e.g. manager not found)
+		ST.mu.Unlock()
+		return
+	}
+	span := spans[len(spans)-1]
+	if len(spans) == 1 {
+		delete(ST.active, key)
+	} else {
+		ST.active[key] = spans[:len(spans)-1]
+	}
+	ST.mu.Unlock()
+
+	if report := event.Report; report != nil {
+		ctx := trace.ContextWithSpan(context.Background(), span)
+		for _, phase := range report.Phases {
+			_, phaseSpan := ST.tracer.Start(ctx, phase.Name, trace.WithTimestamp(phase.StartedAt))
+			phaseSpan.End(trace.WithTimestamp(phase.StartedAt.Add(time.Duration(phase.Duration))))
+		}
+		graceful, forceCancelled, stillRunning := report.Totals()
+		span.SetAttributes(
+			AttrGraceful.Int(graceful),
+			AttrForceCancelled.Int(forceCancelled),
+			AttrStillRunning.Int(stillRunning),
+		)
+		for _, reportErr := range report.Errors {
+			span.AddEvent(EventError, trace.WithAttributes(attribute.String("exception.message", reportErr)))
+		}
+		if stillRunning > 0 || len(report.Errors) > 0 {
+			span.SetStatus(codes.Error, "shutdown incomplete")
+		}
+	}
+	span.End(trace.WithTimestamp(event.Time))
+}
@@ -0,0 +1,168 @@
+// Package tracing records routines and manager shutdowns as OpenTelemetry spans.
+//
+// Each worker run becomes a span named after its function, a child of the span in the context
+// passed with local.WithContextValues (or linked to it, see Config.Link). Errors, panics, timeouts
+// and cancellations are recorded as span events. Every global, app and local shutdown or drain
+// becomes a span with a child span per shutdown phase.
+//
+// Example:
+//
+//	uninstall, err := tracing.Install(globalMgr, tracing.Config{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer uninstall()
+//
+//	localMgr.Go("send-email", sendEmail, local.WithContextValues(r.Context()))
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/interfaces"
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer spans are recorded with
+const InstrumentationName = "github.com/JupiterMetaLabs/goroutine-orchestrator/tracing"
+
+// Span attributes
+const (
+	AttrApp            = attribute.Key("orchestrator.app")
+	AttrLocal          = attribute.Key("orchestrator.local")
+	AttrFunction       = attribute.Key("orchestrator.function")
+	AttrRoutineID      = attribute.Key("orchestrator.routine_id")
+	AttrRestarts       = attribute.Key("orchestrator.restarts")
+	AttrManager        = attribute.Key("orchestrator.manager")
+	AttrSafe           = attribute.Key("orchestrator.safe")
+	AttrGraceful       = attribute.Key("orchestrator.graceful")
+	AttrForceCancelled = attribute.Key("orchestrator.force_cancelled")
+	AttrStillRunning   = attribute.Key("orchestrator.still_running")
+	AttrCount          = attribute.Key("orchestrator.count")
+)
+
+// Span event names (errors of worker runs are recorded as the standard "exception" event)
+const (
+	EventError       = "shutdown_error"
+	EventPanic       = "panic"
+	EventTimeout     = "timeout"
+	EventCancelled   = "cancelled"
+	EventForceCancel = "force_cancel"
+)
+
+// Config configures the tracing integration
+type Config struct {
+	// TracerProvider creates the tracer (nil = the global provider, otel.GetTracerProvider())
+	TracerProvider trace.TracerProvider
+	// Link starts routine spans as new traces linked to the span of the spawning context instead of
+	// as its children - for routines that outlive the request that spawned them
+	Link bool
+}
+
+// tracer returns the tracer of the configured provider
+func (C Config) tracer() trace.Tracer {
+	provider := C.TracerProvider
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return provider.Tracer(InstrumentationName)
+}
+
+// Target is what Install traces: a global manager or an orchestrator
+type Target interface {
+	interfaces.InterceptorUser
+	interfaces.EventSubscriber
+}
+
+// Install traces the routines and shutdowns of target: it adds the Interceptor to target and
+// subscribes a ShutdownTracer to its events. The returned function stops tracing.
+//
+// Returns:
+//   - func(): Stops tracing - removes the interceptor (routines already running keep it) and the
+//     shutdown tracer
+//   - error: Returns error if the interceptor cannot be added (e.g. global manager not initialized)
+func Install(target Target, config Config) (uninstall func(), err error) {
+	removeInterceptor, err := target.Use(Interceptor(config))
+	if err != nil {
+		return nil, err
+	}
+	unsubscribe := target.Subscribe(NewShutdownTracer(config))
+	return func() {
+		removeInterceptor()
+		unsubscribe()
+	}, nil
+}
+
+// Interceptor returns the interceptor recording each worker run as a span named after its
+// function. Register it with Use at the level to trace, or pass it to a single Go() call with
+// local.WithInterceptors. The worker's context carries the span.
+func Interceptor(config Config) types.Interceptor {
+	tracer := config.tracer()
+	return func(next types.WorkerFunc) types.WorkerFunc {
+		return func(ctx context.Context) (err error) {
+			info, _ := types.RoutineInfoFromContext(ctx)
+			opts := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindInternal),
+				trace.WithAttributes(
+					AttrApp.String(info.AppName),
+					AttrLocal.String(info.LocalName),
+					AttrFunction.String(info.FunctionName),
+					AttrRoutineID.String(info.RoutineID),
+					AttrRestarts.Int64(info.Restarts),
+				),
+			}
+			if parent := trace.SpanContextFromContext(ctx); config.Link && parent.IsValid() {
+				opts = append(opts, trace.WithNewRoot(), trace.WithLinks(trace.Link{SpanContext: parent}))
+			}
+			spanCtx, span := tracer.Start(ctx, info.FunctionName, opts...)
+
+			returned := false
+			defer func() {
+				if returned {
+					recordOutcome(ctx, span, err)
+					span.End()
+					return
+				}
+				r := recover()
+				if r == nil {
+					// runtime.Goexit - let it continue
+					span.End()
+					return
+				}
+				// Record the panic and let it unwind to the built-in panic recovery
+				span.AddEvent(EventPanic, trace.WithAttributes(
+					attribute.String("exception.message", fmt.Sprint(r)),
+					attribute.String("exception.stacktrace", string(debug.Stack())),
+				))
+				span.SetStatus(codes.Error, fmt.Sprintf("panic: %v", r))
+				span.End()
+				panic(r)
+			}()
+			err = next(spanCtx)
+			returned = true
+			return err
+		}
+	}
+}
+
+// recordOutcome records the error of a worker run and whether the routine's context timed out or was
+// cancelled
+func recordOutcome(ctx context.Context, span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	switch ctxErr := ctx.Err(); {
+	case errors.Is(ctxErr, context.DeadlineExceeded):
+		span.AddEvent(EventTimeout)
+	case errors.Is(ctxErr, context.Canceled):
+		span.AddEvent(EventCancelled)
+	}
+}
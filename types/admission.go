@@ -0,0 +1,66 @@
+package types
+
+import "sync"
+
+// AdmissionGate serialises routine admission so that checking the MaxRoutines limits and
+// registering the new routine happen atomically. Callers that find a limit reached can wait
+// on Released() to be woken the next time a tracked routine is removed.
+type AdmissionGate struct {
+	mu       sync.Mutex
+	released chan struct{}
+	waiters  int
+}
+
+// NewAdmissionGate creates an admission gate without waiters
+func NewAdmissionGate() *AdmissionGate {
+	return &AdmissionGate{
+		released: make(chan struct{}),
+	}
+}
+
+// admission is the admission gate of the package-level Global
+var admission = NewAdmissionGate()
+
+// Admission returns the admission gate of the package-level Global
+func Admission() *AdmissionGate {
+	return admission
+}
+
+// Admission returns the admission gate of the manager tree: its own for an isolated tree, the
+// default gate (Admission) for the package-level Global
+func (GM *GlobalManager) Admission() *AdmissionGate {
+	if GM == nil || GM.admission == nil {
+		return admission
+	}
+	return GM.admission
+}
+
+// Lock acquires the admission gate - hold it while checking limits and registering the routine
+func (AG *AdmissionGate) Lock() {
+	AG.mu.Lock()
+}
+
+// Unlock releases the admission gate
+func (AG *AdmissionGate) Unlock() {
+	AG.mu.Unlock()
+}
+
+// Released returns a channel that is closed the next time a routine slot is freed.
+// The caller must hold the gate lock while calling Released.
+func (AG *AdmissionGate) Released() <-chan struct{} {
+	AG.waiters++
+	return AG.released
+}
+
+// Release wakes every caller currently waiting for a free routine slot.
+// It is a no-op when nobody is waiting, so routine removal stays cheap when no limits are hit.
+func (AG *AdmissionGate) Release() {
+	AG.mu.Lock()
+	defer AG.mu.Unlock()
+	if AG.waiters == 0 {
+		return
+	}
+	close(AG.released)
+	AG.released = make(chan struct{})
+	AG.waiters = 0
+}
@@ -2,8 +2,8 @@ package types
 
 import (
 	"context"
-	"fmt"
 	"sync"
+	"time"
 
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/ctxo"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
@@ -106,33 +106,93 @@ func (AM *AppManager) SetAppParentContext() *AppManager {
 	return AM
 }
 
-// Create Local Manger function is made private
-// Only accessible throught the appmanager
-// After creating the local manager, it will be added to the app manager
-func (AM *AppManager) CreateLocal(localName string) (*LocalManager, error) {
-	// Get or create the local manager
-	if IsIntilized().Local(AM.AppName, localName) {
-		LM, err := AM.GetLocalManager(localName)
-		if err != nil {
-			return nil, errors.ErrLocalManagerNotFound
-		}
-		return LM, errors.WrngLocalManagerAlreadyExists
+// SetMaxRoutines sets the routine quota for the app manager (0 = unlimited)
+func (AM *AppManager) SetMaxRoutines(maxRoutines int) *AppManager {
+	AM.LockAppWriteMutex()
+	defer AM.UnlockAppWriteMutex()
+	AM.MaxRoutines = maxRoutines
+	return AM
+}
+
+// SetShutdownTimeout sets the shutdown timeout override for the app manager (0 = the global one)
+func (AM *AppManager) SetShutdownTimeout(timeout time.Duration) *AppManager {
+	AM.LockAppWriteMutex()
+	defer AM.UnlockAppWriteMutex()
+	AM.ShutdownTimeout = timeout
+	return AM
+}
+
+// SetDefaults sets the routine defaults for the app manager (zero fields = no default)
+func (AM *AppManager) SetDefaults(defaults FunctionConfig) *AppManager {
+	AM.LockAppWriteMutex()
+	defer AM.UnlockAppWriteMutex()
+	AM.Defaults = defaults
+	return AM
+}
+
+// Configure sets the quota, shutdown timeout and routine defaults of the app manager
+func (AM *AppManager) Configure(config ManagerConfig) *AppManager {
+	AM.LockAppWriteMutex()
+	defer AM.UnlockAppWriteMutex()
+	AM.MaxRoutines = config.MaxRoutines
+	AM.ShutdownTimeout = config.ShutdownTimeout
+	AM.Defaults = config.Defaults
+	return AM
+}
+
+// AddInterceptors appends interceptors to those wrapping the worker runs of every routine of the app
+func (AM *AppManager) AddInterceptors(interceptors ...Interceptor) *AppManager {
+	AM.UseInterceptors(interceptors...)
+	return AM
+}
+
+// UseInterceptors appends interceptors like AddInterceptors and returns the function that removes them
+// again. Routines already running keep their chain.
+func (AM *AppManager) UseInterceptors(interceptors ...Interceptor) (remove func()) {
+	AM.LockAppWriteMutex()
+	defer AM.UnlockAppWriteMutex()
+	var added []uint64
+	AM.Interceptors, AM.interceptorIDs, added = appendInterceptors(AM.Interceptors, AM.interceptorIDs, interceptors)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			AM.LockAppWriteMutex()
+			defer AM.UnlockAppWriteMutex()
+			AM.Interceptors, AM.interceptorIDs = deleteInterceptors(AM.Interceptors, AM.interceptorIDs, added)
+		})
 	}
-	// Set the parent contex before returning
-	LM := newLocalManager(localName, AM.AppName).SetParentContext(AM.ParentCtx)
+}
 
-	AM.AddLocalManager(LM.LocalName, LM)
+// SetShutdownOrder sets the shutdown ordering of the app manager among the other app managers
+func (AM *AppManager) SetShutdownOrder(order ShutdownOrder) *AppManager {
+	AM.LockAppWriteMutex()
+	defer AM.UnlockAppWriteMutex()
+	AM.ShutdownOrder = order
+	return AM
+}
+
+// CreateLocal gets the local manager of the app manager, creating and adding it if it does not exist.
+// created reports whether the local manager was created by this call.
+func (AM *AppManager) CreateLocal(localName string) (LM *LocalManager, created bool) {
+	AM.LockAppWriteMutex()
+	defer AM.UnlockAppWriteMutex()
+	if existing, ok := AM.LocalManagers[localName]; ok {
+		return existing, false
+	}
 
-	return LM, nil
+	// Set the parent contex before adding
+	LM = newLocalManager(localName).SetParentContext(AM.ParentCtx)
+	AM.LocalManagers[localName] = LM
+	return LM, true
 }
 
 // AddLocalManager adds a new local manager to the app manager
 func (AM *AppManager) AddLocalManager(localName string, local *LocalManager) *AppManager {
-	if IsIntilized().Local(AM.AppName, localName) {
-		return AM
-	}
 	AM.LockAppWriteMutex()
 	defer AM.UnlockAppWriteMutex()
+	if _, ok := AM.LocalManagers[localName]; ok {
+		return AM
+	}
 	AM.LocalManagers[localName] = local
 	return AM
 }
@@ -150,7 +210,12 @@ func (AM *AppManager) RemoveLocalManager(localName string) *AppManager {
 func (AM *AppManager) GetLocalManagers() map[string]*LocalManager {
 	AM.LockAppReadMutex()
 	defer AM.UnlockAppReadMutex()
-	return AM.LocalManagers
+
+	localManagersCopy := make(map[string]*LocalManager, len(AM.LocalManagers))
+	for k, v := range AM.LocalManagers {
+		localManagersCopy[k] = v
+	}
+	return localManagersCopy
 }
 
 // GetLocalManager gets a specific local manager for the app manager
@@ -158,11 +223,19 @@ func (AM *AppManager) GetLocalManager(localName string) (*LocalManager, error) {
 	AM.LockAppReadMutex()
 	defer AM.UnlockAppReadMutex()
 	if _, ok := AM.LocalManagers[localName]; !ok {
-		return nil, fmt.Errorf("%w: %s", errors.ErrLocalManagerNotFound, localName)
+		return nil, &errors.NotFoundError{Name: localName, Err: errors.ErrLocalManagerNotFound}
 	}
 	return AM.LocalManagers[localName], nil
 }
 
+// HasLocalManager checks if the local manager exists in the app manager
+func (AM *AppManager) HasLocalManager(localName string) bool {
+	AM.LockAppReadMutex()
+	defer AM.UnlockAppReadMutex()
+	_, ok := AM.LocalManagers[localName]
+	return ok
+}
+
 // GetLocalManagerCount gets the number of local managers for the app manager
 func (AM *AppManager) GetLocalManagerCount() int {
 	AM.LockAppReadMutex()
@@ -190,3 +263,54 @@ func (AM *AppManager) GetParentContext() context.Context {
 	defer AM.UnlockAppReadMutex()
 	return AM.ParentCtx
 }
+
+// GetMaxRoutines gets the routine quota for the app manager (0 = unlimited)
+func (AM *AppManager) GetMaxRoutines() int {
+	AM.LockAppReadMutex()
+	defer AM.UnlockAppReadMutex()
+	return AM.MaxRoutines
+}
+
+// GetManagerConfig gets the quota, shutdown timeout and routine defaults set on the app manager
+// (not inherited - see GlobalManager.GetEffectiveConfig)
+func (AM *AppManager) GetManagerConfig() ManagerConfig {
+	AM.LockAppReadMutex()
+	defer AM.UnlockAppReadMutex()
+	return ManagerConfig{MaxRoutines: AM.MaxRoutines, ShutdownTimeout: AM.ShutdownTimeout, Defaults: AM.Defaults}
+}
+
+// GetInterceptors gets the interceptors of the app manager, outermost first (must not be modified)
+func (AM *AppManager) GetInterceptors() []Interceptor {
+	AM.LockAppReadMutex()
+	defer AM.UnlockAppReadMutex()
+	return AM.Interceptors
+}
+
+// GetShutdownOrder gets the shutdown ordering of the app manager
+func (AM *AppManager) GetShutdownOrder() ShutdownOrder {
+	AM.LockAppReadMutex()
+	defer AM.UnlockAppReadMutex()
+	return ShutdownOrder{Priority: AM.ShutdownOrder.Priority, After: append([]string(nil), AM.ShutdownOrder.After...)}
+}
+
+// GetLocalShutdownOrders gets the shutdown ordering of every local manager of the app manager
+func (AM *AppManager) GetLocalShutdownOrders() map[string]ShutdownOrder {
+	AM.LockAppReadMutex()
+	defer AM.UnlockAppReadMutex()
+	orders := make(map[string]ShutdownOrder, len(AM.LocalManagers))
+	for localName, localManager := range AM.LocalManagers {
+		orders[localName] = localManager.GetShutdownOrder()
+	}
+	return orders
+}
+
+// GetRoutineCount gets the number of tracked routines across all local managers of the app manager
+func (AM *AppManager) GetRoutineCount() int {
+	AM.LockAppReadMutex()
+	defer AM.UnlockAppReadMutex()
+	count := 0
+	for _, localManager := range AM.LocalManagers {
+		count += localManager.GetRoutineCount()
+	}
+	return count
+}
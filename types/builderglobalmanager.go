@@ -2,28 +2,71 @@ package types
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/ctxo"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
 )
 
 func NewGlobalManager() *GlobalManager {
-	if IsIntilized().Global() {
+	// Global is read under the same lock (GetGlobalManager)
+	lock.Lock()
+	defer lock.Unlock()
+	if Global != nil {
 		return Global
 	}
 
-	Global = &GlobalManager{
+	global := &GlobalManager{
 		AppManagers: make(map[string]*AppManager),
 		Wg:          &sync.WaitGroup{}, // Initialize wait group for safe shutdown
 	}
 
 	// Initialize metadata
-	Global.NewMetadata()
+	global.NewMetadata()
 
+	Global = global
 	return Global
 }
 
+// NewIsolatedGlobalManager creates a global manager that is independent of the package-level Global.
+// It owns its own app managers, metadata and wait group, and a root context that is not tied to process
+// signals - it is cancelled by Cancel (e.g. through an unsafe shutdown). Several isolated global managers
+// can live side by side in one process.
+func NewIsolatedGlobalManager() *GlobalManager {
+	GM := &GlobalManager{
+		AppManagers:  make(map[string]*AppManager),
+		Wg:           &sync.WaitGroup{},
+		isolated:     true,
+		treeID:       fmt.Sprintf("tree-%d", treeCount.Add(1)),
+		events:       NewEventBus(),
+		panicHandler: &panicHandlerSlot{},
+		admission:    NewAdmissionGate(),
+	}
+	GM.SetGlobalMutex()
+	GM.NewMetadata()
+	GM.Ctx, GM.Cancel = context.WithCancel(context.Background())
+	return GM
+}
+
+// treeCount numbers the isolated trees for their TreeID
+var treeCount atomic.Uint64
+
+// TreeID returns the ID of an isolated tree, set as the LabelTree label of its routines. It is empty
+// for the package-level Global.
+func (GM *GlobalManager) TreeID() string {
+	if GM == nil {
+		return ""
+	}
+	return GM.treeID
+}
+
+// IsIsolated reports whether the global manager was created by NewIsolatedGlobalManager
+func (GM *GlobalManager) IsIsolated() bool {
+	return GM.isolated
+}
+
 // Mutex Lock APIs
 // LockGlobalReadMutex locks the global read mutex for the global manager - This is used to read the global manager's data
 func (GM *GlobalManager) LockGlobalReadMutex() {
@@ -99,17 +142,71 @@ func (GM *GlobalManager) GetMetadata() *Metadata {
 	return GM.Metadata
 }
 
+// AddInterceptors appends interceptors to those wrapping the worker runs of every routine of the tree
+func (GM *GlobalManager) AddInterceptors(interceptors ...Interceptor) *GlobalManager {
+	GM.UseInterceptors(interceptors...)
+	return GM
+}
+
+// UseInterceptors appends interceptors like AddInterceptors and returns the function that removes them
+// again. Routines already running keep their chain.
+func (GM *GlobalManager) UseInterceptors(interceptors ...Interceptor) (remove func()) {
+	GM.LockGlobalWriteMutex()
+	defer GM.UnlockGlobalWriteMutex()
+	var added []uint64
+	GM.Interceptors, GM.interceptorIDs, added = appendInterceptors(GM.Interceptors, GM.interceptorIDs, interceptors)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			GM.LockGlobalWriteMutex()
+			defer GM.UnlockGlobalWriteMutex()
+			GM.Interceptors, GM.interceptorIDs = deleteInterceptors(GM.Interceptors, GM.interceptorIDs, added)
+		})
+	}
+}
+
+// GetInterceptors gets the interceptors of the global manager, outermost first (must not be modified)
+func (GM *GlobalManager) GetInterceptors() []Interceptor {
+	GM.LockGlobalReadMutex()
+	defer GM.UnlockGlobalReadMutex()
+	return GM.Interceptors
+}
+
 // AddAppManager adds a new app manager to the global manager
 func (GM *GlobalManager) AddAppManager(appName string, app *AppManager) *GlobalManager {
-	if IsIntilized().App(appName) {
-		return GM
-	}
 	GM.LockGlobalWriteMutex()
 	defer GM.UnlockGlobalWriteMutex()
+	if _, ok := GM.AppManagers[appName]; ok {
+		return GM
+	}
 	GM.AppManagers[appName] = app
 	return GM
 }
 
+// NewAppManager gets the app manager of the global manager, creating and adding it if it does not exist.
+// The app context is derived from the global manager's root context if the global manager is isolated.
+func (GM *GlobalManager) NewAppManager(appName string) *AppManager {
+	GM.LockGlobalWriteMutex()
+	defer GM.UnlockGlobalWriteMutex()
+	if appMgr, ok := GM.AppManagers[appName]; ok {
+		return appMgr
+	}
+
+	appMgr := &AppManager{
+		AppName:       appName,
+		LocalManagers: make(map[string]*LocalManager),
+		Wg:            &sync.WaitGroup{}, // Initialize wait group for safe shutdown
+	}
+	appMgr.SetAppMutex()
+	if GM.isolated {
+		appMgr.Ctx, appMgr.Cancel = context.WithCancel(GM.Ctx)
+	} else {
+		appMgr.SetAppContext()
+	}
+	GM.AppManagers[appName] = appMgr
+	return appMgr
+}
+
 // RemoveAppManager removes an app manager from the global manager
 func (GM *GlobalManager) RemoveAppManager(appName string) *GlobalManager {
 	GM.LockGlobalWriteMutex()
@@ -147,17 +244,51 @@ func (GM *GlobalManager) GetGlobalWaitGroup() *sync.WaitGroup {
 func (GM *GlobalManager) GetAppManagers() map[string]*AppManager {
 	GM.LockGlobalReadMutex()
 	defer GM.UnlockGlobalReadMutex()
-	return GM.AppManagers
+
+	appManagersCopy := make(map[string]*AppManager, len(GM.AppManagers))
+	for k, v := range GM.AppManagers {
+		appManagersCopy[k] = v
+	}
+	return appManagersCopy
 }
 
 // GetAppManager gets a specific app manager for the global manager
 func (GM *GlobalManager) GetAppManager(appName string) (*AppManager, error) {
-	if !IsIntilized().App(appName) {
-		return nil, errors.ErrAppManagerNotFound
+	GM.LockGlobalReadMutex()
+	defer GM.UnlockGlobalReadMutex()
+	appMgr, ok := GM.AppManagers[appName]
+	if !ok {
+		return nil, &errors.NotFoundError{Name: appName, Err: errors.ErrAppManagerNotFound}
 	}
+	return appMgr, nil
+}
+
+// HasAppManager checks if the app manager exists in the global manager
+func (GM *GlobalManager) HasAppManager(appName string) bool {
 	GM.LockGlobalReadMutex()
 	defer GM.UnlockGlobalReadMutex()
-	return GM.AppManagers[appName], nil
+	_, ok := GM.AppManagers[appName]
+	return ok
+}
+
+// GetLocalManager gets a specific local manager of an app manager of the global manager
+func (GM *GlobalManager) GetLocalManager(appName, localName string) (*LocalManager, error) {
+	appMgr, err := GM.GetAppManager(appName)
+	if err != nil {
+		return nil, err
+	}
+	return appMgr.GetLocalManager(localName)
+}
+
+// GetAppShutdownOrders gets the shutdown ordering of every app manager
+func (GM *GlobalManager) GetAppShutdownOrders() map[string]ShutdownOrder {
+	GM.LockGlobalReadMutex()
+	defer GM.UnlockGlobalReadMutex()
+	orders := make(map[string]ShutdownOrder, len(GM.AppManagers))
+	for appName, appManager := range GM.AppManagers {
+		orders[appName] = appManager.GetShutdownOrder()
+	}
+	return orders
 }
 
 // GetAppManagerCount gets the number of app managers for the global manager
@@ -166,3 +297,40 @@ func (GM *GlobalManager) GetAppManagerCount() int {
 	defer GM.UnlockGlobalReadMutex()
 	return len(GM.AppManagers)
 }
+
+// GetEffectiveConfig returns the settings in effect for an app manager (localName "") or one of
+// its local managers: the settings set on the manager, with an unset shutdown timeout and routine
+// defaults inherited from the app manager and then from the global configuration. Managers that
+// do not exist (yet) are skipped.
+func (GM *GlobalManager) GetEffectiveConfig(appName, localName string) ManagerConfig {
+	effective := ManagerConfig{ShutdownTimeout: ShutdownTimeout}
+	if metadata := GM.GetMetadata(); metadata != nil {
+		effective.ShutdownTimeout = metadata.GetShutdownTimeout()
+	}
+	appManager, err := GM.GetAppManager(appName)
+	if err != nil {
+		return effective
+	}
+	effective = appManager.GetManagerConfig().Inherit(effective)
+	if localName == "" {
+		return effective
+	}
+	localManager, err := appManager.GetLocalManager(localName)
+	if err != nil {
+		// The app's quota is not the local manager's
+		effective.MaxRoutines = 0
+		return effective
+	}
+	return localManager.GetManagerConfig().Inherit(effective)
+}
+
+// GetRoutineCount gets the number of tracked routines across the whole manager tree
+func (GM *GlobalManager) GetRoutineCount() int {
+	GM.LockGlobalReadMutex()
+	defer GM.UnlockGlobalReadMutex()
+	count := 0
+	for _, appManager := range GM.AppManagers {
+		count += appManager.GetRoutineCount()
+	}
+	return count
+}
@@ -2,9 +2,9 @@ package types
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/ctxo"
 	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
@@ -14,25 +14,17 @@ const (
 	Prefix_LocalManager = "LocalManager."
 )
 
-func newLocalManager(localName string, appName string) *LocalManager {
-	if IsIntilized().Local(localName, appName) {
-		LocalManager, err := NewAppManager(appName).GetLocalManager(localName)
-		if err != nil {
-			return nil
-		}
-		return LocalManager
-	}
-
+// newLocalManager creates a local manager - AppManager.CreateLocal adds it to its app manager
+func newLocalManager(localName string) *LocalManager {
 	LocalManager := &LocalManager{
 		LocalName:   localName,
 		Routines:    make(map[string]*Routine),
 		FunctionWgs: make(map[string]*sync.WaitGroup), // Initialize FunctionWgs map
 		Wg:          &sync.WaitGroup{},                // Initialize wait group for safe shutdown
+		Results:     NewRoutineResults(DefaultRoutineResultsCapacity),
+		Pools:       make(map[string]Pool),
 	}
 
-	// Add the local manager to the app manager
-	SetLocalManager(appName, localName, LocalManager)
-
 	return LocalManager
 }
 
@@ -108,6 +100,16 @@ func (LM *LocalManager) SetLocalWaitGroup() *LocalManager {
 	return LM
 }
 
+// SetLocalContextFrom sets the context and cancel function for the local manager as a child of parent.
+// Local managers of isolated global managers use it instead of SetLocalContext.
+func (LM *LocalManager) SetLocalContextFrom(parent context.Context) *LocalManager {
+	// Lock and update
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+	LM.Ctx, LM.Cancel = context.WithCancel(parent)
+	return LM
+}
+
 // SetParentContext sets the parent context for the local manager
 func (LM *LocalManager) SetParentContext(ctx context.Context) *LocalManager {
 	// Lock and update
@@ -140,12 +142,12 @@ func (LM *LocalManager) AddRoutine(routine *Routine) *LocalManager {
 	return LM
 }
 
-// RemoveRoutine removes a routine from the local manager
+// RemoveRoutine removes a routine from the local manager. The caller wakes the Go() calls waiting
+// for a routine slot through the admission gate of its manager tree (GlobalManager.Admission).
 func (LM *LocalManager) RemoveRoutine(routine *Routine, safe bool) *LocalManager {
 
 	// Lock -> remove the routine -> unlock
 	LM.lockLocalWriteMutex()
-	defer LM.unlockLocalWriteMutex()
 
 	// Cancel the routine's context to signal it to stop
 	if routine.Cancel != nil {
@@ -160,6 +162,7 @@ func (LM *LocalManager) RemoveRoutine(routine *Routine, safe bool) *LocalManager
 		// Atomically decrement routine count for lock-free reads
 		atomic.AddInt64(&LM.routineCount, -1)
 	}
+	LM.unlockLocalWriteMutex()
 	return LM
 }
 
@@ -184,6 +187,119 @@ func (LM *LocalManager) RemoveFunctionWg(functionName string) *LocalManager {
 	return LM
 }
 
+// SetMaxRoutines sets the routine quota for the local manager (0 = unlimited)
+func (LM *LocalManager) SetMaxRoutines(maxRoutines int) *LocalManager {
+	// Lock and update
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+	LM.MaxRoutines = maxRoutines
+	return LM
+}
+
+// SetShutdownTimeout sets the shutdown timeout override for the local manager (0 = the app's)
+func (LM *LocalManager) SetShutdownTimeout(timeout time.Duration) *LocalManager {
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+	LM.ShutdownTimeout = timeout
+	return LM
+}
+
+// SetDefaults sets the routine defaults for the local manager (zero fields = the app's)
+func (LM *LocalManager) SetDefaults(defaults FunctionConfig) *LocalManager {
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+	LM.Defaults = defaults
+	return LM
+}
+
+// Configure sets the quota, shutdown timeout and routine defaults of the local manager
+func (LM *LocalManager) Configure(config ManagerConfig) *LocalManager {
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+	LM.MaxRoutines = config.MaxRoutines
+	LM.ShutdownTimeout = config.ShutdownTimeout
+	LM.Defaults = config.Defaults
+	return LM
+}
+
+// SetDefaultOptions sets the Go() options applied to every routine of the local manager
+func (LM *LocalManager) SetDefaultOptions(opts []interface{}) *LocalManager {
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+	LM.DefaultOptions = append([]interface{}(nil), opts...)
+	return LM
+}
+
+// SetFunctionOptions sets the Go() options applied to the routines of a function name (none = removed)
+func (LM *LocalManager) SetFunctionOptions(functionName string, opts []interface{}) *LocalManager {
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+	if len(opts) == 0 {
+		delete(LM.FunctionOptions, functionName)
+		return LM
+	}
+	if LM.FunctionOptions == nil {
+		LM.FunctionOptions = make(map[string][]interface{})
+	}
+	LM.FunctionOptions[functionName] = append([]interface{}(nil), opts...)
+	return LM
+}
+
+// AddInterceptors appends interceptors to those wrapping the worker runs of every routine of the
+// local manager
+func (LM *LocalManager) AddInterceptors(interceptors ...Interceptor) *LocalManager {
+	LM.UseInterceptors(interceptors...)
+	return LM
+}
+
+// UseInterceptors appends interceptors like AddInterceptors and returns the function that removes them
+// again. Routines already running keep their chain.
+func (LM *LocalManager) UseInterceptors(interceptors ...Interceptor) (remove func()) {
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+	var added []uint64
+	LM.Interceptors, LM.interceptorIDs, added = appendInterceptors(LM.Interceptors, LM.interceptorIDs, interceptors)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			LM.lockLocalWriteMutex()
+			defer LM.unlockLocalWriteMutex()
+			LM.Interceptors, LM.interceptorIDs = deleteInterceptors(LM.Interceptors, LM.interceptorIDs, added)
+		})
+	}
+}
+
+// SetShutdownOrder sets the shutdown ordering of the local manager among the other local managers
+func (LM *LocalManager) SetShutdownOrder(order ShutdownOrder) *LocalManager {
+	// Lock and update
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+	LM.ShutdownOrder = order
+	return LM
+}
+
+// SetResultsCapacity replaces the recent results store with one holding at most capacity results
+func (LM *LocalManager) SetResultsCapacity(capacity int) *LocalManager {
+	// Lock and update
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+	LM.Results = NewRoutineResults(capacity)
+	return LM
+}
+
+// AddRoutineResult stores the result of a completed routine in the recent results store
+func (LM *LocalManager) AddRoutineResult(result *RoutineResult) *LocalManager {
+	LM.lockLocalWriteMutex()
+	if LM.Results == nil {
+		LM.Results = NewRoutineResults(DefaultRoutineResultsCapacity)
+	}
+	results := LM.Results
+	LM.unlockLocalWriteMutex()
+
+	results.Add(result)
+	return LM
+}
+
 // >>> Get APIs
 // GetRoutine gets a specific routine for the local manager
 func (LM *LocalManager) GetRoutine(routineID string) (*Routine, error) {
@@ -191,7 +307,7 @@ func (LM *LocalManager) GetRoutine(routineID string) (*Routine, error) {
 	defer LM.unlockLocalReadMutex()
 
 	if _, ok := LM.Routines[routineID]; !ok {
-		return nil, fmt.Errorf("%w: %s", errors.ErrRoutineNotFound, routineID)
+		return nil, &errors.NotFoundError{Name: routineID, Err: errors.ErrRoutineNotFound}
 	}
 	return LM.Routines[routineID], nil
 }
@@ -208,6 +324,33 @@ func (LM *LocalManager) GetRoutines() map[string]*Routine {
 	return routinesCopy
 }
 
+// GetFunctionRoutineCount gets the number of tracked routines with a specific function name
+func (LM *LocalManager) GetFunctionRoutineCount(functionName string) int {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+
+	count := 0
+	for _, routine := range LM.Routines {
+		if routine.FunctionName == functionName {
+			count++
+		}
+	}
+	return count
+}
+
+// GetSingleflightRoutine gets the tracked routine of a function that was started with a singleflight key
+func (LM *LocalManager) GetSingleflightRoutine(functionName, key string) (*Routine, bool) {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+
+	for _, routine := range LM.Routines {
+		if routine.FunctionName == functionName && routine.GetSingleflight() == key {
+			return routine, true
+		}
+	}
+	return nil, false
+}
+
 // GetLocalContext gets the context for the local manager
 func (LM *LocalManager) GetLocalContext() (context.Context, context.CancelFunc) {
 	return LM.Ctx, LM.Cancel
@@ -219,7 +362,7 @@ func (LM *LocalManager) GetFunctionWg(functionName string) (*sync.WaitGroup, err
 	defer LM.unlockLocalReadMutex()
 
 	if _, ok := LM.FunctionWgs[functionName]; !ok {
-		return nil, fmt.Errorf("%w: %s", errors.ErrFunctionWgNotFound, functionName)
+		return nil, &errors.NotFoundError{Name: functionName, Err: errors.ErrFunctionWgNotFound}
 	}
 	return LM.FunctionWgs[functionName], nil
 }
@@ -268,3 +411,58 @@ func (LM *LocalManager) GetLocalWaitGroup() *sync.WaitGroup {
 	defer LM.unlockLocalReadMutex()
 	return LM.Wg
 }
+
+// GetMaxRoutines gets the routine quota for the local manager (0 = unlimited)
+func (LM *LocalManager) GetMaxRoutines() int {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+	return LM.MaxRoutines
+}
+
+// GetManagerConfig gets the quota, shutdown timeout and routine defaults set on the local manager
+// (not inherited - see GlobalManager.GetEffectiveConfig)
+func (LM *LocalManager) GetManagerConfig() ManagerConfig {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+	return ManagerConfig{MaxRoutines: LM.MaxRoutines, ShutdownTimeout: LM.ShutdownTimeout, Defaults: LM.Defaults}
+}
+
+// GetDefaultOptions gets the Go() options applied to the routines of a function name: those of the
+// local manager followed by those of the function name
+func (LM *LocalManager) GetDefaultOptions(functionName string) []interface{} {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+	opts := make([]interface{}, 0, len(LM.DefaultOptions)+len(LM.FunctionOptions[functionName]))
+	opts = append(opts, LM.DefaultOptions...)
+	return append(opts, LM.FunctionOptions[functionName]...)
+}
+
+// GetInterceptors gets the interceptors of the local manager, outermost first (must not be modified)
+func (LM *LocalManager) GetInterceptors() []Interceptor {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+	return LM.Interceptors
+}
+
+// GetShutdownOrder gets the shutdown ordering of the local manager
+func (LM *LocalManager) GetShutdownOrder() ShutdownOrder {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+	return ShutdownOrder{Priority: LM.ShutdownOrder.Priority, After: append([]string(nil), LM.ShutdownOrder.After...)}
+}
+
+// GetRoutineResult gets the result of a completed routine from the recent results store
+func (LM *LocalManager) GetRoutineResult(routineID string) (*RoutineResult, error) {
+	LM.lockLocalReadMutex()
+	results := LM.Results
+	LM.unlockLocalReadMutex()
+
+	if results == nil {
+		return nil, &errors.NotFoundError{Name: routineID, Err: errors.ErrRoutineNotFound}
+	}
+	result, ok := results.Get(routineID)
+	if !ok {
+		return nil, &errors.NotFoundError{Name: routineID, Err: errors.ErrRoutineNotFound}
+	}
+	return result, nil
+}
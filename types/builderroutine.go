@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	Helper "github.com/JupiterMetaLabs/goroutine-orchestrator/internal/helper/routine"
@@ -54,11 +55,38 @@ func (r *Routine) SetFunctionName(functionName string) *Routine {
 }
 
 // SetContext sets the context for the routine
+// Supervised routines get a new context on every restart, so access is locked
 func (r *Routine) SetContext(ctx context.Context) *Routine {
+	r.routineMu.Lock()
+	defer r.routineMu.Unlock()
 	r.Ctx = ctx
 	return r
 }
 
+// SetWaitGroup sets the name of the function wait group the routine belongs to
+func (r *Routine) SetWaitGroup(waitGroupName string) *Routine {
+	r.routineMu.Lock()
+	defer r.routineMu.Unlock()
+	r.WaitGroup = waitGroupName
+	return r
+}
+
+// SetSingleflight sets the singleflight key that later Go() calls with the same key join on
+func (r *Routine) SetSingleflight(key string) *Routine {
+	r.routineMu.Lock()
+	defer r.routineMu.Unlock()
+	r.Singleflight = key
+	return r
+}
+
+// SetOptions sets the options in effect for the routine
+func (r *Routine) SetOptions(opts RoutineOptions) *Routine {
+	r.routineMu.Lock()
+	defer r.routineMu.Unlock()
+	r.Options = copyOptions(opts)
+	return r
+}
+
 // SetDone sets the done channel for the routine
 func (r *Routine) SetDone(done <-chan struct{}) *Routine {
 	r.Done = done
@@ -77,6 +105,17 @@ func (r *Routine) SetStartedAt(timestamp int64) *Routine {
 	return r
 }
 
+// SetResult sets the result for the routine - must be called before the done channel is closed
+func (r *Routine) SetResult(result *RoutineResult) *Routine {
+	r.Result = result
+	return r
+}
+
+// IncrementRestarts records a supervised restart of the routine
+func (r *Routine) IncrementRestarts() int64 {
+	return atomic.AddInt64(&r.Restarts, 1)
+}
+
 // DoneChan returns the done channel for the routine (read-only).
 // The channel should be closed (not sent to) when the routine completes.
 // Consumers can select on this channel to detect routine completion.
@@ -94,6 +133,8 @@ func (r *Routine) GetFunctionName() string {
 }
 
 func (r *Routine) GetContext() context.Context {
+	r.routineMu.RLock()
+	defer r.routineMu.RUnlock()
 	return r.Ctx
 }
 
@@ -104,3 +145,34 @@ func (r *Routine) GetCancel() context.CancelFunc {
 func (r *Routine) GetStartedAt() int64 {
 	return r.StartedAt
 }
+
+// GetResult returns the routine's result - nil until the done channel is closed
+func (r *Routine) GetResult() *RoutineResult {
+	return r.Result
+}
+
+// GetWaitGroup returns the name of the function wait group the routine belongs to ("" if none)
+func (r *Routine) GetWaitGroup() string {
+	r.routineMu.RLock()
+	defer r.routineMu.RUnlock()
+	return r.WaitGroup
+}
+
+// GetSingleflight returns the routine's singleflight key ("" if none)
+func (r *Routine) GetSingleflight() string {
+	r.routineMu.RLock()
+	defer r.routineMu.RUnlock()
+	return r.Singleflight
+}
+
+// GetOptions returns the options in effect for the routine
+func (r *Routine) GetOptions() RoutineOptions {
+	r.routineMu.RLock()
+	defer r.routineMu.RUnlock()
+	return copyOptions(r.Options)
+}
+
+// GetRestarts returns how many times a supervised routine has been restarted
+func (r *Routine) GetRestarts() int64 {
+	return atomic.LoadInt64(&r.Restarts)
+}
@@ -0,0 +1,360 @@
+package types
+
+import (
+	goerrors "errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+)
+
+// Config settings reported by ConfigChange
+const (
+	ConfigShutdownTimeout = "shutdown_timeout"
+	ConfigMaxRoutines     = "max_routines"
+	ConfigMetrics         = "metrics"
+	ConfigMetricsURL      = "metrics_url"
+	ConfigMetricsInterval = "metrics_interval"
+	ConfigSignals         = "signals"
+	// App, local and function settings are reported as "apps.<app>.max_routines",
+	// "apps.<app>.locals.<local>.shutdown_timeout", "apps.<app>.defaults" and "functions.<function>"
+	ConfigApps      = "apps"
+	ConfigDefaults  = "defaults"
+	ConfigFunctions = "functions"
+)
+
+// Default configuration used by DefaultConfig
+const (
+	DefaultShutdownTimeout = 10 * time.Second
+	DefaultMetricsInterval = 5 * time.Second
+)
+
+// Config is the typed configuration of a global manager, applied by Init and Reconfigure.
+// Build it with DefaultConfig (or the global package's options) and check it with Validate.
+type Config struct {
+	ShutdownTimeout time.Duration // Graceful shutdown timeout (default 10s)
+	MaxRoutines     int           // Process-wide routine limit enforced by Go() (0 = unlimited)
+	Metrics         bool          // Enables Prometheus metrics collection
+	MetricsURL      string        // Address the metrics server listens on ("" = collector only)
+	MetricsInterval time.Duration // Metrics collection interval (default 5s)
+	// Signals is the process signal policy (nil = the default policy for the package-level
+	// singleton, no signal handling for isolated trees)
+	Signals *SignalPolicy
+	// Apps holds app and local manager settings by app name, applied to existing app and local
+	// managers and to the ones created later
+	Apps map[string]AppConfig
+	// Functions holds the defaults for the routines of a function name - options passed to Go() override them
+	Functions map[string]FunctionConfig
+}
+
+// ManagerConfig overrides the global settings for an app or local manager. Unset values inherit:
+// a local manager falls back to its app manager, an app manager to the global configuration.
+type ManagerConfig struct {
+	// MaxRoutines is the routine quota of the manager (0 = none). Quotas are not inherited - the
+	// limits of the parents apply as well.
+	MaxRoutines     int
+	ShutdownTimeout time.Duration  // Shutdown timeout of the manager (0 = the parent's)
+	Defaults        FunctionConfig // Defaults for every routine of the manager (zero fields = the parent's)
+}
+
+// Inherit returns M with its unset shutdown timeout and defaults taken from parent
+func (M ManagerConfig) Inherit(parent ManagerConfig) ManagerConfig {
+	if M.ShutdownTimeout == 0 {
+		M.ShutdownTimeout = parent.ShutdownTimeout
+	}
+	M.Defaults = M.Defaults.Inherit(parent.Defaults)
+	return M
+}
+
+// AppConfig configures an app manager and its local managers
+type AppConfig struct {
+	ManagerConfig
+	Locals map[string]ManagerConfig // Local manager settings by local name
+}
+
+// FunctionConfig holds the defaults for the routines of a function name in every local manager,
+// or for every routine of an app or local manager (ManagerConfig.Defaults)
+type FunctionConfig struct {
+	Timeout       time.Duration // Default WithTimeout (0 = no timeout)
+	MaxConcurrent int           // Default WithMaxConcurrent (0 = unlimited)
+}
+
+// Inherit returns F with its unset fields taken from parent
+func (F FunctionConfig) Inherit(parent FunctionConfig) FunctionConfig {
+	if F.Timeout == 0 {
+		F.Timeout = parent.Timeout
+	}
+	if F.MaxConcurrent == 0 {
+		F.MaxConcurrent = parent.MaxConcurrent
+	}
+	return F
+}
+
+// ConfigOption changes a Config - the global package provides WithShutdownTimeout, WithMetrics, ...
+type ConfigOption func(*Config)
+
+// ConfigChange is a setting that Reconfigure changed
+type ConfigChange struct {
+	Setting string // ConfigShutdownTimeout, ConfigMaxRoutines, ...
+	Old     any
+	New     any
+}
+
+// String returns the change as "setting: old -> new"
+func (CC ConfigChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", CC.Setting, CC.Old, CC.New)
+}
+
+// DefaultConfig returns the configuration a global manager starts with
+func DefaultConfig() Config {
+	return Config{
+		ShutdownTimeout: DefaultShutdownTimeout,
+		MetricsInterval: DefaultMetricsInterval,
+	}
+}
+
+// NewConfig returns DefaultConfig with opts applied in order
+func NewConfig(opts ...ConfigOption) Config {
+	return DefaultConfig().With(opts...)
+}
+
+// With returns a copy of C with opts applied in order
+func (C Config) With(opts ...ConfigOption) Config {
+	for _, opt := range opts {
+		opt(&C)
+	}
+	return C
+}
+
+// Validate checks every setting and returns all problems at once, each wrapping ErrInvalidConfig
+func (C Config) Validate() error {
+	var errs []error
+	if C.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must be positive, got %v", errors.ErrInvalidConfig, ConfigShutdownTimeout, C.ShutdownTimeout))
+	}
+	if C.MaxRoutines < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must not be negative, got %d", errors.ErrInvalidConfig, ConfigMaxRoutines, C.MaxRoutines))
+	}
+	if C.MetricsInterval <= 0 {
+		errs = append(errs, fmt.Errorf("%w: %s must be positive, got %v", errors.ErrInvalidConfig, ConfigMetricsInterval, C.MetricsInterval))
+	}
+	if C.Signals != nil && !C.Signals.Disabled {
+		shutdown := make(map[os.Signal]bool)
+		for _, sig := range C.Signals.ShutdownSignals() {
+			shutdown[sig] = true
+		}
+		for _, sig := range C.Signals.ReloadSignalList() {
+			if shutdown[sig] {
+				errs = append(errs, fmt.Errorf("%w: %s: %s is both a shutdown and a reload signal", errors.ErrInvalidConfig, ConfigSignals, sig))
+			}
+		}
+		if len(C.Signals.ReloadSignals) > 0 && C.Signals.OnReload == nil {
+			errs = append(errs, fmt.Errorf("%w: %s: reload signals need an OnReload hook", errors.ErrInvalidConfig, ConfigSignals))
+		}
+	}
+	for _, appName := range sortedKeys(C.Apps) {
+		app := C.Apps[appName]
+		if appName == "" {
+			errs = append(errs, fmt.Errorf("%w: %s: empty app name", errors.ErrInvalidConfig, ConfigApps))
+		}
+		errs = append(errs, app.ManagerConfig.validate(appPrefix(appName))...)
+		for _, localName := range sortedKeys(app.Locals) {
+			if localName == "" {
+				errs = append(errs, fmt.Errorf("%w: %s.%s: empty local name", errors.ErrInvalidConfig, ConfigApps, appName))
+			}
+			errs = append(errs, app.Locals[localName].validate(localPrefix(appName, localName))...)
+		}
+	}
+	for _, functionName := range sortedKeys(C.Functions) {
+		if functionName == "" {
+			errs = append(errs, fmt.Errorf("%w: %s: empty function name", errors.ErrInvalidConfig, ConfigFunctions))
+		}
+		errs = append(errs, C.Functions[functionName].validate(functionSetting(functionName))...)
+	}
+	return goerrors.Join(errs...)
+}
+
+// validate checks the settings of an app or local manager named by prefix
+func (M ManagerConfig) validate(prefix string) []error {
+	var errs []error
+	if M.MaxRoutines < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s.%s must not be negative, got %d", errors.ErrInvalidConfig, prefix, ConfigMaxRoutines, M.MaxRoutines))
+	}
+	if M.ShutdownTimeout < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s.%s must not be negative, got %v", errors.ErrInvalidConfig, prefix, ConfigShutdownTimeout, M.ShutdownTimeout))
+	}
+	return append(errs, M.Defaults.validate(prefix+"."+ConfigDefaults)...)
+}
+
+// validate checks the routine defaults named by prefix
+func (F FunctionConfig) validate(prefix string) []error {
+	var errs []error
+	if F.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s.timeout must not be negative, got %v", errors.ErrInvalidConfig, prefix, F.Timeout))
+	}
+	if F.MaxConcurrent < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s.max_concurrent must not be negative, got %d", errors.ErrInvalidConfig, prefix, F.MaxConcurrent))
+	}
+	return errs
+}
+
+// Diff returns the settings that differ between C and next, in declaration order.
+// Signal policies are compared field by field, hooks by identity.
+func (C Config) Diff(next Config) []ConfigChange {
+	var changes []ConfigChange
+	add := func(setting string, before, after any) {
+		changes = append(changes, ConfigChange{Setting: setting, Old: before, New: after})
+	}
+	if C.ShutdownTimeout != next.ShutdownTimeout {
+		add(ConfigShutdownTimeout, C.ShutdownTimeout, next.ShutdownTimeout)
+	}
+	if C.MaxRoutines != next.MaxRoutines {
+		add(ConfigMaxRoutines, C.MaxRoutines, next.MaxRoutines)
+	}
+	if C.Metrics != next.Metrics {
+		add(ConfigMetrics, C.Metrics, next.Metrics)
+	}
+	if C.MetricsURL != next.MetricsURL {
+		add(ConfigMetricsURL, C.MetricsURL, next.MetricsURL)
+	}
+	if C.MetricsInterval != next.MetricsInterval {
+		add(ConfigMetricsInterval, C.MetricsInterval, next.MetricsInterval)
+	}
+	if next.Signals != nil && !sameSignalPolicy(C.Signals, next.Signals) {
+		add(ConfigSignals, C.Signals, next.Signals)
+	}
+	diffManager := func(prefix string, before, after ManagerConfig) {
+		if before.MaxRoutines != after.MaxRoutines {
+			add(prefix+"."+ConfigMaxRoutines, before.MaxRoutines, after.MaxRoutines)
+		}
+		if before.ShutdownTimeout != after.ShutdownTimeout {
+			add(prefix+"."+ConfigShutdownTimeout, before.ShutdownTimeout, after.ShutdownTimeout)
+		}
+		if before.Defaults != after.Defaults {
+			add(prefix+"."+ConfigDefaults, before.Defaults, after.Defaults)
+		}
+	}
+	for _, appName := range unionKeys(C.Apps, next.Apps) {
+		before, after := C.Apps[appName], next.Apps[appName]
+		diffManager(appPrefix(appName), before.ManagerConfig, after.ManagerConfig)
+		for _, localName := range unionKeys(before.Locals, after.Locals) {
+			diffManager(localPrefix(appName, localName), before.Locals[localName], after.Locals[localName])
+		}
+	}
+	for _, functionName := range unionKeys(C.Functions, next.Functions) {
+		if before, after := C.Functions[functionName], next.Functions[functionName]; before != after {
+			add(functionSetting(functionName), before, after)
+		}
+	}
+	return changes
+}
+
+// appPrefix is the prefix of the setting names of an app manager
+func appPrefix(appName string) string {
+	return fmt.Sprintf("%s.%s", ConfigApps, appName)
+}
+
+// localPrefix is the prefix of the setting names of a local manager
+func localPrefix(appName, localName string) string {
+	return fmt.Sprintf("%s.%s.locals.%s", ConfigApps, appName, localName)
+}
+
+// functionSetting is the setting name of the defaults of a function name
+func functionSetting(functionName string) string {
+	return fmt.Sprintf("%s.%s", ConfigFunctions, functionName)
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// unionKeys returns the keys of a and b in order
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := sortedKeys(a)
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// copyApps returns a deep copy of apps (nil if empty)
+func copyApps(apps map[string]AppConfig) map[string]AppConfig {
+	if len(apps) == 0 {
+		return nil
+	}
+	copied := make(map[string]AppConfig, len(apps))
+	for appName, app := range apps {
+		if len(app.Locals) > 0 {
+			locals := make(map[string]ManagerConfig, len(app.Locals))
+			for localName, local := range app.Locals {
+				locals[localName] = local
+			}
+			app.Locals = locals
+		} else {
+			app.Locals = nil
+		}
+		copied[appName] = app
+	}
+	return copied
+}
+
+// copyFunctions returns a copy of functions (nil if empty)
+func copyFunctions(functions map[string]FunctionConfig) map[string]FunctionConfig {
+	if len(functions) == 0 {
+		return nil
+	}
+	copied := make(map[string]FunctionConfig, len(functions))
+	for functionName, function := range functions {
+		copied[functionName] = function
+	}
+	return copied
+}
+
+// sameSignalPolicy reports whether two signal policies are equal, comparing hooks by identity
+func sameSignalPolicy(a, b *SignalPolicy) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Disabled == b.Disabled &&
+		a.Immediate == b.Immediate &&
+		reflect.DeepEqual(a.Signals, b.Signals) &&
+		reflect.DeepEqual(a.ReloadSignals, b.ReloadSignals) &&
+		sameFunc(a.OnReload, b.OnReload) &&
+		sameFunc(a.OnExit, b.OnExit)
+}
+
+// sameFunc reports whether two hooks are the same function (both nil counts as the same)
+func sameFunc(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.IsNil() || vb.IsNil() {
+		return va.IsNil() == vb.IsNil()
+	}
+	return va.Pointer() == vb.Pointer()
+}
+
+// GetConfig returns the current configuration of the global manager
+func (GM *GlobalManager) GetConfig() Config {
+	metadata := GM.NewMetadata()
+	return Config{
+		ShutdownTimeout: metadata.GetShutdownTimeout(),
+		MaxRoutines:     metadata.GetMaxRoutines(),
+		Metrics:         metadata.GetMetrics(),
+		MetricsURL:      metadata.GetMetricsURL(),
+		MetricsInterval: metadata.GetUpdateInterval(),
+		Signals:         GM.GetSignalPolicy(),
+		Apps:            metadata.GetApps(),
+		Functions:       metadata.GetFunctions(),
+	}
+}
@@ -0,0 +1,45 @@
+package types
+
+import "time"
+
+// Drain phases reported by DrainProgress
+const (
+	// DrainPhaseWait waits for the routines to finish on their own - nothing is cancelled
+	DrainPhaseWait = "wait"
+	// DrainPhaseCancel cancels the contexts of the routines still running and waits for them
+	DrainPhaseCancel = "cancel"
+	// DrainPhaseForce removes the routines still running from tracking
+	DrainPhaseForce = "force"
+)
+
+// Default drain settings used when a DrainConfig leaves them empty (the timeouts default to the shutdown timeout of the local manager)
+const (
+	DefaultDrainProgressInterval = time.Second
+)
+
+// DrainConfig configures the Drain of a global, app or local manager.
+// Drain rejects new routines, waits up to WaitTimeout for the running routines to finish without
+// cancelling them, then cancels the rest and waits up to CancelTimeout, then removes what is left.
+// With ordered shutdown phases every phase of sibling managers gets the full timeouts.
+type DrainConfig struct {
+	// WaitTimeout bounds the wait for routines to finish on their own (0 = the local manager's shutdown timeout)
+	WaitTimeout time.Duration
+	// CancelTimeout bounds the wait after cancelling the remaining routines (0 = the local manager's shutdown timeout)
+	CancelTimeout time.Duration
+	// ProgressInterval is how often OnProgress is called while routines remain (0 = DefaultDrainProgressInterval)
+	ProgressInterval time.Duration
+	// OnProgress is called by each local manager when a phase begins and every ProgressInterval
+	// while routines remain (nil = no progress). Local managers drain concurrently, so it must be
+	// safe for concurrent use.
+	OnProgress func(progress DrainProgress)
+}
+
+// DrainProgress reports the routines a local manager is still waiting for during a Drain
+type DrainProgress struct {
+	AppName   string
+	LocalName string
+	Phase     string         // DrainPhaseWait, DrainPhaseCancel or DrainPhaseForce
+	Remaining int            // Routines still running
+	Functions map[string]int // Routines still running per function name
+	Elapsed   time.Duration  // Time since the local manager began to drain
+}
@@ -0,0 +1,178 @@
+package types
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType identifies a routine or manager lifecycle event
+type EventType string
+
+// Routine events
+const (
+	EventRoutineStarted   EventType = "routine_started"
+	EventRoutineCompleted EventType = "routine_completed" // Every routine, whatever its outcome
+	EventRoutineErrored   EventType = "routine_errored"   // Worker returned a non-nil error
+	EventRoutinePanicked  EventType = "routine_panicked"  // Worker panicked (once per run for supervised routines)
+	EventRoutineTimedOut  EventType = "routine_timed_out" // Routine context hit its WithTimeout deadline
+	EventRoutineCancelled EventType = "routine_cancelled" // Routine context was cancelled (CancelRoutine, shutdown)
+)
+
+// Manager events
+const (
+	EventAppCreated       EventType = "app_created"
+	EventLocalCreated     EventType = "local_created"
+	EventShutdownBegan    EventType = "shutdown_began"
+	EventShutdownFinished EventType = "shutdown_finished"
+	EventForceCancel      EventType = "force_cancel" // Safe shutdown timed out - Count routines are force-cancelled
+)
+
+// Manager levels of manager events
+const (
+	EventManagerGlobal = "global"
+	EventManagerApp    = "app"
+	EventManagerLocal  = "local"
+)
+
+// Event is a routine or manager lifecycle event delivered to subscribers.
+// Fields that do not apply to an event type are left empty.
+type Event struct {
+	Type         EventType
+	Time         time.Time
+	Manager      string // Manager level for manager events (EventManagerGlobal, EventManagerApp, EventManagerLocal)
+	AppName      string
+	LocalName    string
+	FunctionName string
+	RoutineID    string
+	Duration     time.Duration   // Routine run time (completed) or shutdown duration (shutdown finished)
+	Safe         bool            // Shutdown mode for shutdown events
+	Count        int             // Number of routines affected (force cancel)
+	Err          error           // Worker error or panic
+	Panic        *PanicInfo      // Recovered panic value and stack (routine panicked)
+	Report       *ShutdownReport // Finished report of the shutdown or drain (shutdown finished) - must not be modified
+}
+
+// Subscriber receives orchestrator events.
+// OnEvent is called synchronously on the goroutine that caused the event, so it must be fast
+// and must not block; hand the event off to a channel for slow work. Panics are recovered.
+type Subscriber interface {
+	OnEvent(event Event)
+}
+
+// SubscriberFunc adapts a function to a Subscriber
+type SubscriberFunc func(event Event)
+
+// OnEvent calls the function
+func (SF SubscriberFunc) OnEvent(event Event) {
+	SF(event)
+}
+
+type subscription struct {
+	subscriber Subscriber
+}
+
+// EventBus delivers events to its subscribers. Every manager tree has its own (see
+// GlobalManager.Events); the package-level Subscribe and Publish use the bus of the package-level
+// Global.
+type EventBus struct {
+	// Serialises Subscribe/unsubscribe - Publish reads the copy-on-write list without locking
+	mu          sync.Mutex
+	subscribers atomic.Pointer[[]*subscription]
+}
+
+// defaultEvents is the event bus of the package-level Global
+var defaultEvents = NewEventBus()
+
+// NewEventBus creates an event bus without subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe registers a subscriber for all events of the default manager tree and returns a function
+// that removes it again
+func Subscribe(subscriber Subscriber) (unsubscribe func()) {
+	return defaultEvents.Subscribe(subscriber)
+}
+
+// Publish delivers an event to every subscriber of the default manager tree, setting its Time if unset
+func Publish(event Event) {
+	defaultEvents.Publish(event)
+}
+
+// Events returns the event bus of the manager tree: its own for an isolated tree, the default bus
+// (Subscribe, Publish) for the package-level Global
+func (GM *GlobalManager) Events() *EventBus {
+	if GM == nil || GM.events == nil {
+		return defaultEvents
+	}
+	return GM.events
+}
+
+// Subscribe registers a subscriber for all events of the manager tree and returns a function that
+// removes it again
+func (GM *GlobalManager) Subscribe(subscriber Subscriber) (unsubscribe func()) {
+	return GM.Events().Subscribe(subscriber)
+}
+
+// Publish delivers an event to every subscriber of the manager tree, setting its Time if unset
+func (GM *GlobalManager) Publish(event Event) {
+	GM.Events().Publish(event)
+}
+
+// Subscribe registers a subscriber for all events of the bus and returns a function that removes it again
+func (EB *EventBus) Subscribe(subscriber Subscriber) (unsubscribe func()) {
+	sub := &subscription{subscriber: subscriber}
+
+	EB.mu.Lock()
+	defer EB.mu.Unlock()
+	var current []*subscription
+	if list := EB.subscribers.Load(); list != nil {
+		current = *list
+	}
+	next := make([]*subscription, 0, len(current)+1)
+	next = append(next, current...)
+	next = append(next, sub)
+	EB.subscribers.Store(&next)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			EB.mu.Lock()
+			defer EB.mu.Unlock()
+			list := EB.subscribers.Load()
+			if list == nil {
+				return
+			}
+			next := make([]*subscription, 0, len(*list))
+			for _, existing := range *list {
+				if existing != sub {
+					next = append(next, existing)
+				}
+			}
+			EB.subscribers.Store(&next)
+		})
+	}
+}
+
+// Publish delivers an event to every subscriber of the bus, setting its Time if unset
+func (EB *EventBus) Publish(event Event) {
+	list := EB.subscribers.Load()
+	if list == nil || len(*list) == 0 {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	for _, sub := range *list {
+		deliver(sub.subscriber, event)
+	}
+}
+
+// deliver calls one subscriber, so that a panicking subscriber cannot break routine bookkeeping
+func deliver(subscriber Subscriber, event Event) {
+	defer func() {
+		_ = recover()
+	}()
+	subscriber.OnEvent(event)
+}
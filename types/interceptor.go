@@ -0,0 +1,101 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/manager/errors"
+)
+
+// WorkerFunc is the function a routine runs
+type WorkerFunc func(ctx context.Context) error
+
+// Interceptor wraps every run of a routine's worker function with cross-cutting behavior (logging,
+// tracing, timing, context values). It returns the function run in place of next, which must call
+// next to run the rest of the chain. RoutineInfoFromContext returns the routine being run.
+//
+// Example:
+//
+//	func logRuns(next types.WorkerFunc) types.WorkerFunc {
+//	    return func(ctx context.Context) error {
+//	        info, _ := types.RoutineInfoFromContext(ctx)
+//	        start := time.Now()
+//	        err := next(ctx)
+//	        log.Printf("%s/%s/%s took %v: %v", info.AppName, info.LocalName, info.FunctionName, time.Since(start), err)
+//	        return err
+//	    }
+//	}
+type Interceptor func(next WorkerFunc) WorkerFunc
+
+// RoutineInfo identifies the routine an interceptor or worker function runs in
+type RoutineInfo struct {
+	AppName      string
+	LocalName    string
+	FunctionName string
+	RoutineID    string
+	Restarts     int64 // Supervised restarts before this run
+}
+
+// routineInfoKey is the context key of the RoutineInfo
+type routineInfoKey struct{}
+
+// WithRoutineInfo returns a child context of ctx carrying info
+func WithRoutineInfo(ctx context.Context, info RoutineInfo) context.Context {
+	return context.WithValue(ctx, routineInfoKey{}, info)
+}
+
+// RoutineInfoFromContext returns the routine the context of a worker function run belongs to, and
+// false if ctx is not the context of a routine
+func RoutineInfoFromContext(ctx context.Context) (RoutineInfo, bool) {
+	info, ok := ctx.Value(routineInfoKey{}).(RoutineInfo)
+	return info, ok
+}
+
+// ValidateInterceptors returns an error wrapping ErrInvalidConfig if one of interceptors is nil
+func ValidateInterceptors(interceptors []Interceptor) error {
+	for i, interceptor := range interceptors {
+		if interceptor == nil {
+			return fmt.Errorf("%w: interceptor %d is nil", errors.ErrInvalidConfig, i)
+		}
+	}
+	return nil
+}
+
+// Chain returns next wrapped by interceptors, the first being the outermost
+func Chain(next WorkerFunc, interceptors ...Interceptor) WorkerFunc {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		next = interceptors[i](next)
+	}
+	return next
+}
+
+// interceptorCount numbers the interceptors added to a manager, so that UseInterceptors can remove
+// them again (interceptors are functions and cannot be compared)
+var interceptorCount atomic.Uint64
+
+// appendInterceptors appends interceptors to chain and their new IDs to ids, copying both so that
+// chains built from the previous slices are not affected. It also returns the new IDs.
+func appendInterceptors(chain []Interceptor, ids []uint64, interceptors []Interceptor) ([]Interceptor, []uint64, []uint64) {
+	added := make([]uint64, len(interceptors))
+	for i := range added {
+		added[i] = interceptorCount.Add(1)
+	}
+	chain = append(chain[:len(chain):len(chain)], interceptors...)
+	ids = append(ids[:len(ids):len(ids)], added...)
+	return chain, ids, added
+}
+
+// deleteInterceptors returns copies of chain and ids without the interceptors with the IDs of removed
+func deleteInterceptors(chain []Interceptor, ids []uint64, removed []uint64) ([]Interceptor, []uint64) {
+	keptChain := make([]Interceptor, 0, len(chain))
+	keptIDs := make([]uint64, 0, len(ids))
+	for i, id := range ids {
+		if !slices.Contains(removed, id) {
+			keptChain = append(keptChain, chain[i])
+			keptIDs = append(keptIDs, id)
+		}
+	}
+	return keptChain, keptIDs
+}
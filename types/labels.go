@@ -0,0 +1,12 @@
+package types
+
+// pprof label keys set on the goroutine of every tracked routine.
+// Goroutines started by a routine's worker inherit them, which lets the leak detector
+// attribute untracked goroutines to the routine that started them.
+const (
+	LabelApp       = "orchestrator.app"
+	LabelLocal     = "orchestrator.local"
+	LabelFunction  = "orchestrator.function"
+	LabelRoutineID = "orchestrator.routine_id"
+	LabelTree      = "orchestrator.tree" // GlobalManager.TreeID of an isolated tree - not set for the package-level Global
+)
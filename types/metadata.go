@@ -13,7 +13,7 @@ func (GM *GlobalManager) NewMetadata() *Metadata {
 	}
 
 	md := &Metadata{
-		metadataMu:              &sync.RWMutex{},
+		metadataMu:      &sync.RWMutex{},
 		Metrics:         false,
 		ShutdownTimeout: 10 * time.Second,
 		UpdateInterval:  UpdateInterval,
@@ -22,7 +22,7 @@ func (GM *GlobalManager) NewMetadata() *Metadata {
 	return md
 }
 
-// Right now our codebase not respecting the max routines limit, will set it
+// SetMaxRoutines sets the process wide routine limit enforced by Go() (0 = unlimited)
 func (MD *Metadata) SetMaxRoutines(maxroutines int) *Metadata {
 	// Lock and update
 	MD.metadataMu.Lock()
@@ -76,31 +76,85 @@ func (MD *Metadata) UpdateIntervalTime(time time.Duration) time.Duration {
 
 // ✅ ADD these getter methods
 func (MD *Metadata) GetMetrics() bool {
-    MD.metadataMu.RLock()
-    defer MD.metadataMu.RUnlock()
-    return MD.Metrics
+	MD.metadataMu.RLock()
+	defer MD.metadataMu.RUnlock()
+	return MD.Metrics
 }
 
 func (MD *Metadata) GetMaxRoutines() int {
-    MD.metadataMu.RLock()
-    defer MD.metadataMu.RUnlock()
-    return MD.MaxRoutines
+	MD.metadataMu.RLock()
+	defer MD.metadataMu.RUnlock()
+	return MD.MaxRoutines
 }
 
 func (MD *Metadata) GetUpdateInterval() time.Duration {
-    MD.metadataMu.RLock()
-    defer MD.metadataMu.RUnlock()
-    return MD.UpdateInterval
+	MD.metadataMu.RLock()
+	defer MD.metadataMu.RUnlock()
+	return MD.UpdateInterval
 }
 
 func (MD *Metadata) GetShutdownTimeout() time.Duration {
-    MD.metadataMu.RLock()
-    defer MD.metadataMu.RUnlock()
-    return MD.ShutdownTimeout
+	MD.metadataMu.RLock()
+	defer MD.metadataMu.RUnlock()
+	return MD.ShutdownTimeout
 }
 
 func (MD *Metadata) GetMetricsURL() string {
-    MD.metadataMu.RLock()
-    defer MD.metadataMu.RUnlock()
-    return MD.MetricsURL
-}
\ No newline at end of file
+	MD.metadataMu.RLock()
+	defer MD.metadataMu.RUnlock()
+	return MD.MetricsURL
+}
+
+// SetApps stores the app and local manager settings of the configuration (copied)
+func (MD *Metadata) SetApps(apps map[string]AppConfig) *Metadata {
+	MD.metadataMu.Lock()
+	defer MD.metadataMu.Unlock()
+	MD.Apps = copyApps(apps)
+	return MD
+}
+
+// GetApps returns a copy of the app and local manager settings (nil if none are configured)
+func (MD *Metadata) GetApps() map[string]AppConfig {
+	MD.metadataMu.RLock()
+	defer MD.metadataMu.RUnlock()
+	return copyApps(MD.Apps)
+}
+
+// GetAppConfig returns the configuration of an app manager
+func (MD *Metadata) GetAppConfig(appName string) (AppConfig, bool) {
+	MD.metadataMu.RLock()
+	defer MD.metadataMu.RUnlock()
+	app, ok := MD.Apps[appName]
+	return app, ok
+}
+
+// GetLocalConfig returns the configuration of a local manager
+func (MD *Metadata) GetLocalConfig(appName, localName string) (ManagerConfig, bool) {
+	MD.metadataMu.RLock()
+	defer MD.metadataMu.RUnlock()
+	local, ok := MD.Apps[appName].Locals[localName]
+	return local, ok
+}
+
+// SetFunctions stores the per-function defaults of the configuration (copied)
+func (MD *Metadata) SetFunctions(functions map[string]FunctionConfig) *Metadata {
+	MD.metadataMu.Lock()
+	defer MD.metadataMu.Unlock()
+	MD.Functions = copyFunctions(functions)
+	return MD
+}
+
+// GetFunctions returns a copy of the per-function defaults (nil if none are configured)
+func (MD *Metadata) GetFunctions() map[string]FunctionConfig {
+	MD.metadataMu.RLock()
+	defer MD.metadataMu.RUnlock()
+	return copyFunctions(MD.Functions)
+}
+
+// GetFunctionConfig returns the defaults for the routines of a function name
+func (MD *Metadata) GetFunctionConfig(functionName string) (FunctionConfig, bool) {
+	MD.metadataMu.RLock()
+	defer MD.metadataMu.RUnlock()
+	function, ok := MD.Functions[functionName]
+	return function, ok
+}
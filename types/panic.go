@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PanicInfo describes a panic recovered from a routine's worker function
+type PanicInfo struct {
+	AppName      string
+	LocalName    string
+	FunctionName string
+	RoutineID    string
+	Value        interface{} // Value passed to panic()
+	Stack        []byte      // Stack of the panicking goroutine (debug.Stack)
+	RecoveredAt  time.Time
+}
+
+// String returns the panic value and stack as they would be printed by an unrecovered panic
+func (PI *PanicInfo) String() string {
+	return fmt.Sprintf("panic: %v\n\n%s", PI.Value, PI.Stack)
+}
+
+// PanicHandler is called with every panic recovered from a routine, on the panicking routine's goroutine
+type PanicHandler func(info *PanicInfo)
+
+// panicHandlerSlot holds the panic handler of a manager tree
+type panicHandlerSlot struct {
+	mu      sync.RWMutex
+	handler PanicHandler
+}
+
+// defaultPanicHandler is the panic handler of the package-level Global
+var defaultPanicHandler = &panicHandlerSlot{}
+
+func (PS *panicHandlerSlot) set(handler PanicHandler) {
+	PS.mu.Lock()
+	defer PS.mu.Unlock()
+	PS.handler = handler
+}
+
+func (PS *panicHandlerSlot) get() PanicHandler {
+	PS.mu.RLock()
+	defer PS.mu.RUnlock()
+	return PS.handler
+}
+
+// SetPanicHandler sets the panic handler of the default manager tree, used by routines without
+// their own (local.WithPanicHandler). nil removes it. See GlobalManager.SetPanicHandler.
+func SetPanicHandler(handler PanicHandler) {
+	defaultPanicHandler.set(handler)
+}
+
+// GetPanicHandler returns the panic handler of the default manager tree, or nil if none is set
+func GetPanicHandler() PanicHandler {
+	return defaultPanicHandler.get()
+}
+
+// panicHandlers returns the panic handler slot of the manager tree
+func (GM *GlobalManager) panicHandlers() *panicHandlerSlot {
+	if GM == nil || GM.panicHandler == nil {
+		return defaultPanicHandler
+	}
+	return GM.panicHandler
+}
+
+// SetPanicHandler sets the panic handler of the manager tree, used by its routines without their own
+// (local.WithPanicHandler). nil removes it. For the package-level Global it is the handler of
+// SetPanicHandler.
+func (GM *GlobalManager) SetPanicHandler(handler PanicHandler) {
+	GM.panicHandlers().set(handler)
+}
+
+// GetPanicHandler returns the panic handler of the manager tree, or nil if none is set
+func (GM *GlobalManager) GetPanicHandler() PanicHandler {
+	return GM.panicHandlers().get()
+}
@@ -0,0 +1,90 @@
+package types
+
+import "time"
+
+// Default worker pool settings used when a WorkerPoolConfig leaves them empty
+const (
+	DefaultPoolWorkers     = 1
+	DefaultPoolIdleTimeout = 30 * time.Second
+)
+
+// WorkerPoolConfig configures a worker pool owned by a local manager.
+// A pool with MinWorkers == MaxWorkers has a fixed size; otherwise it scales
+// between the two as the task queue fills up and drains.
+type WorkerPoolConfig struct {
+	MinWorkers  int           // Workers kept running at all times (default 1)
+	MaxWorkers  int           // Upper bound when auto-scaling (default MinWorkers = fixed size)
+	QueueSize   int           // Bounded task queue capacity (default MaxWorkers)
+	IdleTimeout time.Duration // Idle time after which a worker above MinWorkers exits (default 30s)
+}
+
+// Pool is a subsystem owned by a local manager that must be drained when the local manager shuts down.
+type Pool interface {
+	// GetName returns the pool name (unique within the local manager)
+	GetName() string
+	// Drain stops accepting tasks and waits up to timeout for queued tasks to finish
+	Drain(timeout time.Duration) error
+	// Stop stops accepting tasks and discards the queue without waiting
+	Stop()
+}
+
+// AddPool registers a pool with the local manager
+func (LM *LocalManager) AddPool(pool Pool) *LocalManager {
+	// Lock and update
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+
+	if LM.Pools == nil {
+		LM.Pools = make(map[string]Pool)
+	}
+	LM.Pools[pool.GetName()] = pool
+	return LM
+}
+
+// AddPoolIfAbsent registers pool with the local manager unless a pool with its name is registered.
+// It returns the registered pool and whether pool was added.
+func (LM *LocalManager) AddPoolIfAbsent(pool Pool) (Pool, bool) {
+	// Lock and update
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+
+	if existing, ok := LM.Pools[pool.GetName()]; ok {
+		return existing, false
+	}
+	if LM.Pools == nil {
+		LM.Pools = make(map[string]Pool)
+	}
+	LM.Pools[pool.GetName()] = pool
+	return pool, true
+}
+
+// RemovePool removes a pool from the local manager
+func (LM *LocalManager) RemovePool(name string) *LocalManager {
+	// Lock and update
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+
+	delete(LM.Pools, name)
+	return LM
+}
+
+// GetPool gets a specific pool of the local manager
+func (LM *LocalManager) GetPool(name string) (Pool, bool) {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+
+	pool, ok := LM.Pools[name]
+	return pool, ok
+}
+
+// GetPools gets all pools of the local manager
+func (LM *LocalManager) GetPools() []Pool {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+
+	pools := make([]Pool, 0, len(LM.Pools))
+	for _, pool := range LM.Pools {
+		pools = append(pools, pool)
+	}
+	return pools
+}
@@ -0,0 +1,71 @@
+package types
+
+import "sync"
+
+// Default number of completed routine results kept per local manager
+const DefaultRoutineResultsCapacity = 256
+
+// RoutineResult is the outcome of a completed routine
+type RoutineResult struct {
+	RoutineID    string
+	FunctionName string
+	Err          error      // Error returned by the worker, or the recovered panic as an error
+	Panicked     bool       // Whether the worker panicked
+	Panic        *PanicInfo // Recovered panic value and stack (nil unless a recovered panic ended the routine)
+	StartedAt    int64      // Unix nano timestamp
+	FinishedAt   int64      // Unix nano timestamp
+}
+
+// RoutineResults is a bounded store of recent routine results.
+// Once full, the oldest result is evicted when a new one is added.
+type RoutineResults struct {
+	mu       sync.RWMutex
+	capacity int
+	order    []string // Routine IDs in insertion order (ring buffer)
+	next     int      // Next write position in order
+	results  map[string]*RoutineResult
+}
+
+// NewRoutineResults creates a result store holding at most capacity results
+func NewRoutineResults(capacity int) *RoutineResults {
+	if capacity <= 0 {
+		capacity = DefaultRoutineResultsCapacity
+	}
+	return &RoutineResults{
+		capacity: capacity,
+		order:    make([]string, 0, capacity),
+		results:  make(map[string]*RoutineResult, capacity),
+	}
+}
+
+// Add stores a result, evicting the oldest one if the store is full
+func (RR *RoutineResults) Add(result *RoutineResult) *RoutineResults {
+	RR.mu.Lock()
+	defer RR.mu.Unlock()
+
+	if len(RR.order) < RR.capacity {
+		RR.order = append(RR.order, result.RoutineID)
+	} else {
+		// Evict the oldest entry and reuse its slot
+		delete(RR.results, RR.order[RR.next])
+		RR.order[RR.next] = result.RoutineID
+		RR.next = (RR.next + 1) % RR.capacity
+	}
+	RR.results[result.RoutineID] = result
+	return RR
+}
+
+// Get returns the result for a routine ID, if it is still stored
+func (RR *RoutineResults) Get(routineID string) (*RoutineResult, bool) {
+	RR.mu.RLock()
+	defer RR.mu.RUnlock()
+	result, ok := RR.results[routineID]
+	return result, ok
+}
+
+// Len returns the number of stored results
+func (RR *RoutineResults) Len() int {
+	RR.mu.RLock()
+	defer RR.mu.RUnlock()
+	return len(RR.results)
+}
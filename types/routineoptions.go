@@ -0,0 +1,26 @@
+package types
+
+// RoutineOptions describes the options in effect for a routine: the configured defaults and the
+// default options of its local manager and function name, merged with the options passed to Go()
+type RoutineOptions struct {
+	Timeout       ReportDuration    `json:"timeout,omitempty"` // 0 = no timeout
+	PanicRecovery bool              `json:"panic_recovery"`
+	WaitGroup     string            `json:"wait_group,omitempty"`
+	MaxConcurrent int               `json:"max_concurrent,omitempty"` // 0 = unlimited
+	Singleflight  string            `json:"singleflight,omitempty"`
+	AdmissionWait bool              `json:"admission_wait,omitempty"` // Go() waits for a routine slot instead of failing
+	Supervised    bool              `json:"supervised,omitempty"`     // Restarted according to a restart policy
+	Labels        map[string]string `json:"labels,omitempty"`
+}
+
+// copyOptions returns opts with its own copy of the labels
+func copyOptions(opts RoutineOptions) RoutineOptions {
+	if opts.Labels != nil {
+		labels := make(map[string]string, len(opts.Labels))
+		for key, value := range opts.Labels {
+			labels[key] = value
+		}
+		opts.Labels = labels
+	}
+	return opts
+}
@@ -0,0 +1,119 @@
+package types
+
+import "time"
+
+// OverlapPolicy decides what happens when a scheduled run is due while the previous run is still going
+type OverlapPolicy int
+
+const (
+	// OverlapSkip skips the due run (default)
+	OverlapSkip OverlapPolicy = iota
+	// OverlapQueue starts the due run as soon as the previous run finishes
+	OverlapQueue
+	// OverlapAllow starts the due run concurrently with the previous run
+	OverlapAllow
+)
+
+// MissedRunPolicy decides what happens when one or more scheduled run times have passed without a run,
+// e.g. after a long queued run, a process pause or a system sleep
+type MissedRunPolicy int
+
+const (
+	// MissedRunOnce runs once for all missed run times, then continues with the next future run time (default)
+	MissedRunOnce MissedRunPolicy = iota
+	// MissedRunSkip drops the missed runs and waits for the next future run time
+	MissedRunSkip
+)
+
+// Schedule kinds reported in ScheduleStatus
+const (
+	ScheduleKindInterval   = "interval"
+	ScheduleKindFixedDelay = "fixed_delay"
+	ScheduleKindCron       = "cron"
+)
+
+// ScheduleConfig configures a schedule owned by a local manager.
+// Exactly one of Interval, Delay and Cron must be set.
+type ScheduleConfig struct {
+	Interval       time.Duration   // Fixed interval: runs start Interval apart
+	Delay          time.Duration   // Fixed delay: the next run starts Delay after the previous run finished
+	Cron           string          // Cron expression: "min hour day-of-month month day-of-week" or @hourly, @daily, ...
+	Location       *time.Location  // Time zone of the cron expression (default time.Local)
+	Jitter         time.Duration   // Random delay in [0, Jitter) added to every run time
+	Overlap        OverlapPolicy   // Due run while the previous one is running (default OverlapSkip)
+	MissedRun      MissedRunPolicy // Run times passed without a run (default MissedRunOnce)
+	RunImmediately bool            // Run once when the schedule starts instead of waiting for the first run time
+}
+
+// ScheduleStatus is the state of a schedule at the time it was read
+type ScheduleStatus struct {
+	Name         string         `json:"name"`
+	Kind         string         `json:"kind"` // ScheduleKindInterval, ScheduleKindFixedDelay or ScheduleKindCron
+	Spec         string         `json:"spec"` // Interval, delay or cron expression
+	NextRun      *time.Time     `json:"next_run,omitempty"`
+	LastRun      *time.Time     `json:"last_run,omitempty"`
+	LastDuration ReportDuration `json:"last_duration,omitempty"`
+	LastError    string         `json:"last_error,omitempty"` // Error of the last finished run ("" if it succeeded)
+	Running      int            `json:"running"`              // Runs currently in progress
+	Runs         int64          `json:"runs"`                 // Finished runs
+	Failures     int64          `json:"failures"`             // Finished runs that returned an error or panicked
+	Skipped      int64          `json:"skipped"`              // Runs skipped because the previous run was still going
+	Missed       int64          `json:"missed"`               // Run times that passed without a run
+}
+
+// Schedule is a subsystem owned by a local manager that starts tracked routines on a schedule.
+// It must be stopped when the local manager shuts down.
+type Schedule interface {
+	// GetName returns the schedule name (unique within the local manager)
+	GetName() string
+	// Status returns the schedule's current state
+	Status() ScheduleStatus
+	// Stop stops starting runs and cancels the runs in progress without waiting
+	Stop()
+	// Drain stops starting runs and lets the runs in progress finish
+	Drain()
+}
+
+// AddSchedule registers a schedule with the local manager
+func (LM *LocalManager) AddSchedule(schedule Schedule) *LocalManager {
+	// Lock and update
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+
+	if LM.Schedules == nil {
+		LM.Schedules = make(map[string]Schedule)
+	}
+	LM.Schedules[schedule.GetName()] = schedule
+	return LM
+}
+
+// RemoveSchedule removes a schedule from the local manager
+func (LM *LocalManager) RemoveSchedule(name string) *LocalManager {
+	// Lock and update
+	LM.lockLocalWriteMutex()
+	defer LM.unlockLocalWriteMutex()
+
+	delete(LM.Schedules, name)
+	return LM
+}
+
+// GetSchedule gets a specific schedule of the local manager
+func (LM *LocalManager) GetSchedule(name string) (Schedule, bool) {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+
+	schedule, ok := LM.Schedules[name]
+	return schedule, ok
+}
+
+// GetSchedules gets all schedules of the local manager
+func (LM *LocalManager) GetSchedules() []Schedule {
+	LM.lockLocalReadMutex()
+	defer LM.unlockLocalReadMutex()
+
+	schedules := make([]Schedule, 0, len(LM.Schedules))
+	for _, schedule := range LM.Schedules {
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
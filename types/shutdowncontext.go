@@ -0,0 +1,59 @@
+package types
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// NoTimeout is the timeout of a context-aware shutdown or wait whose context has no deadline:
+// it only ends when the context is cancelled
+const NoTimeout = time.Duration(math.MaxInt64)
+
+// ContextTimeout returns the time left until the deadline of ctx (0 if it has passed), or NoTimeout
+// if ctx has no deadline
+func ContextTimeout(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return NoTimeout
+	}
+	if timeout := time.Until(deadline); timeout > 0 {
+		return timeout
+	}
+	return 0
+}
+
+// WaitContext waits for wg unless ctx ends first. Returns true if wg completed.
+// If ctx ends first, the goroutine waiting for wg keeps running until wg completes.
+func WaitContext(ctx context.Context, wg *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// BoundContext returns ctx bounded by timeout as well (ctx itself if timeout is 0) - an app or local
+// manager's own shutdown timeout bounds its part of the parent's shutdown
+func BoundContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// BoundTimeout returns the shorter of timeout and bound (timeout if bound is 0)
+func BoundTimeout(timeout, bound time.Duration) time.Duration {
+	if bound > 0 && bound < timeout {
+		return bound
+	}
+	return timeout
+}
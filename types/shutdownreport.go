@@ -0,0 +1,152 @@
+package types
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Shutdown report levels
+const (
+	ShutdownLevelGlobal   = "global"
+	ShutdownLevelApp      = "app"
+	ShutdownLevelLocal    = "local"
+	ShutdownLevelFunction = "function"
+)
+
+// ReportDuration is a time.Duration that is printed as a string ("1.5s") in JSON reports
+type ReportDuration time.Duration
+
+// MarshalJSON encodes the duration as a string
+func (RD ReportDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(RD).String())
+}
+
+// UnmarshalJSON decodes a duration string
+func (RD *ReportDuration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*RD = ReportDuration(d)
+	return nil
+}
+
+// ShutdownPhase is one timed step of a shutdown (e.g. graceful wait, force cancel)
+type ShutdownPhase struct {
+	Name      string         `json:"name"`
+	StartedAt time.Time      `json:"started_at"`
+	Duration  ReportDuration `json:"duration"`
+}
+
+// ShutdownReport is one node of the shutdown report tree (global → app → local → function).
+//
+// Routine IDs are only listed on function nodes: Graceful routines exited within the shutdown
+// timeout, ForceCancelled routines exited only after being force-cancelled, and StillRunning
+// routines had not exited when the report was built. Parent nodes aggregate them via Totals.
+type ShutdownReport struct {
+	Level          string            `json:"level"`
+	Name           string            `json:"name"`
+	Safe           bool              `json:"safe"`
+	StartedAt      time.Time         `json:"started_at"`
+	Duration       ReportDuration    `json:"duration"`
+	Phases         []ShutdownPhase   `json:"phases,omitempty"`
+	Graceful       []string          `json:"graceful,omitempty"`
+	ForceCancelled []string          `json:"force_cancelled,omitempty"`
+	StillRunning   []string          `json:"still_running,omitempty"`
+	Errors         []string          `json:"errors,omitempty"`
+	Children       []*ShutdownReport `json:"children,omitempty"`
+}
+
+// NewShutdownReport creates a report node started now
+func NewShutdownReport(level, name string, safe bool) *ShutdownReport {
+	return &ShutdownReport{
+		Level:     level,
+		Name:      name,
+		Safe:      safe,
+		StartedAt: time.Now(),
+	}
+}
+
+// AddPhase records a phase that started at start and ended now
+func (SR *ShutdownReport) AddPhase(name string, start time.Time) *ShutdownReport {
+	SR.Phases = append(SR.Phases, ShutdownPhase{Name: name, StartedAt: start, Duration: ReportDuration(time.Since(start))})
+	return SR
+}
+
+// AddError records a non-fatal error that happened during shutdown
+func (SR *ShutdownReport) AddError(err error) *ShutdownReport {
+	if err != nil {
+		SR.Errors = append(SR.Errors, err.Error())
+	}
+	return SR
+}
+
+// AddChild attaches a child report (ignored if nil)
+func (SR *ShutdownReport) AddChild(child *ShutdownReport) *ShutdownReport {
+	if child != nil {
+		SR.Children = append(SR.Children, child)
+	}
+	return SR
+}
+
+// SortChildren orders the child reports by name for readable reports
+func (SR *ShutdownReport) SortChildren() *ShutdownReport {
+	sort.Slice(SR.Children, func(i, j int) bool { return SR.Children[i].Name < SR.Children[j].Name })
+	return SR
+}
+
+// Finish sets the total duration of the node
+func (SR *ShutdownReport) Finish() *ShutdownReport {
+	SR.Duration = ReportDuration(time.Since(SR.StartedAt))
+	return SR
+}
+
+// Totals returns the number of graceful, force-cancelled and still running routines in this subtree
+func (SR *ShutdownReport) Totals() (graceful, forceCancelled, stillRunning int) {
+	graceful = len(SR.Graceful)
+	forceCancelled = len(SR.ForceCancelled)
+	stillRunning = len(SR.StillRunning)
+	for _, child := range SR.Children {
+		g, f, s := child.Totals()
+		graceful += g
+		forceCancelled += f
+		stillRunning += s
+	}
+	return graceful, forceCancelled, stillRunning
+}
+
+// Clean reports whether every routine in this subtree exited gracefully and no errors were recorded
+func (SR *ShutdownReport) Clean() bool {
+	if len(SR.Errors) > 0 {
+		return false
+	}
+	_, forceCancelled, stillRunning := SR.Totals()
+	if forceCancelled > 0 || stillRunning > 0 {
+		return false
+	}
+	for _, child := range SR.Children {
+		if !child.Clean() {
+			return false
+		}
+	}
+	return true
+}
+
+// JSON returns the report as indented JSON, e.g. for deploy logs
+func (SR *ShutdownReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(SR, "", "  ")
+}
+
+// String returns the report as JSON
+func (SR *ShutdownReport) String() string {
+	data, err := SR.JSON()
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
@@ -0,0 +1,221 @@
+package types
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/JupiterMetaLabs/goroutine-orchestrator/ctxo"
+)
+
+// Exit codes passed to SignalPolicy.OnExit
+const (
+	// ExitCodeClean is passed when every routine exited gracefully
+	ExitCodeClean = 0
+	// ExitCodeUnclean is passed when routines had to be force-cancelled or the shutdown failed
+	ExitCodeUnclean = 1
+)
+
+// SignalPolicy configures how a global manager handles process signals.
+// The zero value traps SIGINT and SIGTERM: the first signal runs a graceful Shutdown(true) of the
+// global manager, and a second signal during it cancels the graceful shutdown and forces Shutdown(false).
+type SignalPolicy struct {
+	// Disabled installs no signal handlers - the host application owns signals and shuts down itself
+	Disabled bool
+	// Signals trigger the shutdown (default SIGINT and SIGTERM)
+	Signals []os.Signal
+	// Immediate makes the first signal force Shutdown(false) instead of a graceful Shutdown(true)
+	Immediate bool
+	// OnReload is called for each reload signal instead of shutting down (nil = reload signals are not trapped)
+	OnReload func(sig os.Signal)
+	// ReloadSignals call OnReload (default SIGHUP)
+	ReloadSignals []os.Signal
+	// OnExit is called once a signal-triggered shutdown finished, with ExitCodeClean or
+	// ExitCodeUnclean (nil = keep running). Pass os.Exit to exit the process.
+	OnExit func(code int)
+}
+
+// ShutdownSignals returns the signals that trigger the shutdown
+func (SP SignalPolicy) ShutdownSignals() []os.Signal {
+	if len(SP.Signals) == 0 {
+		return []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+	return SP.Signals
+}
+
+// ReloadSignalList returns the signals that call OnReload (none without OnReload)
+func (SP SignalPolicy) ReloadSignalList() []os.Signal {
+	if SP.OnReload == nil {
+		return nil
+	}
+	if len(SP.ReloadSignals) == 0 {
+		return []os.Signal{syscall.SIGHUP}
+	}
+	return SP.ReloadSignals
+}
+
+// SignalShutdown runs the shutdown of a global manager for a signal. The graceful shutdown (safe)
+// must return once ctx is cancelled - a second signal cancels it before forcing the shutdown.
+type SignalShutdown func(ctx context.Context, safe bool) (*ShutdownReport, error)
+
+// signalHandler is the process signal handler installed for a global manager
+type signalHandler struct {
+	policy SignalPolicy
+	sigCh  chan os.Signal
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// The handler of the package-level singleton - a new singleton (e.g. after a reset) replaces it
+var (
+	singletonSignals *signalHandler
+	signalsMu        sync.Mutex
+)
+
+// HandleSignals installs the process signal handler of the global manager according to policy,
+// replacing the handler installed before. shutdown runs the global manager's shutdown (safe or
+// forced); nil cancels the manager tree's contexts instead. A disabled policy only removes the
+// previous handler.
+func (GM *GlobalManager) HandleSignals(policy SignalPolicy, shutdown SignalShutdown) {
+	signalsMu.Lock()
+	defer signalsMu.Unlock()
+
+	slot := &singletonSignals
+	if GM.isolated {
+		slot = &GM.signals
+	}
+	if *slot != nil {
+		(*slot).close()
+		*slot = nil
+	}
+	GM.signalPolicy = &policy
+	if policy.Disabled {
+		return
+	}
+
+	if shutdown == nil {
+		shutdown = GM.cancelOnSignal
+	}
+	handler := &signalHandler{
+		policy: policy,
+		sigCh:  make(chan os.Signal, 2),
+		stop:   make(chan struct{}),
+	}
+	signal.Notify(handler.sigCh, append(policy.ShutdownSignals(), policy.ReloadSignalList()...)...)
+	go handler.run(shutdown)
+	*slot = handler
+}
+
+// GetSignalPolicy returns the signal policy installed by HandleSignals (nil if none was installed)
+func (GM *GlobalManager) GetSignalPolicy() *SignalPolicy {
+	signalsMu.Lock()
+	defer signalsMu.Unlock()
+	return GM.signalPolicy
+}
+
+// StopSignals removes the process signal handler of the global manager, if any
+func (GM *GlobalManager) StopSignals() {
+	signalsMu.Lock()
+	defer signalsMu.Unlock()
+	slot := &singletonSignals
+	if GM.isolated {
+		slot = &GM.signals
+	}
+	if *slot != nil {
+		(*slot).close()
+		*slot = nil
+	}
+}
+
+// cancelOnSignal cancels the contexts of the manager tree without running a manager shutdown
+func (GM *GlobalManager) cancelOnSignal(ctx context.Context, safe bool) (*ShutdownReport, error) {
+	if GM.isolated {
+		if _, cancel := GM.GetGlobalContext(); cancel != nil {
+			cancel()
+		}
+	} else {
+		ctxo.GetGlobalContext().Shutdown()
+	}
+	return nil, nil
+}
+
+// run handles signals until the shutdown they triggered has finished or the handler is closed
+func (SH *signalHandler) run(shutdown SignalShutdown) {
+	defer signal.Stop(SH.sigCh)
+
+	reload := make(map[os.Signal]bool)
+	for _, sig := range SH.policy.ReloadSignalList() {
+		reload[sig] = true
+	}
+
+	var (
+		finished       chan int
+		cancelGraceful context.CancelFunc
+	)
+	for {
+		select {
+		case <-SH.stop:
+			return
+		case code := <-finished:
+			SH.exit(code)
+			return
+		case sig := <-SH.sigCh:
+			if reload[sig] {
+				log.Printf("Global manager received reload signal: %s", sig)
+				SH.policy.OnReload(sig)
+				continue
+			}
+			if finished == nil && !SH.policy.Immediate {
+				// First signal: graceful shutdown in the background so a second signal can force it
+				log.Printf("Global manager received shutdown signal: %s", sig)
+				finished = make(chan int, 1)
+				cancelGraceful = startGraceful(shutdown, finished)
+				continue
+			}
+			log.Printf("Global manager received shutdown signal: %s, forcing shutdown", sig)
+			if cancelGraceful != nil {
+				// Stop the graceful shutdown and wait for it, so the forced one never runs alongside it
+				cancelGraceful()
+				<-finished
+			}
+			shutdown(context.Background(), false)
+			SH.exit(ExitCodeUnclean)
+			return
+		}
+	}
+}
+
+// startGraceful runs the graceful shutdown in the background, sending its exit code to finished,
+// and returns the function that cancels it
+func startGraceful(shutdown SignalShutdown, finished chan<- int) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer cancel()
+		finished <- exitCode(shutdown(ctx, true))
+	}()
+	return cancel
+}
+
+// exit calls OnExit once the handler stopped listening
+func (SH *signalHandler) exit(code int) {
+	SH.once.Do(func() { close(SH.stop) })
+	if SH.policy.OnExit != nil {
+		SH.policy.OnExit(code)
+	}
+}
+
+// close stops the handler
+func (SH *signalHandler) close() {
+	SH.once.Do(func() { close(SH.stop) })
+}
+
+// exitCode maps the result of a graceful shutdown to ExitCodeClean or ExitCodeUnclean
+func exitCode(report *ShutdownReport, err error) int {
+	if err != nil || (report != nil && !report.Clean()) {
+		return ExitCodeUnclean
+	}
+	return ExitCodeClean
+}
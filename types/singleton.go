@@ -14,10 +14,21 @@ var (
 func SetGlobalManager(global *GlobalManager) {
 	// By using this once - we can avoid the race condition thus made thread safe
 	once.Do(func() {
+		lock.Lock()
+		defer lock.Unlock()
 		Global = global
 	})
 }
 
+// ResetGlobalManager clears the package-level Global, so that the next manager creates a new one.
+// It is meant for tests - the routines of the previous tree should have finished.
+func ResetGlobalManager() {
+	lock.Lock()
+	defer lock.Unlock()
+	Global = nil
+	once = sync.Once{}
+}
+
 func SetAppManager(appName string, app *AppManager) {
 	if IsIntilized().App(appName) {
 		return
@@ -50,14 +61,14 @@ func GetGlobalManager() (*GlobalManager, error) {
 
 func GetAppManager(appName string) (*AppManager, error) {
 	if !IsIntilized().App(appName) {
-		return nil, errors.ErrAppManagerNotFound
+		return nil, &errors.NotFoundError{Name: appName, Err: errors.ErrAppManagerNotFound}
 	}
 	return Global.GetAppManager(appName)
 }
 
 func GetLocalManager(appName, localName string) (*LocalManager, error) {
 	if !IsIntilized().App(appName) {
-		return nil, errors.ErrAppManagerNotFound
+		return nil, &errors.NotFoundError{Name: appName, Err: errors.ErrAppManagerNotFound}
 	}
 	appManager, err := Global.GetAppManager(appName)
 	if err != nil {
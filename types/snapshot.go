@@ -0,0 +1,143 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// SnapshotFilter selects the routines included in a manager tree snapshot.
+// Empty fields match everything.
+type SnapshotFilter struct {
+	AppName      string
+	LocalName    string
+	FunctionName string
+	MinAge       time.Duration // Only routines running for at least MinAge
+}
+
+// RoutineSnapshot is the state of one tracked routine at snapshot time
+type RoutineSnapshot struct {
+	ID           string         `json:"id"`
+	FunctionName string         `json:"function_name"`
+	StartedAt    time.Time      `json:"started_at"`
+	Age          ReportDuration `json:"age"`
+	Deadline     *time.Time     `json:"deadline,omitempty"` // WithTimeout deadline, if any
+	Cancelled    bool           `json:"cancelled"`          // Context cancelled or deadline exceeded
+	WaitGroup    string         `json:"wait_group,omitempty"`
+	Restarts     int64          `json:"restarts,omitempty"`
+	Options      RoutineOptions `json:"options"`
+}
+
+// LocalSnapshot is the state of one local manager at snapshot time
+type LocalSnapshot struct {
+	Name         string            `json:"name"`
+	RoutineCount int               `json:"routine_count"` // All tracked routines, before filtering
+	Routines     []RoutineSnapshot `json:"routines"`
+	Schedules    []ScheduleStatus  `json:"schedules,omitempty"`
+}
+
+// AppSnapshot is the state of one app manager at snapshot time
+type AppSnapshot struct {
+	Name   string          `json:"name"`
+	Locals []LocalSnapshot `json:"locals"`
+}
+
+// ManagerSnapshot is a point-in-time view of the Global → App → Local → Routine tree
+type ManagerSnapshot struct {
+	TakenAt      time.Time     `json:"taken_at"`
+	RoutineCount int           `json:"routine_count"` // Routines matching the filter
+	Apps         []AppSnapshot `json:"apps"`
+}
+
+// TakeSnapshot returns the live manager tree, restricted to the routines matching filter.
+// Apps and local managers are sorted by name, routines oldest first. A filter on app or local
+// name also drops the non-matching managers; the other filters keep managers with no matches.
+func TakeSnapshot(filter SnapshotFilter) *ManagerSnapshot {
+	globalManager, err := GetGlobalManager()
+	if err != nil {
+		return &ManagerSnapshot{TakenAt: time.Now(), Apps: []AppSnapshot{}}
+	}
+	return globalManager.TakeSnapshot(filter)
+}
+
+// TakeSnapshot returns the live tree of this global manager like the package-level TakeSnapshot
+func (GM *GlobalManager) TakeSnapshot(filter SnapshotFilter) *ManagerSnapshot {
+	now := time.Now()
+	snapshot := &ManagerSnapshot{TakenAt: now, Apps: []AppSnapshot{}}
+
+	GM.LockGlobalReadMutex()
+	appManagers := make([]*AppManager, 0, len(GM.AppManagers))
+	for appName, appManager := range GM.AppManagers {
+		if filter.AppName == "" || filter.AppName == appName {
+			appManagers = append(appManagers, appManager)
+		}
+	}
+	GM.UnlockGlobalReadMutex()
+
+	for _, appManager := range appManagers {
+		appSnapshot := AppSnapshot{Name: appManager.GetAppName(), Locals: []LocalSnapshot{}}
+
+		appManager.LockAppReadMutex()
+		localManagers := make([]*LocalManager, 0, len(appManager.LocalManagers))
+		for localName, localManager := range appManager.LocalManagers {
+			if filter.LocalName == "" || filter.LocalName == localName {
+				localManagers = append(localManagers, localManager)
+			}
+		}
+		appManager.UnlockAppReadMutex()
+
+		for _, localManager := range localManagers {
+			routines := localManager.GetRoutines()
+			localSnapshot := LocalSnapshot{
+				Name:         localManager.GetLocalName(),
+				RoutineCount: len(routines),
+				Routines:     []RoutineSnapshot{},
+			}
+			for _, routine := range routines {
+				if filter.FunctionName != "" && filter.FunctionName != routine.GetFunctionName() {
+					continue
+				}
+				startedAt := time.Unix(0, routine.GetStartedAt())
+				age := now.Sub(startedAt)
+				if age < filter.MinAge {
+					continue
+				}
+
+				routineSnapshot := RoutineSnapshot{
+					ID:           routine.GetID(),
+					FunctionName: routine.GetFunctionName(),
+					StartedAt:    startedAt,
+					Age:          ReportDuration(age),
+					WaitGroup:    routine.GetWaitGroup(),
+					Restarts:     routine.GetRestarts(),
+					Options:      routine.GetOptions(),
+				}
+				if ctx := routine.GetContext(); ctx != nil {
+					if deadline, ok := ctx.Deadline(); ok {
+						routineSnapshot.Deadline = &deadline
+					}
+					routineSnapshot.Cancelled = ctx.Err() != nil
+				}
+				localSnapshot.Routines = append(localSnapshot.Routines, routineSnapshot)
+			}
+			sort.Slice(localSnapshot.Routines, func(i, j int) bool {
+				return localSnapshot.Routines[i].StartedAt.Before(localSnapshot.Routines[j].StartedAt)
+			})
+			for _, schedule := range localManager.GetSchedules() {
+				if filter.FunctionName != "" && filter.FunctionName != schedule.GetName() {
+					continue
+				}
+				localSnapshot.Schedules = append(localSnapshot.Schedules, schedule.Status())
+			}
+			sort.Slice(localSnapshot.Schedules, func(i, j int) bool {
+				return localSnapshot.Schedules[i].Name < localSnapshot.Schedules[j].Name
+			})
+			snapshot.RoutineCount += len(localSnapshot.Routines)
+			appSnapshot.Locals = append(appSnapshot.Locals, localSnapshot)
+		}
+		sort.Slice(appSnapshot.Locals, func(i, j int) bool { return appSnapshot.Locals[i].Name < appSnapshot.Locals[j].Name })
+		snapshot.Apps = append(snapshot.Apps, appSnapshot)
+	}
+	sort.Slice(snapshot.Apps, func(i, j int) bool { return snapshot.Apps[i].Name < snapshot.Apps[j].Name })
+
+	return snapshot
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ManagerState is the lifecycle state of a global, app or local manager.
+// Managers start running, drain while they shut down and are stopped afterwards.
+// Stopped managers are reopened when they are created again (Init, CreateApp, CreateLocal).
+type ManagerState int32
+
+const (
+	// StateRunning accepts new routines and child managers (default)
+	StateRunning ManagerState = iota
+	// StateDraining is shutting down - new routines and child managers are rejected
+	StateDraining
+	// StateStopped has shut down - new routines and child managers are rejected until it is reopened
+	StateStopped
+)
+
+// String returns the name of the state ("running", "draining" or "stopped")
+func (S ManagerState) String() string {
+	switch S {
+	case StateRunning:
+		return "running"
+	case StateDraining:
+		return "draining"
+	case StateStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// reopen moves state from stopped to running, calling init in between.
+// Concurrent callers see the manager draining until init has returned; only one of them runs init.
+func reopen(state *int32, init func()) bool {
+	if !atomic.CompareAndSwapInt32(state, int32(StateStopped), int32(StateDraining)) {
+		return false
+	}
+	init()
+	atomic.StoreInt32(state, int32(StateRunning))
+	return true
+}
+
+// GetState returns the lifecycle state of the global manager
+func (GM *GlobalManager) GetState() ManagerState {
+	return ManagerState(atomic.LoadInt32(&GM.state))
+}
+
+// SetState sets the lifecycle state of the global manager
+func (GM *GlobalManager) SetState(state ManagerState) *GlobalManager {
+	atomic.StoreInt32(&GM.state, int32(state))
+	return GM
+}
+
+// Reopen returns a stopped global manager to running with a fresh root context if the old one was
+// cancelled. It reports whether the global manager was stopped.
+func (GM *GlobalManager) Reopen() bool {
+	return reopen(&GM.state, func() {
+		if ctx, _ := GM.GetGlobalContext(); ctx != nil && ctx.Err() == nil {
+			return
+		}
+		if GM.isolated {
+			GM.LockGlobalWriteMutex()
+			GM.Ctx, GM.Cancel = context.WithCancel(context.Background())
+			GM.UnlockGlobalWriteMutex()
+		} else {
+			GM.SetGlobalContext()
+		}
+	})
+}
+
+// GetState returns the lifecycle state of the app manager
+func (AM *AppManager) GetState() ManagerState {
+	return ManagerState(atomic.LoadInt32(&AM.state))
+}
+
+// SetState sets the lifecycle state of the app manager
+func (AM *AppManager) SetState(state ManagerState) *AppManager {
+	atomic.StoreInt32(&AM.state, int32(state))
+	return AM
+}
+
+// Reopen returns a stopped app manager to running with a fresh context derived from parent
+// (nil derives it from the process-wide app context, as for app managers of the singleton).
+// It reports whether the app manager was stopped.
+func (AM *AppManager) Reopen(parent context.Context) bool {
+	return reopen(&AM.state, func() {
+		AM.LockAppWriteMutex()
+		defer AM.UnlockAppWriteMutex()
+		if parent != nil {
+			AM.Ctx, AM.Cancel = context.WithCancel(parent)
+		} else {
+			AM.SetAppContext()
+		}
+	})
+}
+
+// GetState returns the lifecycle state of the local manager
+func (LM *LocalManager) GetState() ManagerState {
+	return ManagerState(atomic.LoadInt32(&LM.state))
+}
+
+// SetState sets the lifecycle state of the local manager
+func (LM *LocalManager) SetState(state ManagerState) *LocalManager {
+	atomic.StoreInt32(&LM.state, int32(state))
+	return LM
+}
+
+// Reopen returns a stopped local manager to running with a fresh context derived from parent
+// (nil derives it from the process-wide local context, as for local managers of the singleton).
+// It reports whether the local manager was stopped.
+func (LM *LocalManager) Reopen(parent context.Context) bool {
+	return reopen(&LM.state, func() {
+		if parent != nil {
+			LM.SetLocalContextFrom(parent)
+		} else {
+			LM.SetLocalContext()
+		}
+	})
+}
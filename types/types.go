@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"net/http"
 	"sync"
 	"time"
 )
@@ -27,6 +28,22 @@ type GlobalManager struct {
 	Cancel      context.CancelFunc
 	Wg          *sync.WaitGroup
 	Metadata    *Metadata
+	isolated    bool   // Created by NewIsolatedGlobalManager instead of being the package-level Global
+	treeID      string // Identifies an isolated tree in goroutine labels (LabelTree)
+	state       int32  // ManagerState (use sync/atomic)
+
+	signals      *signalHandler // Process signal handler of an isolated tree (guarded by signalsMu)
+	signalPolicy *SignalPolicy  // Signal policy installed by HandleSignals (guarded by signalsMu)
+
+	Interceptors   []Interceptor // Wrap the worker runs of every routine of the tree
+	interceptorIDs []uint64      // IDs of Interceptors, for removing them (UseInterceptors)
+
+	// Serves the tree's registry on the metrics server of an isolated tree (nil = a TreeCollector)
+	MetricsHandler http.Handler
+
+	events       *EventBus         // Event bus of an isolated tree (nil = the default bus)
+	panicHandler *panicHandlerSlot // Panic handler of an isolated tree (nil = the default handler)
+	admission    *AdmissionGate    // Admission gate of an isolated tree (nil = the default gate)
 }
 
 // AppManager manages local-level managers for a specific app/module
@@ -38,6 +55,14 @@ type AppManager struct {
 	Cancel        context.CancelFunc
 	Wg            *sync.WaitGroup
 	ParentCtx     context.Context
+	MaxRoutines   int           // Per-app routine quota (0 = unlimited)
+	ShutdownOrder ShutdownOrder // Shutdown ordering among the other app managers
+	// Overrides of the global shutdown timeout and routine defaults (zero values = inherited)
+	ShutdownTimeout time.Duration
+	Defaults        FunctionConfig
+	Interceptors    []Interceptor // Wrap the worker runs of every routine of the app
+	interceptorIDs  []uint64      // IDs of Interceptors, for removing them (UseInterceptors)
+	state           int32         // ManagerState (use sync/atomic)
 }
 
 // LocalManager manages goroutines for a specific file/module within an app
@@ -50,26 +75,50 @@ type LocalManager struct {
 	Wg          *sync.WaitGroup
 	FunctionWgs map[string]*sync.WaitGroup // Per function name for selective shutdown
 	ParentCtx   context.Context
+	MaxRoutines int                 // Per-local routine quota (0 = unlimited)
+	Results     *RoutineResults     // Bounded store of recently completed routine results
+	Pools       map[string]Pool     // Worker pools drained on shutdown
+	Schedules   map[string]Schedule // Schedules stopped on shutdown
+	// Shutdown ordering among the other local managers of the app
+	ShutdownOrder ShutdownOrder
+	// Overrides of the app's shutdown timeout and routine defaults (zero values = inherited)
+	ShutdownTimeout time.Duration
+	Defaults        FunctionConfig
+	// Go() options (local.Option values) applied to every routine and to the routines of a function
+	// name, before the options passed to Go()
+	DefaultOptions  []interface{}
+	FunctionOptions map[string][]interface{}
+	Interceptors    []Interceptor // Wrap the worker runs of every routine of the local manager
+	interceptorIDs  []uint64      // IDs of Interceptors, for removing them (UseInterceptors)
 	// Atomic counter for lock-free reads of routine count
 	// Updated atomically when routines are added/removed
 	routineCount int64 // Use sync/atomic for operations
+	state        int32 // ManagerState (use sync/atomic)
 }
 
 // Routine represents a tracked goroutine
 type Routine struct {
+	routineMu    sync.RWMutex // Protects Ctx while a supervised routine is restarted
 	ID           string
 	FunctionName string
 	Ctx          context.Context
 	Cancel       context.CancelFunc
 	Done         <-chan struct{}
-	StartedAt    int64 // Unix timestamp or monotonic time
+	StartedAt    int64          // Unix timestamp or monotonic time
+	Result       *RoutineResult // Set before Done is closed
+	Restarts     int64          // Number of supervised restarts (use sync/atomic)
+	WaitGroup    string         // Function wait group the routine belongs to ("" if none)
+	Singleflight string         // Singleflight key later Go() calls join on ("" if none)
+	Options      RoutineOptions // Options in effect for the routine
 }
 
 type Metadata struct {
-	metadataMu *sync.RWMutex
+	metadataMu      *sync.RWMutex
 	MaxRoutines     int
 	Metrics         bool
 	MetricsURL      string
 	UpdateInterval  time.Duration
 	ShutdownTimeout time.Duration
+	Apps            map[string]AppConfig      // App and local manager settings applied when the managers are created
+	Functions       map[string]FunctionConfig // Defaults for the routines of a function name
 }